@@ -33,6 +33,30 @@ const ContainerLoaderKey ContextKey = "container_loader"
 // PalletLoaderKey declares a statically typed key for context reference in other packages
 const PalletLoaderKey ContextKey = "pallet_loader"
 
+// SkuLoaderKey declares a statically typed key for context reference in other packages
+const SkuLoaderKey ContextKey = "sku_loader"
+
+// ContractLoaderKey declares a statically typed key for context reference in other packages
+const ContractLoaderKey ContextKey = "contract_loader"
+
+// DistributorLoaderKey declares a statically typed key for context reference in other packages
+const DistributorLoaderKey ContextKey = "distributor_loader"
+
+// AddressLoaderKey declares a statically typed key for context reference in other packages
+const AddressLoaderKey ContextKey = "address_loader"
+
+// ContainerPalletsLoaderKey declares a statically typed key for context reference in other packages
+const ContainerPalletsLoaderKey ContextKey = "container_pallets_loader"
+
+// LocationLoaderKey declares a statically typed key for context reference in other packages
+const LocationLoaderKey ContextKey = "location_loader"
+
+// WarehouseLoaderKey declares a statically typed key for context reference in other packages
+const WarehouseLoaderKey ContextKey = "warehouse_loader"
+
+// LotLoaderKey declares a statically typed key for context reference in other packages
+const LotLoaderKey ContextKey = "lot_loader"
+
 // UserLoaderFromContext runs the dataloader inside the context
 func UserLoaderFromContext(ctx context.Context, id int64) (*models.User, error) {
 	return ctx.Value(UserLoaderKey).(*UserLoader).Load(id)
@@ -63,6 +87,46 @@ func PalletLoaderFromContext(ctx context.Context, id int64) (*models.Pallet, err
 	return ctx.Value(PalletLoaderKey).(*PalletLoader).Load(id)
 }
 
+// SkuLoaderFromContext runs the dataloader inside the context
+func SkuLoaderFromContext(ctx context.Context, id int64) (*models.Sku, error) {
+	return ctx.Value(SkuLoaderKey).(*SkuLoader).Load(id)
+}
+
+// ContractLoaderFromContext runs the dataloader inside the context
+func ContractLoaderFromContext(ctx context.Context, id int64) (*models.Contract, error) {
+	return ctx.Value(ContractLoaderKey).(*ContractLoader).Load(id)
+}
+
+// DistributorLoaderFromContext runs the dataloader inside the context
+func DistributorLoaderFromContext(ctx context.Context, id int64) (*models.Distributor, error) {
+	return ctx.Value(DistributorLoaderKey).(*DistributorLoader).Load(id)
+}
+
+// AddressLoaderFromContext runs the dataloader inside the context
+func AddressLoaderFromContext(ctx context.Context, id int64) (*models.Address, error) {
+	return ctx.Value(AddressLoaderKey).(*AddressLoader).Load(id)
+}
+
+// ContainerPalletsLoaderFromContext runs the dataloader of the pallets inside a container
+func ContainerPalletsLoaderFromContext(ctx context.Context, containerID int64) ([]models.Pallet, error) {
+	return ctx.Value(ContainerPalletsLoaderKey).(*ContainerPalletsLoader).Load(containerID)
+}
+
+// LocationLoaderFromContext runs the dataloader inside the context
+func LocationLoaderFromContext(ctx context.Context, id int64) (*models.Location, error) {
+	return ctx.Value(LocationLoaderKey).(*LocationLoader).Load(id)
+}
+
+// WarehouseLoaderFromContext runs the dataloader inside the context
+func WarehouseLoaderFromContext(ctx context.Context, id int64) (*models.Warehouse, error) {
+	return ctx.Value(WarehouseLoaderKey).(*WarehouseLoader).Load(id)
+}
+
+// LotLoaderFromContext runs the dataloader inside the context
+func LotLoaderFromContext(ctx context.Context, id int64) (*models.Lot, error) {
+	return ctx.Value(LotLoaderKey).(*LotLoader).Load(id)
+}
+
 // WithDataloaders returns a new context that contains dataloaders
 func WithDataloaders(
 	ctx context.Context,
@@ -221,12 +285,231 @@ func WithDataloaders(
 		},
 	)
 
+	skuLoader := NewSkuLoader(
+		SkuLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Sku, []error) {
+				data, err := dbstore.SkuStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Sku, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Sku, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	contractLoader := NewContractLoader(
+		ContractLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Contract, []error) {
+				data, err := dbstore.ContractStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Contract, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Contract, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	distributorLoader := NewDistributorLoader(
+		DistributorLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Distributor, []error) {
+				data, err := dbstore.DistributorStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Distributor, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Distributor, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	addressLoader := NewAddressLoader(
+		AddressLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Address, []error) {
+				data, err := dbstore.AddressStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Address, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Address, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	containerPalletsLoader := NewContainerPalletsLoader(
+		ContainerPalletsLoaderConfig{
+			Fetch: func(containerIDs []int64) ([][]models.Pallet, []error) {
+				data, err := dbstore.PalletStore.ListByContainerIDs(ctx, containerIDs)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// group the pallets by container in the order of the container ids
+				groups := make(map[int64][]models.Pallet, len(containerIDs))
+				for _, e := range data {
+					groups[e.ContainerID.Int64] = append(groups[e.ContainerID.Int64], e)
+				}
+
+				result := make([][]models.Pallet, len(containerIDs))
+				for i, key := range containerIDs {
+					result[i] = groups[key]
+					if result[i] == nil {
+						result[i] = []models.Pallet{}
+					}
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	locationLoader := NewLocationLoader(
+		LocationLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Location, []error) {
+				data, err := dbstore.LocationStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Location, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Location, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	warehouseLoader := NewWarehouseLoader(
+		WarehouseLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Warehouse, []error) {
+				data, err := dbstore.WarehouseStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Warehouse, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Warehouse, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
+	lotLoader := NewLotLoader(
+		LotLoaderConfig{
+			Fetch: func(ids []int64) ([]*models.Lot, []error) {
+				data, err := dbstore.LotStore.GetMany(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				// make result and ids of the same order
+				slice := make(map[interface{}]*models.Lot, len(data))
+				for _, e := range data {
+					slice[e.ID] = e
+				}
+
+				result := make([]*models.Lot, len(ids))
+				for i, key := range ids {
+					result[i] = slice[key]
+				}
+
+				return result, nil
+			},
+			Wait:     1 * time.Millisecond,
+			MaxBatch: 100,
+		},
+	)
+
 	ctx = context.WithValue(ctx, UserLoaderKey, userLoader)
 	ctx = context.WithValue(ctx, ProfileLoaderKey, profileLoader)
 	ctx = context.WithValue(ctx, OrganizationLoaderKey, organizationLoader)
 	ctx = context.WithValue(ctx, RoleLoaderKey, roleLoader)
 	ctx = context.WithValue(ctx, ContainerLoaderKey, containerLoader)
 	ctx = context.WithValue(ctx, PalletLoaderKey, palletLoader)
+	ctx = context.WithValue(ctx, SkuLoaderKey, skuLoader)
+	ctx = context.WithValue(ctx, ContractLoaderKey, contractLoader)
+	ctx = context.WithValue(ctx, DistributorLoaderKey, distributorLoader)
+	ctx = context.WithValue(ctx, AddressLoaderKey, addressLoader)
+	ctx = context.WithValue(ctx, ContainerPalletsLoaderKey, containerPalletsLoader)
+	ctx = context.WithValue(ctx, LocationLoaderKey, locationLoader)
+	ctx = context.WithValue(ctx, WarehouseLoaderKey, warehouseLoader)
+	ctx = context.WithValue(ctx, LotLoaderKey, lotLoader)
 	return ctx
 }
 
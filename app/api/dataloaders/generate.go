@@ -4,5 +4,13 @@
 //go:generate go run github.com/vektah/dataloaden RoleLoader int64 *orijinplus/app/models.Role
 //go:generate go run github.com/vektah/dataloaden ContainerLoader int64 *orijinplus/app/models.Container
 //go:generate go run github.com/vektah/dataloaden PalletLoader int64 *orijinplus/app/models.Pallet
+//go:generate go run github.com/vektah/dataloaden SkuLoader int64 *orijinplus/app/models.Sku
+//go:generate go run github.com/vektah/dataloaden ContractLoader int64 *orijinplus/app/models.Contract
+//go:generate go run github.com/vektah/dataloaden DistributorLoader int64 *orijinplus/app/models.Distributor
+//go:generate go run github.com/vektah/dataloaden AddressLoader int64 *orijinplus/app/models.Address
+//go:generate go run github.com/vektah/dataloaden ContainerPalletsLoader int64 []orijinplus/app/models.Pallet
+//go:generate go run github.com/vektah/dataloaden LocationLoader int64 *orijinplus/app/models.Location
+//go:generate go run github.com/vektah/dataloaden WarehouseLoader int64 *orijinplus/app/models.Warehouse
+//go:generate go run github.com/vektah/dataloaden LotLoader int64 *orijinplus/app/models.Lot
 
 package dataloaders
@@ -7,20 +7,103 @@ import (
 	"io"
 	"orijinplus/app/models"
 	"strconv"
+	"time"
 
 	"github.com/volatiletech/null"
 )
 
+type AddressInput struct {
+	Tag     string       `json:"tag"`
+	Line1   string       `json:"line1"`
+	Line2   string       `json:"line2"`
+	Line3   *null.String `json:"line3"`
+	City    string       `json:"city"`
+	State   string       `json:"state"`
+	Country string       `json:"country"`
+	Pincode string       `json:"pincode"`
+}
+
+type AttributeInput struct {
+	Key   string  `json:"key"`
+	Value *string `json:"value"`
+}
+
+type ConsumerOrderItemInput struct {
+	SkuID    int64 `json:"skuID"`
+	Quantity int   `json:"quantity"`
+}
+
+type ConsumerOrderResult struct {
+	ConsumerOrders []models.ConsumerOrder `json:"consumerOrders"`
+	Total          int                    `json:"total"`
+}
+
+type ContainerBulkResult struct {
+	Containers []models.Container `json:"containers"`
+	Total      int                `json:"total"`
+	LabelFile  *models.File       `json:"labelFile"`
+}
+
 type ContainerResult struct {
 	Containers []models.Container `json:"containers"`
 	Total      int                `json:"total"`
 }
 
+type ContractResult struct {
+	Contracts []models.Contract `json:"contracts"`
+	Total     int               `json:"total"`
+}
+
+type DistributorResult struct {
+	Distributors []models.Distributor `json:"distributors"`
+	Total        int                  `json:"total"`
+}
+
 type FileInput struct {
 	Name string `json:"name"`
 	URL  string `json:"url"`
 }
 
+type LabelTemplateInput struct {
+	PageWidth   float64 `json:"pageWidth"`
+	PageHeight  float64 `json:"pageHeight"`
+	LabelWidth  float64 `json:"labelWidth"`
+	LabelHeight float64 `json:"labelHeight"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	MarginLeft  float64 `json:"marginLeft"`
+	MarginTop   float64 `json:"marginTop"`
+	GapX        float64 `json:"gapX"`
+	GapY        float64 `json:"gapY"`
+	ShowQr      bool    `json:"showQR"`
+	ShowBarcode bool    `json:"showBarcode"`
+	ShowText    bool    `json:"showText"`
+}
+
+type LocationContents struct {
+	Pallets    []models.Pallet    `json:"pallets"`
+	Containers []models.Container `json:"containers"`
+}
+
+type NewAddress struct {
+	Tag            string       `json:"tag"`
+	Line1          string       `json:"line1"`
+	Line2          string       `json:"line2"`
+	Line3          *null.String `json:"line3"`
+	City           string       `json:"city"`
+	State          string       `json:"state"`
+	Country        string       `json:"country"`
+	Pincode        string       `json:"pincode"`
+	IsDefault      *bool        `json:"isDefault"`
+	OrganizationID *int64       `json:"organizationID"`
+}
+
+type NewConsumerOrder struct {
+	AddressID    int64                    `json:"addressID"`
+	Items        []ConsumerOrderItemInput `json:"items"`
+	WalletPoints *null.Int64              `json:"walletPoints"`
+}
+
 type NewCustomer struct {
 	FirstName     *null.String `json:"firstName"`
 	LastName      *null.String `json:"lastName"`
@@ -31,6 +114,13 @@ type NewCustomer struct {
 	PurchaseToken *null.String `json:"purchaseToken"`
 }
 
+type NewLot struct {
+	Code           string     `json:"code"`
+	SkuID          int64      `json:"skuID"`
+	ProductionDate time.Time  `json:"productionDate"`
+	ExpiryDate     *null.Time `json:"expiryDate"`
+}
+
 type NewMember struct {
 	FirstName      *null.String `json:"firstName"`
 	LastName       *null.String `json:"lastName"`
@@ -41,6 +131,16 @@ type NewMember struct {
 	RoleID         *null.String `json:"roleID"`
 }
 
+type NewRecall struct {
+	Title          string       `json:"title"`
+	Reason         *null.String `json:"reason"`
+	Scope          string       `json:"scope"`
+	SkuID          *null.Int64  `json:"skuID"`
+	LotID          *null.Int64  `json:"lotID"`
+	Uids           []string     `json:"uids"`
+	OrganizationID *null.Int64  `json:"organizationID"`
+}
+
 type NewRole struct {
 	Name           string   `json:"name"`
 	IsOrgAdmin     bool     `json:"isOrgAdmin"`
@@ -56,6 +156,26 @@ type NewSuperAdmin struct {
 	Password  *null.String `json:"password"`
 }
 
+type NewTrackAction struct {
+	Action      string                 `json:"action"`
+	ContainerID *null.Int64            `json:"containerID"`
+	PalletID    *null.Int64            `json:"palletID"`
+	Location    *null.String           `json:"location"`
+	Payload     map[string]interface{} `json:"payload"`
+	OccurredAt  *null.Time             `json:"occurredAt"`
+}
+
+type NewWalletAdjustment struct {
+	UserID int64  `json:"userID"`
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
+
+type OrderResult struct {
+	Orders []models.Order `json:"orders"`
+	Total  int            `json:"total"`
+}
+
 type OrganizationsResult struct {
 	Organizations []models.Organization `json:"organizations"`
 	Total         int                   `json:"total"`
@@ -66,11 +186,32 @@ type PageInfo struct {
 	EndCursor   int64 `json:"endCursor"`
 }
 
+type PalletBulkResult struct {
+	Pallets   []models.Pallet `json:"pallets"`
+	Total     int             `json:"total"`
+	LabelFile *models.File    `json:"labelFile"`
+}
+
 type PalletResult struct {
 	Pallets []models.Pallet `json:"pallets"`
 	Total   int             `json:"total"`
 }
 
+type PurchaseRecordResult struct {
+	PurchaseRecords []models.PurchaseRecord `json:"purchaseRecords"`
+	Total           int                     `json:"total"`
+}
+
+type RecallResult struct {
+	Recalls []models.Recall `json:"recalls"`
+	Total   int             `json:"total"`
+}
+
+type ReferralResult struct {
+	Referrals []models.Referral `json:"referrals"`
+	Total     int               `json:"total"`
+}
+
 type RolesResult struct {
 	Roles []models.Role `json:"roles"`
 	Total int           `json:"total"`
@@ -83,9 +224,106 @@ type SearchFilter struct {
 	SortDir *SortDir      `json:"sortDir"`
 }
 
+type ShipmentResult struct {
+	Shipments []models.Shipment `json:"shipments"`
+	Total     int               `json:"total"`
+}
+
+type SkuResult struct {
+	Skus  []models.Sku `json:"skus"`
+	Total int          `json:"total"`
+}
+
+type TaskResult struct {
+	Tasks []models.Task `json:"tasks"`
+	Total int           `json:"total"`
+}
+
+type TrackActionResult struct {
+	TrackActions []models.TrackAction `json:"trackActions"`
+	Total        int                  `json:"total"`
+}
+
+type UpdateAddress struct {
+	Tag       *null.String `json:"tag"`
+	Line1     *null.String `json:"line1"`
+	Line2     *null.String `json:"line2"`
+	Line3     *null.String `json:"line3"`
+	City      *null.String `json:"city"`
+	State     *null.String `json:"state"`
+	Country   *null.String `json:"country"`
+	Pincode   *null.String `json:"pincode"`
+	IsDefault *null.Bool   `json:"isDefault"`
+}
+
+type UpdateAttributeDefinition struct {
+	Entity     string       `json:"entity"`
+	Key        string       `json:"key"`
+	Label      *null.String `json:"label"`
+	Type       string       `json:"type"`
+	Required   *bool        `json:"required"`
+	EnumValues []string     `json:"enumValues"`
+}
+
+type UpdateCodeFormat struct {
+	Entity      string `json:"entity"`
+	Prefix      string `json:"prefix"`
+	Padding     *int   `json:"padding"`
+	ResetYearly *bool  `json:"resetYearly"`
+}
+
 type UpdateContainer struct {
-	Description    *null.String `json:"description"`
-	OrganizationID *null.Int64  `json:"organizationID"`
+	Description    *null.String     `json:"description"`
+	OrganizationID *null.Int64      `json:"organizationID"`
+	Attributes     []AttributeInput `json:"attributes"`
+}
+
+type UpdateContract struct {
+	Title               *null.String `json:"title"`
+	Description         *null.String `json:"description"`
+	BuyerOrganizationID *null.Int64  `json:"buyerOrganizationID"`
+	StartDate           *null.Time   `json:"startDate"`
+	EndDate             *null.Time   `json:"endDate"`
+	PricingTerms        *null.String `json:"pricingTerms"`
+	VolumeTerms         *null.String `json:"volumeTerms"`
+	OrganizationID      *null.Int64  `json:"organizationID"`
+}
+
+type UpdateDistributor struct {
+	Name           *null.String  `json:"name"`
+	ContactName    *null.String  `json:"contactName"`
+	Email          *null.String  `json:"email"`
+	Phone          *null.String  `json:"phone"`
+	Regions        []string      `json:"regions"`
+	Address        *AddressInput `json:"address"`
+	OrganizationID *null.Int64   `json:"organizationID"`
+}
+
+type UpdateGS1Settings struct {
+	CompanyPrefix  string `json:"companyPrefix"`
+	ExtensionDigit *int   `json:"extensionDigit"`
+}
+
+type UpdateLocation struct {
+	Code        *null.String `json:"code"`
+	Name        *null.String `json:"name"`
+	Kind        *null.String `json:"kind"`
+	WarehouseID *null.Int64  `json:"warehouseID"`
+	ParentID    *null.Int64  `json:"parentID"`
+}
+
+type UpdateLot struct {
+	Code           *null.String `json:"code"`
+	ProductionDate *null.Time   `json:"productionDate"`
+	ExpiryDate     *null.Time   `json:"expiryDate"`
+}
+
+type UpdateOrder struct {
+	Notes               *null.String `json:"notes"`
+	BuyerOrganizationID *null.Int64  `json:"buyerOrganizationID"`
+	ContractID          *null.Int64  `json:"contractID"`
+	DistributorID       *null.Int64  `json:"distributorID"`
+	OrganizationID      *null.Int64  `json:"organizationID"`
 }
 
 type UpdateOrganization struct {
@@ -95,17 +333,72 @@ type UpdateOrganization struct {
 }
 
 type UpdatePallet struct {
-	Description    *null.String `json:"description"`
-	ContainerID    *null.Int64  `json:"containerID"`
+	Description    *null.String     `json:"description"`
+	ContainerID    *null.Int64      `json:"containerID"`
+	OrganizationID *null.Int64      `json:"organizationID"`
+	Attributes     []AttributeInput `json:"attributes"`
+}
+
+type UpdateProvenanceSettings struct {
+	IsPublic         *bool `json:"isPublic"`
+	ShowOrganization *bool `json:"showOrganization"`
+	ShowDescription  *bool `json:"showDescription"`
+	ShowLocations    *bool `json:"showLocations"`
+	ShowEventDetails *bool `json:"showEventDetails"`
+	ShowAnchoring    *bool `json:"showAnchoring"`
+}
+
+type UpdatePurchaseRecord struct {
+	ProductUID     *null.String `json:"productUID"`
+	BuyerEmail     *null.String `json:"buyerEmail"`
+	BuyerPhone     *null.String `json:"buyerPhone"`
+	Retailer       *null.String `json:"retailer"`
+	Points         *null.Int64  `json:"points"`
 	OrganizationID *null.Int64  `json:"organizationID"`
 }
 
+type UpdateReferralRule struct {
+	ReferrerPoints *null.Int64 `json:"referrerPoints"`
+	RefereePoints  *null.Int64 `json:"refereePoints"`
+	IsActive       *null.Bool  `json:"isActive"`
+}
+
 type UpdateRole struct {
 	Name        *null.String `json:"name"`
 	Permissions []string     `json:"permissions"`
 	IsArchived  *null.Bool   `json:"isArchived"`
 }
 
+type UpdateShipment struct {
+	OriginAddressID      *null.Int64  `json:"originAddressID"`
+	DestinationAddressID *null.Int64  `json:"destinationAddressID"`
+	Carrier              *null.String `json:"carrier"`
+	TransportReference   *null.String `json:"transportReference"`
+	Etd                  *null.Time   `json:"etd"`
+	Eta                  *null.Time   `json:"eta"`
+	Notes                *null.String `json:"notes"`
+	OrganizationID       *null.Int64  `json:"organizationID"`
+}
+
+type UpdateSku struct {
+	Name           *null.String `json:"name"`
+	Description    *null.String `json:"description"`
+	Price          *null.Int64  `json:"price"`
+	Gtin           *null.String `json:"gtin"`
+	OrganizationID *null.Int64  `json:"organizationID"`
+}
+
+type UpdateTask struct {
+	Title          *null.String `json:"title"`
+	Description    *null.String `json:"description"`
+	DueDate        *null.Time   `json:"dueDate"`
+	AssigneeUserID *null.Int64  `json:"assigneeUserID"`
+	AssigneeRoleID *null.Int64  `json:"assigneeRoleID"`
+	ContainerID    *null.Int64  `json:"containerID"`
+	PalletID       *null.Int64  `json:"palletID"`
+	OrganizationID *null.Int64  `json:"organizationID"`
+}
+
 type UpdateUser struct {
 	FirstName *null.String `json:"firstName"`
 	LastName  *null.String `json:"lastName"`
@@ -115,11 +408,27 @@ type UpdateUser struct {
 	Password  *null.String `json:"password"`
 }
 
+type UpdateWarehouse struct {
+	Name           *null.String `json:"name"`
+	AddressID      *null.Int64  `json:"addressID"`
+	OrganizationID *null.Int64  `json:"organizationID"`
+}
+
 type UserResult struct {
 	Users []models.User `json:"users"`
 	Total int           `json:"total"`
 }
 
+type WalletPointEntryResult struct {
+	WalletPointEntries []models.WalletPointEntry `json:"walletPointEntries"`
+	Total              int                       `json:"total"`
+}
+
+type WarehouseResult struct {
+	Warehouses []models.Warehouse `json:"warehouses"`
+	Total      int                `json:"total"`
+}
+
 type FilterOption string
 
 const (
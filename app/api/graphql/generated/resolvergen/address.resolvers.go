@@ -0,0 +1,52 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *addressResolver) Organization(ctx context.Context, obj *models.Address) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) AddressCreate(ctx context.Context, input graph.NewAddress) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) AddressUpdate(ctx context.Context, id int64, input graph.UpdateAddress) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) AddressSetDefault(ctx context.Context, id int64) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) AddressDelete(ctx context.Context, id int64) (bool, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) MyAddresses(ctx context.Context) ([]models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrganizationAddresses(ctx context.Context, organizationID int64) ([]models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) AddressByID(ctx context.Context, id int64) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Address returns graph.AddressResolver implementation.
+func (r *Resolver) Address() graph.AddressResolver { return &addressResolver{r} }
+
+// Query returns graph.QueryResolver implementation.
+func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
+
+type addressResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
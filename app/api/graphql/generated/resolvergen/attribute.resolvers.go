@@ -0,0 +1,34 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *attributeDefinitionResolver) Organization(ctx context.Context, obj *models.AttributeDefinition) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrganizationAttributeDefinitionSet(ctx context.Context, organizationID int64, input graph.UpdateAttributeDefinition) (*models.AttributeDefinition, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrganizationAttributeDefinitionDelete(ctx context.Context, id int64) (bool, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrganizationAttributeDefinitions(ctx context.Context, organizationID int64) ([]models.AttributeDefinition, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// AttributeDefinition returns graph.AttributeDefinitionResolver implementation.
+func (r *Resolver) AttributeDefinition() graph.AttributeDefinitionResolver {
+	return &attributeDefinitionResolver{r}
+}
+
+type attributeDefinitionResolver struct{ *Resolver }
@@ -0,0 +1,78 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *consumerOrderResolver) UID(ctx context.Context, obj *models.ConsumerOrder) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *consumerOrderResolver) Customer(ctx context.Context, obj *models.ConsumerOrder) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *consumerOrderResolver) Address(ctx context.Context, obj *models.ConsumerOrder) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *consumerOrderResolver) Items(ctx context.Context, obj *models.ConsumerOrder) ([]models.ConsumerOrderItem, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *consumerOrderResolver) AmountDue(ctx context.Context, obj *models.ConsumerOrder) (int, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *consumerOrderResolver) Organization(ctx context.Context, obj *models.ConsumerOrder) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *consumerOrderItemResolver) Sku(ctx context.Context, obj *models.ConsumerOrderItem) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ConsumerOrderCreate(ctx context.Context, input graph.NewConsumerOrder) (*models.ConsumerOrder, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ConsumerOrderUpdateStatus(ctx context.Context, id int64, status string) (*models.ConsumerOrder, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ConsumerOrders(ctx context.Context, search graph.SearchFilter, limit int, offset int, status *string) (*graph.ConsumerOrderResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) MyConsumerOrders(ctx context.Context, search graph.SearchFilter, limit int, offset int, status *string) (*graph.ConsumerOrderResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ConsumerOrderByID(ctx context.Context, id int64) (*models.ConsumerOrder, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ConsumerOrderByUID(ctx context.Context, uid string) (*models.ConsumerOrder, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ConsumerOrderByCode(ctx context.Context, code string) (*models.ConsumerOrder, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// ConsumerOrder returns graph.ConsumerOrderResolver implementation.
+func (r *Resolver) ConsumerOrder() graph.ConsumerOrderResolver { return &consumerOrderResolver{r} }
+
+// ConsumerOrderItem returns graph.ConsumerOrderItemResolver implementation.
+func (r *Resolver) ConsumerOrderItem() graph.ConsumerOrderItemResolver {
+	return &consumerOrderItemResolver{r}
+}
+
+type consumerOrderResolver struct{ *Resolver }
+type consumerOrderItemResolver struct{ *Resolver }
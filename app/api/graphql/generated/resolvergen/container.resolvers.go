@@ -18,14 +18,94 @@ func (r *containerResolver) Organization(ctx context.Context, obj *models.Contai
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *containerResolver) Pallets(ctx context.Context, obj *models.Container) ([]models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) PalletCount(ctx context.Context, obj *models.Container) (int, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) Timeline(ctx context.Context, obj *models.Container) ([]models.TrackAction, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) Transitions(ctx context.Context, obj *models.Container) ([]models.ContainerTransition, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) Location(ctx context.Context, obj *models.Container) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) LocationHistory(ctx context.Context, obj *models.Container) ([]models.LocationMove, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) Attributes(ctx context.Context, obj *models.Container) ([]models.Attribute, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) Attachments(ctx context.Context, obj *models.Container) ([]models.StoredFile, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerResolver) Recalls(ctx context.Context, obj *models.Container) ([]models.Recall, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerTransitionResolver) Container(ctx context.Context, obj *models.ContainerTransition) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *containerTransitionResolver) Actor(ctx context.Context, obj *models.ContainerTransition) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *mutationResolver) ContainerCreate(ctx context.Context, input graph.UpdateContainer) (*models.Container, error) {
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *mutationResolver) ContainerCreateBulk(ctx context.Context, count int, input graph.UpdateContainer, withLabels *bool) (*graph.ContainerBulkResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *mutationResolver) ContainerUpdate(ctx context.Context, id int64, input graph.UpdateContainer) (*models.Container, error) {
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *mutationResolver) ContainerStartPacking(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerReopen(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerSeal(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerDispatch(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerArrive(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerUnpack(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerPlace(ctx context.Context, id int64, locationID *int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContainerSSCCAssign(ctx context.Context, id int64) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *mutationResolver) ContainerArchive(ctx context.Context, id int64) (*models.Container, error) {
 	panic(fmt.Errorf("not implemented"))
 }
@@ -34,7 +114,7 @@ func (r *mutationResolver) ContainerUnarchive(ctx context.Context, id int64) (*m
 	panic(fmt.Errorf("not implemented"))
 }
 
-func (r *queryResolver) Containers(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ContainerResult, error) {
+func (r *queryResolver) Containers(ctx context.Context, search graph.SearchFilter, limit int, offset int, attributes []models.AttributeFilter) (*graph.ContainerResult, error) {
 	panic(fmt.Errorf("not implemented"))
 }
 
@@ -50,11 +130,17 @@ func (r *queryResolver) ContainerByCode(ctx context.Context, code string) (*mode
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *queryResolver) ContainerBySscc(ctx context.Context, sscc string) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 // Container returns graph.ContainerResolver implementation.
 func (r *Resolver) Container() graph.ContainerResolver { return &containerResolver{r} }
 
-// Query returns graph.QueryResolver implementation.
-func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
+// ContainerTransition returns graph.ContainerTransitionResolver implementation.
+func (r *Resolver) ContainerTransition() graph.ContainerTransitionResolver {
+	return &containerTransitionResolver{r}
+}
 
 type containerResolver struct{ *Resolver }
-type queryResolver struct{ *Resolver }
+type containerTransitionResolver struct{ *Resolver }
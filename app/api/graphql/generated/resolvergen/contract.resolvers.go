@@ -0,0 +1,102 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *contractResolver) UID(ctx context.Context, obj *models.Contract) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) Status(ctx context.Context, obj *models.Contract) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) Organization(ctx context.Context, obj *models.Contract) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) Buyer(ctx context.Context, obj *models.Contract) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) SupplierApprovedBy(ctx context.Context, obj *models.Contract) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) BuyerApprovedBy(ctx context.Context, obj *models.Contract) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) Documents(ctx context.Context, obj *models.Contract) ([]models.ContractDocument, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractResolver) Orders(ctx context.Context, obj *models.Contract) ([]models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *contractDocumentResolver) UploadedBy(ctx context.Context, obj *models.ContractDocument) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractCreate(ctx context.Context, input graph.UpdateContract) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractUpdate(ctx context.Context, id int64, input graph.UpdateContract) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractApprove(ctx context.Context, id int64) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractAddDocument(ctx context.Context, id int64, file graph.FileInput) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractRemoveDocument(ctx context.Context, id int64) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractArchive(ctx context.Context, id int64) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ContractUnarchive(ctx context.Context, id int64) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Contracts(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ContractResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ContractByID(ctx context.Context, id int64) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ContractByUID(ctx context.Context, uid string) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ContractByCode(ctx context.Context, code string) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Contract returns graph.ContractResolver implementation.
+func (r *Resolver) Contract() graph.ContractResolver { return &contractResolver{r} }
+
+// ContractDocument returns graph.ContractDocumentResolver implementation.
+func (r *Resolver) ContractDocument() graph.ContractDocumentResolver {
+	return &contractDocumentResolver{r}
+}
+
+type contractResolver struct{ *Resolver }
+type contractDocumentResolver struct{ *Resolver }
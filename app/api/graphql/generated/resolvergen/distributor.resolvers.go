@@ -0,0 +1,68 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *distributorResolver) UID(ctx context.Context, obj *models.Distributor) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *distributorResolver) Address(ctx context.Context, obj *models.Distributor) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *distributorResolver) Organization(ctx context.Context, obj *models.Distributor) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *distributorResolver) Orders(ctx context.Context, obj *models.Distributor) ([]models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *distributorResolver) Pallets(ctx context.Context, obj *models.Distributor) ([]models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) DistributorCreate(ctx context.Context, input graph.UpdateDistributor) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) DistributorUpdate(ctx context.Context, id int64, input graph.UpdateDistributor) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) DistributorArchive(ctx context.Context, id int64) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) DistributorUnarchive(ctx context.Context, id int64) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Distributors(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.DistributorResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) DistributorByID(ctx context.Context, id int64) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) DistributorByUID(ctx context.Context, uid string) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) DistributorByCode(ctx context.Context, code string) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Distributor returns graph.DistributorResolver implementation.
+func (r *Resolver) Distributor() graph.DistributorResolver { return &distributorResolver{r} }
+
+type distributorResolver struct{ *Resolver }
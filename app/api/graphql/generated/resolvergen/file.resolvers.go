@@ -20,7 +20,35 @@ func (r *mutationResolver) FileUploadMultiple(ctx context.Context, files []graph
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *mutationResolver) FileAttach(ctx context.Context, id int64, ownerType string, ownerID int64) (*models.StoredFile, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) FileDetach(ctx context.Context, id int64) (*models.StoredFile, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) FileByID(ctx context.Context, id int64) (*models.StoredFile, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *storedFileResolver) Organization(ctx context.Context, obj *models.StoredFile) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *storedFileResolver) UploadedBy(ctx context.Context, obj *models.StoredFile) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *storedFileResolver) DownloadURL(ctx context.Context, obj *models.StoredFile) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 // Mutation returns graph.MutationResolver implementation.
 func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
 
+// StoredFile returns graph.StoredFileResolver implementation.
+func (r *Resolver) StoredFile() graph.StoredFileResolver { return &storedFileResolver{r} }
+
 type mutationResolver struct{ *Resolver }
+type storedFileResolver struct{ *Resolver }
@@ -0,0 +1,19 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) LabelSheetCreate(ctx context.Context, kind string, ids []int64, template *string, customTemplate *graph.LabelTemplateInput, format *string) (*models.File, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) LabelTemplates(ctx context.Context) ([]models.LabelTemplate, error) {
+	panic(fmt.Errorf("not implemented"))
+}
@@ -0,0 +1,68 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *lotResolver) UID(ctx context.Context, obj *models.Lot) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *lotResolver) Sku(ctx context.Context, obj *models.Lot) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *lotResolver) Organization(ctx context.Context, obj *models.Lot) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *lotResolver) Pallets(ctx context.Context, obj *models.Lot) ([]models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *lotResolver) CreatedBy(ctx context.Context, obj *models.Lot) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LotCreate(ctx context.Context, input graph.NewLot) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LotUpdate(ctx context.Context, id int64, input graph.UpdateLot) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LotAssignPallets(ctx context.Context, id int64, palletIDs []int64) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LotUnassignPallet(ctx context.Context, id int64, palletID int64) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Lots(ctx context.Context, skuID int64) ([]models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) LotByID(ctx context.Context, id int64) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) LotsExpiring(ctx context.Context, days int) ([]models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) FefoPallets(ctx context.Context, skuID int64, limit *int) ([]models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Lot returns graph.LotResolver implementation.
+func (r *Resolver) Lot() graph.LotResolver { return &lotResolver{r} }
+
+type lotResolver struct{ *Resolver }
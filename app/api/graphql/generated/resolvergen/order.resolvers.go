@@ -0,0 +1,100 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) OrderCreate(ctx context.Context, input graph.UpdateOrder) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderUpdate(ctx context.Context, id int64, input graph.UpdateOrder) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderUpdateStatus(ctx context.Context, id int64, status string) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderAddItem(ctx context.Context, orderID int64, skuID int64, quantity int) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderUpdateItem(ctx context.Context, id int64, quantity int) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderRemoveItem(ctx context.Context, id int64) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderAllocatePallet(ctx context.Context, orderID int64, palletID int64) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrderDeallocatePallet(ctx context.Context, orderID int64, palletID int64) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) UID(ctx context.Context, obj *models.Order) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) Organization(ctx context.Context, obj *models.Order) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) Buyer(ctx context.Context, obj *models.Order) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) Contract(ctx context.Context, obj *models.Order) (*models.Contract, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) Distributor(ctx context.Context, obj *models.Order) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) Items(ctx context.Context, obj *models.Order) ([]models.OrderItem, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderResolver) Pallets(ctx context.Context, obj *models.Order) ([]models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *orderItemResolver) Sku(ctx context.Context, obj *models.OrderItem) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Orders(ctx context.Context, search graph.SearchFilter, limit int, offset int, status *string) (*graph.OrderResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrderByID(ctx context.Context, id int64) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrderByUID(ctx context.Context, uid string) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrderByCode(ctx context.Context, code string) (*models.Order, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Order returns graph.OrderResolver implementation.
+func (r *Resolver) Order() graph.OrderResolver { return &orderResolver{r} }
+
+// OrderItem returns graph.OrderItemResolver implementation.
+func (r *Resolver) OrderItem() graph.OrderItemResolver { return &orderItemResolver{r} }
+
+type orderResolver struct{ *Resolver }
+type orderItemResolver struct{ *Resolver }
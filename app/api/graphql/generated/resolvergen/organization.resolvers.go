@@ -10,10 +10,46 @@ import (
 	"orijinplus/app/models"
 )
 
+func (r *codeFormatResolver) Organization(ctx context.Context, obj *models.CodeFormat) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *codeFormatResolver) Example(ctx context.Context, obj *models.CodeFormat) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *gS1SettingsResolver) Organization(ctx context.Context, obj *models.GS1Settings) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *mutationResolver) OrganizationUpdate(ctx context.Context, id int64, input graph.UpdateOrganization) (*models.Organization, error) {
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *mutationResolver) OrganizationCodeFormatSet(ctx context.Context, organizationID int64, input graph.UpdateCodeFormat) (*models.CodeFormat, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrganizationCodeFormatDelete(ctx context.Context, organizationID int64, entity string) (bool, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrganizationProvenanceSettingsSet(ctx context.Context, organizationID int64, input graph.UpdateProvenanceSettings) (*models.ProvenanceSettings, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) OrganizationGS1SettingsSet(ctx context.Context, organizationID int64, input graph.UpdateGS1Settings) (*models.GS1Settings, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *organizationResolver) Attachments(ctx context.Context, obj *models.Organization) ([]models.StoredFile, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *provenanceSettingsResolver) Organization(ctx context.Context, obj *models.ProvenanceSettings) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *queryResolver) Organizations(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.OrganizationsResult, error) {
 	panic(fmt.Errorf("not implemented"))
 }
@@ -29,3 +65,34 @@ func (r *queryResolver) OrganizationByID(ctx context.Context, id int64) (*models
 func (r *queryResolver) OrganizationByCode(ctx context.Context, code string) (*models.Organization, error) {
 	panic(fmt.Errorf("not implemented"))
 }
+
+func (r *queryResolver) OrganizationCodeFormats(ctx context.Context, organizationID int64) ([]models.CodeFormat, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrganizationProvenanceSettings(ctx context.Context, organizationID int64) (*models.ProvenanceSettings, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) OrganizationGS1Settings(ctx context.Context, organizationID int64) (*models.GS1Settings, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// CodeFormat returns graph.CodeFormatResolver implementation.
+func (r *Resolver) CodeFormat() graph.CodeFormatResolver { return &codeFormatResolver{r} }
+
+// GS1Settings returns graph.GS1SettingsResolver implementation.
+func (r *Resolver) GS1Settings() graph.GS1SettingsResolver { return &gS1SettingsResolver{r} }
+
+// Organization returns graph.OrganizationResolver implementation.
+func (r *Resolver) Organization() graph.OrganizationResolver { return &organizationResolver{r} }
+
+// ProvenanceSettings returns graph.ProvenanceSettingsResolver implementation.
+func (r *Resolver) ProvenanceSettings() graph.ProvenanceSettingsResolver {
+	return &provenanceSettingsResolver{r}
+}
+
+type codeFormatResolver struct{ *Resolver }
+type gS1SettingsResolver struct{ *Resolver }
+type organizationResolver struct{ *Resolver }
+type provenanceSettingsResolver struct{ *Resolver }
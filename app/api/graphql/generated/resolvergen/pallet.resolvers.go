@@ -14,10 +14,30 @@ func (r *mutationResolver) PalletCreate(ctx context.Context, input graph.UpdateP
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *mutationResolver) PalletCreateBulk(ctx context.Context, count int, input graph.UpdatePallet, withLabels *bool) (*graph.PalletBulkResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *mutationResolver) PalletUpdate(ctx context.Context, id int64, input graph.UpdatePallet) (*models.Pallet, error) {
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *mutationResolver) PalletMove(ctx context.Context, palletID int64, toContainerID int64) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) PalletUnload(ctx context.Context, palletID int64) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) PalletPlace(ctx context.Context, palletID int64, locationID *int64) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) PalletSSCCAssign(ctx context.Context, id int64) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *mutationResolver) PalletArchive(ctx context.Context, id int64) (*models.Pallet, error) {
 	panic(fmt.Errorf("not implemented"))
 }
@@ -38,7 +58,59 @@ func (r *palletResolver) Organization(ctx context.Context, obj *models.Pallet) (
 	panic(fmt.Errorf("not implemented"))
 }
 
-func (r *queryResolver) Pallets(ctx context.Context, search graph.SearchFilter, limit int, offset int, containerID *int64) (*graph.PalletResult, error) {
+func (r *palletResolver) Distributor(ctx context.Context, obj *models.Pallet) (*models.Distributor, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) Timeline(ctx context.Context, obj *models.Pallet) ([]models.TrackAction, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) History(ctx context.Context, obj *models.Pallet) ([]models.PalletAssignment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) Location(ctx context.Context, obj *models.Pallet) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) LocationHistory(ctx context.Context, obj *models.Pallet) ([]models.LocationMove, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) Lot(ctx context.Context, obj *models.Pallet) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) Attributes(ctx context.Context, obj *models.Pallet) ([]models.Attribute, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) Attachments(ctx context.Context, obj *models.Pallet) ([]models.StoredFile, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletResolver) Recalls(ctx context.Context, obj *models.Pallet) ([]models.Recall, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletAssignmentResolver) Pallet(ctx context.Context, obj *models.PalletAssignment) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletAssignmentResolver) Container(ctx context.Context, obj *models.PalletAssignment) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletAssignmentResolver) LoadedBy(ctx context.Context, obj *models.PalletAssignment) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *palletAssignmentResolver) UnloadedBy(ctx context.Context, obj *models.PalletAssignment) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Pallets(ctx context.Context, search graph.SearchFilter, limit int, offset int, containerID *int64, attributes []models.AttributeFilter) (*graph.PalletResult, error) {
 	panic(fmt.Errorf("not implemented"))
 }
 
@@ -54,7 +126,21 @@ func (r *queryResolver) PalletByCode(ctx context.Context, code string) (*models.
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *queryResolver) PalletBySscc(ctx context.Context, sscc string) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) PalletHistory(ctx context.Context, palletID int64) ([]models.PalletAssignment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 // Pallet returns graph.PalletResolver implementation.
 func (r *Resolver) Pallet() graph.PalletResolver { return &palletResolver{r} }
 
+// PalletAssignment returns graph.PalletAssignmentResolver implementation.
+func (r *Resolver) PalletAssignment() graph.PalletAssignmentResolver {
+	return &palletAssignmentResolver{r}
+}
+
 type palletResolver struct{ *Resolver }
+type palletAssignmentResolver struct{ *Resolver }
@@ -0,0 +1,68 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) PurchaseRecordCreate(ctx context.Context, input graph.UpdatePurchaseRecord) (*models.PurchaseRecord, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) PurchaseRecordUpdate(ctx context.Context, id int64, input graph.UpdatePurchaseRecord) (*models.PurchaseRecord, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) PurchaseRedeem(ctx context.Context, token string) (*models.PurchaseRecord, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *purchaseRecordResolver) UID(ctx context.Context, obj *models.PurchaseRecord) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *purchaseRecordResolver) ProductUID(ctx context.Context, obj *models.PurchaseRecord) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *purchaseRecordResolver) RedeemedBy(ctx context.Context, obj *models.PurchaseRecord) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *purchaseRecordResolver) Organization(ctx context.Context, obj *models.PurchaseRecord) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *purchaseRecordResolver) CreatedBy(ctx context.Context, obj *models.PurchaseRecord) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) PurchaseRecords(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.PurchaseRecordResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) MyPurchaseRecords(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.PurchaseRecordResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) PurchaseRecordByID(ctx context.Context, id int64) (*models.PurchaseRecord, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) PurchaseRecordByUID(ctx context.Context, uid string) (*models.PurchaseRecord, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) PurchaseRecordByCode(ctx context.Context, code string) (*models.PurchaseRecord, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// PurchaseRecord returns graph.PurchaseRecordResolver implementation.
+func (r *Resolver) PurchaseRecord() graph.PurchaseRecordResolver { return &purchaseRecordResolver{r} }
+
+type purchaseRecordResolver struct{ *Resolver }
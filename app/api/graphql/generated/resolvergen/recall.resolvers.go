@@ -0,0 +1,96 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) RecallCreate(ctx context.Context, input graph.NewRecall) (*models.Recall, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) RecallResolveItem(ctx context.Context, id int64, itemID int64) (*models.RecallItem, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) RecallClose(ctx context.Context, id int64) (*models.Recall, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) NotificationMarkRead(ctx context.Context, id int64) (*models.Notification, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *notificationResolver) Organization(ctx context.Context, obj *models.Notification) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Recalls(ctx context.Context, limit int, offset int) (*graph.RecallResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) RecallByID(ctx context.Context, id int64) (*models.Recall, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) MyNotifications(ctx context.Context) ([]models.Notification, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) UID(ctx context.Context, obj *models.Recall) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) Sku(ctx context.Context, obj *models.Recall) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) Lot(ctx context.Context, obj *models.Recall) (*models.Lot, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) Organization(ctx context.Context, obj *models.Recall) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) Items(ctx context.Context, obj *models.Recall) ([]models.RecallItem, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) Progress(ctx context.Context, obj *models.Recall) (*models.RecallProgress, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallResolver) CreatedBy(ctx context.Context, obj *models.Recall) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallItemResolver) Organization(ctx context.Context, obj *models.RecallItem) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallItemResolver) Customer(ctx context.Context, obj *models.RecallItem) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *recallItemResolver) ResolvedBy(ctx context.Context, obj *models.RecallItem) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Notification returns graph.NotificationResolver implementation.
+func (r *Resolver) Notification() graph.NotificationResolver { return &notificationResolver{r} }
+
+// Recall returns graph.RecallResolver implementation.
+func (r *Resolver) Recall() graph.RecallResolver { return &recallResolver{r} }
+
+// RecallItem returns graph.RecallItemResolver implementation.
+func (r *Resolver) RecallItem() graph.RecallItemResolver { return &recallItemResolver{r} }
+
+type notificationResolver struct{ *Resolver }
+type recallResolver struct{ *Resolver }
+type recallItemResolver struct{ *Resolver }
@@ -0,0 +1,56 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) ReferralRuleCreate(ctx context.Context, input graph.UpdateReferralRule) (*models.ReferralRule, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ReferralRuleUpdate(ctx context.Context, id int64, input graph.UpdateReferralRule) (*models.ReferralRule, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Referrals(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ReferralResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) MyReferrals(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ReferralResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ReferralStats(ctx context.Context, userID *int64) (*models.ReferralStats, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ReferralRules(ctx context.Context) ([]models.ReferralRule, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *referralResolver) Referrer(ctx context.Context, obj *models.Referral) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *referralResolver) Referee(ctx context.Context, obj *models.Referral) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *referralRuleResolver) CreatedBy(ctx context.Context, obj *models.ReferralRule) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Referral returns graph.ReferralResolver implementation.
+func (r *Resolver) Referral() graph.ReferralResolver { return &referralResolver{r} }
+
+// ReferralRule returns graph.ReferralRuleResolver implementation.
+func (r *Resolver) ReferralRule() graph.ReferralRuleResolver { return &referralRuleResolver{r} }
+
+type referralResolver struct{ *Resolver }
+type referralRuleResolver struct{ *Resolver }
@@ -0,0 +1,80 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) ShipmentCreate(ctx context.Context, input graph.UpdateShipment) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentUpdate(ctx context.Context, id int64, input graph.UpdateShipment) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentAddContainer(ctx context.Context, id int64, containerID int64) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentRemoveContainer(ctx context.Context, id int64, containerID int64) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentDepart(ctx context.Context, id int64) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentArrive(ctx context.Context, id int64) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentCancel(ctx context.Context, id int64) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) ShipmentManifest(ctx context.Context, id int64, format string) (*models.File, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Shipments(ctx context.Context, limit int, offset int) (*graph.ShipmentResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) ShipmentByID(ctx context.Context, id int64) (*models.Shipment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *shipmentResolver) UID(ctx context.Context, obj *models.Shipment) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *shipmentResolver) OriginAddress(ctx context.Context, obj *models.Shipment) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *shipmentResolver) DestinationAddress(ctx context.Context, obj *models.Shipment) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *shipmentResolver) Organization(ctx context.Context, obj *models.Shipment) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *shipmentResolver) Containers(ctx context.Context, obj *models.Shipment) ([]models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *shipmentResolver) CreatedBy(ctx context.Context, obj *models.Shipment) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Shipment returns graph.ShipmentResolver implementation.
+func (r *Resolver) Shipment() graph.ShipmentResolver { return &shipmentResolver{r} }
+
+type shipmentResolver struct{ *Resolver }
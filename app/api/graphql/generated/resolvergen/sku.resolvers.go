@@ -0,0 +1,56 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) SkuCreate(ctx context.Context, input graph.UpdateSku) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) SkuUpdate(ctx context.Context, id int64, input graph.UpdateSku) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) SkuArchive(ctx context.Context, id int64) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) SkuUnarchive(ctx context.Context, id int64) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Skus(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.SkuResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) SkuByID(ctx context.Context, id int64) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) SkuByUID(ctx context.Context, uid string) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) SkuByCode(ctx context.Context, code string) (*models.Sku, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *skuResolver) UID(ctx context.Context, obj *models.Sku) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *skuResolver) Organization(ctx context.Context, obj *models.Sku) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Sku returns graph.SkuResolver implementation.
+func (r *Resolver) Sku() graph.SkuResolver { return &skuResolver{r} }
+
+type skuResolver struct{ *Resolver }
@@ -0,0 +1,102 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) TaskCreate(ctx context.Context, input graph.UpdateTask) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) TaskUpdate(ctx context.Context, id int64, input graph.UpdateTask) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) TaskUpdateStatus(ctx context.Context, id int64, status string) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) TaskAddComment(ctx context.Context, id int64, body string) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Tasks(ctx context.Context, search graph.SearchFilter, limit int, offset int, status *string) (*graph.TaskResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) MyTasks(ctx context.Context, search graph.SearchFilter, limit int, offset int, status *string) (*graph.TaskResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) TaskByID(ctx context.Context, id int64) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) TaskByUID(ctx context.Context, uid string) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) TaskByCode(ctx context.Context, code string) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) UID(ctx context.Context, obj *models.Task) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) Assignee(ctx context.Context, obj *models.Task) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) AssigneeRole(ctx context.Context, obj *models.Task) (*models.Role, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) Container(ctx context.Context, obj *models.Task) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) Pallet(ctx context.Context, obj *models.Task) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) Comments(ctx context.Context, obj *models.Task) ([]models.TaskComment, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) Organization(ctx context.Context, obj *models.Task) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskResolver) CreatedBy(ctx context.Context, obj *models.Task) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *taskCommentResolver) Author(ctx context.Context, obj *models.TaskComment) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Task returns graph.TaskResolver implementation.
+func (r *Resolver) Task() graph.TaskResolver { return &taskResolver{r} }
+
+// TaskComment returns graph.TaskCommentResolver implementation.
+func (r *Resolver) TaskComment() graph.TaskCommentResolver { return &taskCommentResolver{r} }
+
+type taskResolver struct{ *Resolver }
+type taskCommentResolver struct{ *Resolver }
+
+// !!! WARNING !!!
+// The code below was going to be deleted when updating resolvers. It has been copied here so you have
+// one last chance to move it out of harms way if you want. There are two reasons this happens:
+//   - When renaming or deleting a resolver the old code will be put in here. You can safely delete
+//     it when you're done.
+//   - You have helper methods in this file. Move them out to keep these resolver files clean.
+func (r *mutationResolver) TaskComment(ctx context.Context, id int64, body string) (*models.Task, error) {
+	panic(fmt.Errorf("not implemented"))
+}
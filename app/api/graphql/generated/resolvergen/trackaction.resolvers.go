@@ -0,0 +1,52 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) TrackActionCreate(ctx context.Context, input graph.NewTrackAction) (*models.TrackAction, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) TrackActions(ctx context.Context, containerID *int64, palletID *int64) (*graph.TrackActionResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) TrackActionByID(ctx context.Context, id int64) (*models.TrackAction, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) TrackActionByUID(ctx context.Context, uid string) (*models.TrackAction, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *trackActionResolver) UID(ctx context.Context, obj *models.TrackAction) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *trackActionResolver) Container(ctx context.Context, obj *models.TrackAction) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *trackActionResolver) Pallet(ctx context.Context, obj *models.TrackAction) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *trackActionResolver) Actor(ctx context.Context, obj *models.TrackAction) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *trackActionResolver) Organization(ctx context.Context, obj *models.TrackAction) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// TrackAction returns graph.TrackActionResolver implementation.
+func (r *Resolver) TrackAction() graph.TrackActionResolver { return &trackActionResolver{r} }
+
+type trackActionResolver struct{ *Resolver }
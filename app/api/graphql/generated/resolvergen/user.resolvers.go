@@ -36,6 +36,14 @@ func (r *mutationResolver) ResendEmailVerification(ctx context.Context, email st
 	panic(fmt.Errorf("not implemented"))
 }
 
+func (r *profileResolver) WalletBalance(ctx context.Context, obj *models.Profile) (*models.WalletBalance, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *profileResolver) WalletHistory(ctx context.Context, obj *models.Profile) (*graph.WalletPointEntryResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
 func (r *queryResolver) Users(ctx context.Context, search graph.SearchFilter, limit int, offset int, isAdmin bool, isMember bool, isCustomer bool, organizationID *int64) (*graph.UserResult, error) {
 	panic(fmt.Errorf("not implemented"))
 }
@@ -60,7 +68,11 @@ func (r *userResolver) Profile(ctx context.Context, obj *models.User) (*models.P
 	panic(fmt.Errorf("not implemented"))
 }
 
+// Profile returns graph.ProfileResolver implementation.
+func (r *Resolver) Profile() graph.ProfileResolver { return &profileResolver{r} }
+
 // User returns graph.UserResolver implementation.
 func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
 
+type profileResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
@@ -0,0 +1,38 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *mutationResolver) WalletAdjust(ctx context.Context, input graph.NewWalletAdjustment) (*models.WalletPointEntry, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) WalletExpirePoints(ctx context.Context) (*graph.WalletPointEntryResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *walletPointEntryResolver) UID(ctx context.Context, obj *models.WalletPointEntry) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *walletPointEntryResolver) User(ctx context.Context, obj *models.WalletPointEntry) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *walletPointEntryResolver) CreatedBy(ctx context.Context, obj *models.WalletPointEntry) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// WalletPointEntry returns graph.WalletPointEntryResolver implementation.
+func (r *Resolver) WalletPointEntry() graph.WalletPointEntryResolver {
+	return &walletPointEntryResolver{r}
+}
+
+type walletPointEntryResolver struct{ *Resolver }
@@ -0,0 +1,124 @@
+package resolvergen
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+func (r *locationResolver) UID(ctx context.Context, obj *models.Location) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationResolver) Warehouse(ctx context.Context, obj *models.Location) (*models.Warehouse, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationResolver) Parent(ctx context.Context, obj *models.Location) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationResolver) Children(ctx context.Context, obj *models.Location) ([]models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationResolver) Path(ctx context.Context, obj *models.Location) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationMoveResolver) Pallet(ctx context.Context, obj *models.LocationMove) (*models.Pallet, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationMoveResolver) Container(ctx context.Context, obj *models.LocationMove) (*models.Container, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationMoveResolver) FromLocation(ctx context.Context, obj *models.LocationMove) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationMoveResolver) ToLocation(ctx context.Context, obj *models.LocationMove) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *locationMoveResolver) Actor(ctx context.Context, obj *models.LocationMove) (*models.User, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) WarehouseCreate(ctx context.Context, input graph.UpdateWarehouse) (*models.Warehouse, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) WarehouseUpdate(ctx context.Context, id int64, input graph.UpdateWarehouse) (*models.Warehouse, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) WarehouseArchive(ctx context.Context, id int64) (*models.Warehouse, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) WarehouseUnarchive(ctx context.Context, id int64) (*models.Warehouse, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LocationCreate(ctx context.Context, input graph.UpdateLocation) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LocationUpdate(ctx context.Context, id int64, input graph.UpdateLocation) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *mutationResolver) LocationDelete(ctx context.Context, id int64) (bool, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) Warehouses(ctx context.Context, limit int, offset int) (*graph.WarehouseResult, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) WarehouseByID(ctx context.Context, id int64) (*models.Warehouse, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) LocationByID(ctx context.Context, id int64) (*models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *queryResolver) LocationContents(ctx context.Context, id int64, nested *bool) (*graph.LocationContents, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *warehouseResolver) UID(ctx context.Context, obj *models.Warehouse) (string, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *warehouseResolver) Address(ctx context.Context, obj *models.Warehouse) (*models.Address, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *warehouseResolver) Organization(ctx context.Context, obj *models.Warehouse) (*models.Organization, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+func (r *warehouseResolver) Locations(ctx context.Context, obj *models.Warehouse) ([]models.Location, error) {
+	panic(fmt.Errorf("not implemented"))
+}
+
+// Location returns graph.LocationResolver implementation.
+func (r *Resolver) Location() graph.LocationResolver { return &locationResolver{r} }
+
+// LocationMove returns graph.LocationMoveResolver implementation.
+func (r *Resolver) LocationMove() graph.LocationMoveResolver { return &locationMoveResolver{r} }
+
+// Warehouse returns graph.WarehouseResolver implementation.
+func (r *Resolver) Warehouse() graph.WarehouseResolver { return &warehouseResolver{r} }
+
+type locationResolver struct{ *Resolver }
+type locationMoveResolver struct{ *Resolver }
+type warehouseResolver struct{ *Resolver }
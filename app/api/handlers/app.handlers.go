@@ -6,13 +6,19 @@ import (
 )
 
 type Handlers struct {
-	AuthHandler    *AuthHandler
-	GraphQLHandler *GraphQLHandler
+	AuthHandler       *AuthHandler
+	EPCISHandler      *EPCISHandler
+	GraphQLHandler    *GraphQLHandler
+	LabelHandler      *LabelHandler
+	ProvenanceHandler *ProvenanceHandler
 }
 
 func NewHandlers(s *services.Services, fs *filestore.FileStore) *Handlers {
 	return &Handlers{
 		NewAuthHandler(s),
+		NewEPCISHandler(s),
 		NewGraphQLHandler(s, fs),
+		NewLabelHandler(s),
+		NewProvenanceHandler(s),
 	}
 }
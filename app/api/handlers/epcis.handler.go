@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"io/ioutil"
+	"net/http"
+	"orijinplus/app/api/authentication"
+	"orijinplus/app/models"
+	"orijinplus/app/services"
+	"orijinplus/utils/epcis"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// epcisMaxDocumentSize is the largest EPCIS document accepted for capture, in bytes
+const epcisMaxDocumentSize = 10 << 20
+
+type EPCISHandler struct {
+	services *services.Services
+}
+
+func NewEPCISHandler(s *services.Services) *EPCISHandler {
+	return &EPCISHandler{s}
+}
+
+// Events Handler exports the events of an item given by uid, or of an organization, as EPCIS 2.0
+// JSON-LD or XML. Users get the events of their own organization, admins give an organizationID
+func (h *EPCISHandler) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auther, err := h.authorize(r, models.ReadTrackAction)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = models.EPCISJSON
+		if strings.Contains(r.Header.Get("Accept"), "xml") {
+			format = models.EPCISXML
+		}
+	}
+	if format != models.EPCISJSON && format != models.EPCISXML {
+		err := faulterr.NewBadRequestError("format should be jsonld or xml")
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	var doc *epcis.Document
+	if param := r.URL.Query().Get("uid"); param != "" {
+		uid, parseErr := uuid.FromString(param)
+		if parseErr != nil {
+			err := faulterr.NewBadRequestError("uid should be a uuid")
+			RestResponse(w, r, err.Status, err)
+			return
+		}
+		doc, err = h.services.EPCISService.ExportItem(ctx, uid, auther)
+	} else {
+		orgID := auther.OrganizationID.Int64
+		if param := r.URL.Query().Get("organizationID"); param != "" {
+			orgID, err = ConvertStrToInt64(param)
+			if err != nil {
+				RestResponse(w, r, err.Status, err)
+				return
+			}
+		}
+		doc, err = h.services.EPCISService.ExportOrganization(ctx, orgID, auther)
+	}
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	var (
+		body        []byte
+		encodeErr   error
+		contentType = "application/ld+json"
+	)
+	if format == models.EPCISXML {
+		body, encodeErr = doc.XML()
+		contentType = "application/xml"
+	} else {
+		body, encodeErr = doc.JSON()
+	}
+	if encodeErr != nil {
+		err := faulterr.NewInternalServerError(encodeErr.Error())
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// Capture Handler captures the events of an EPCIS 2.0 document sent as JSON-LD, or as XML
+// when its content type says so
+func (h *EPCISHandler) Capture(w http.ResponseWriter, r *http.Request) {
+	auther, err := h.authorize(r, models.CreateTrackAction)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	body, readErr := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, epcisMaxDocumentSize))
+	if readErr != nil {
+		err := faulterr.NewBadRequestError(readErr.Error())
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+	var (
+		doc      *epcis.Document
+		parseErr error
+	)
+	if strings.Contains(r.Header.Get("Content-Type"), "xml") {
+		doc, parseErr = epcis.ParseXML(body)
+	} else {
+		doc, parseErr = epcis.ParseJSON(body)
+	}
+	if parseErr != nil {
+		err := faulterr.NewBadRequestError("EPCIS document cannot be read: " + parseErr.Error())
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	result, err := h.services.EPCISService.Capture(r.Context(), doc, auther)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	response := ResponseBody{
+		Data:       result,
+		Message:    "EPCIS events captured",
+		StatusCode: http.StatusCreated,
+	}
+
+	RestResponse(w, r, response.StatusCode, response)
+}
+
+// authorize checks the user holds the permission on track actions
+func (h *EPCISHandler) authorize(r *http.Request, perm string) (*models.Auther, *faulterr.FaultErr) {
+	auther := authentication.AutherFromContext(r.Context())
+	if auther == nil {
+		return nil, faulterr.NewUnauthorizedError("no credentials provided")
+	}
+	if err := h.services.AuthService.GrantPermission(r.Context(), auther, perm, true, false); err != nil {
+		return nil, err
+	}
+	return auther, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"orijinplus/app/api/authentication"
+	"orijinplus/app/models"
+	"orijinplus/app/services"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+)
+
+type LabelHandler struct {
+	services *services.Services
+}
+
+func NewLabelHandler(s *services.Services) *LabelHandler {
+	return &LabelHandler{s}
+}
+
+// Download Handler serves the QR code or barcode of a pallet, container or sku,
+// or a whole label drawn with a template
+func (h *LabelHandler) Download(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	kind := chi.URLParam(r, "kind")
+	auther, err := h.authorize(r, kind)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	uid, parseErr := uuid.FromString(chi.URLParam(r, "uid"))
+	if parseErr != nil {
+		err := faulterr.NewBadRequestError("uid should be a uuid")
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+	label, err := h.services.LabelService.LabelByUID(ctx, kind, uid, auther)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	var body []byte
+	file := chi.URLParam(r, "file")
+	switch file {
+	case "qr.png", "qr.svg", "code128.png", "code128.svg":
+		parts := strings.Split(file, ".")
+		body, err = services.LabelSymbol(*label, parts[0], parts[1])
+	case "label.svg":
+		template, templateErr := services.LabelTemplate(r.URL.Query().Get("template"))
+		if templateErr != nil {
+			RestResponse(w, r, templateErr.Status, templateErr)
+			return
+		}
+		body, err = services.LabelSVG(*label, template)
+	default:
+		err = faulterr.NewNotFoundError(fmt.Sprintf("no label file %s", file))
+	}
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	writeFile(w, fmt.Sprintf("%s_%s", label.Code, file), body)
+}
+
+// Sheet Handler serves a pdf sheet with the labels of the comma separated ids
+func (h *LabelHandler) Sheet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	kind := chi.URLParam(r, "kind")
+	auther, err := h.authorize(r, kind)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	ids := []int64{}
+	for _, param := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if param == "" {
+			continue
+		}
+		id, err := ConvertStrToInt64(param)
+		if err != nil {
+			RestResponse(w, r, err.Status, err)
+			return
+		}
+		ids = append(ids, id)
+	}
+	template, err := services.LabelTemplate(r.URL.Query().Get("template"))
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	labels, err := h.services.LabelService.Labels(ctx, kind, ids, auther)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+	body, err := services.LabelSheetPDF(labels, template)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	writeFile(w, fmt.Sprintf("%s_labels.pdf", kind), body)
+}
+
+// authorize checks the user may read the kind of object the labels are printed for
+func (h *LabelHandler) authorize(r *http.Request, kind string) (*models.Auther, *faulterr.FaultErr) {
+	auther := authentication.AutherFromContext(r.Context())
+	if auther == nil {
+		return nil, faulterr.NewUnauthorizedError("no credentials provided")
+	}
+	perm, ok := models.LabelReadPermissions[kind]
+	if !ok {
+		return nil, faulterr.NewNotFoundError(fmt.Sprintf("no labels for %s", kind))
+	}
+	if err := h.services.AuthService.GrantPermission(r.Context(), auther, perm, true, false); err != nil {
+		return nil, err
+	}
+	return auther, nil
+}
+
+// writeFile sends a file as a download, its type is taken from the extension of its name
+func writeFile(w http.ResponseWriter, name string, body []byte) {
+	contentType := "application/octet-stream"
+	switch {
+	case strings.HasSuffix(name, ".png"):
+		contentType = "image/png"
+	case strings.HasSuffix(name, ".svg"):
+		contentType = "image/svg+xml"
+	case strings.HasSuffix(name, ".pdf"):
+		contentType = "application/pdf"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"orijinplus/app/services"
+	"orijinplus/utils/faulterr"
+
+	"github.com/go-chi/chi"
+	"github.com/gofrs/uuid"
+)
+
+type ProvenanceHandler struct {
+	services *services.Services
+}
+
+func NewProvenanceHandler(s *services.Services) *ProvenanceHandler {
+	return &ProvenanceHandler{s}
+}
+
+// Lookup Handler resolves a scanned uid to the public view of its pallet, container or product
+func (h *ProvenanceHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	uid, parseErr := uuid.FromString(chi.URLParam(r, "uid"))
+	if parseErr != nil {
+		err := faulterr.NewBadRequestError("uid should be a uuid")
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	result, err := h.services.ProvenanceService.Lookup(r.Context(), uid)
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	response := ResponseBody{
+		Data:       result,
+		Message:    "Provenance",
+		StatusCode: http.StatusOK,
+	}
+
+	RestResponse(w, r, response.StatusCode, response)
+}
+
+// LookupGS1 Handler resolves the GS1 key of a scanned GS1 Digital Link to the public view of its pallet, container or product
+func (h *ProvenanceHandler) LookupGS1(w http.ResponseWriter, r *http.Request) {
+	result, err := h.services.ProvenanceService.LookupGS1(r.Context(), chi.URLParam(r, "ai"), chi.URLParam(r, "key"))
+	if err != nil {
+		RestResponse(w, r, err.Status, err)
+		return
+	}
+
+	response := ResponseBody{
+		Data:       result,
+		Message:    "Provenance",
+		StatusCode: http.StatusOK,
+	}
+
+	RestResponse(w, r, response.StatusCode, response)
+}
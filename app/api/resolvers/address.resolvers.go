@@ -0,0 +1,198 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/volatiletech/null"
+)
+
+type addressResolver struct{ *Resolver }
+
+// Address returns graph.AddressResolver implementation.
+func (r *Resolver) Address() graph.AddressResolver { return &addressResolver{r} }
+
+func (r *addressResolver) Organization(ctx context.Context, obj *models.Address) (*models.Organization, error) {
+	if obj.OrganizationID.Valid {
+		return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID.Int64)
+	}
+	return nil, nil
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) MyAddresses(ctx context.Context) ([]models.Address, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	addresses, err := r.services.AddressService.ListMine(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return addresses, nil
+}
+
+func (r *queryResolver) OrganizationAddresses(ctx context.Context, organizationID int64) ([]models.Address, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	addresses, err := r.services.AddressService.ListByOrgID(ctx, organizationID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return addresses, nil
+}
+
+func (r *queryResolver) AddressByID(ctx context.Context, id int64) (*models.Address, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.AddressService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) AddressCreate(ctx context.Context, input graph.NewAddress) (*models.Address, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.Address{
+		Tag:            input.Tag,
+		Line1:          input.Line1,
+		Line2:          input.Line2,
+		City:           input.City,
+		State:          input.State,
+		Country:        input.Country,
+		Pincode:        input.Pincode,
+		OrganizationID: null.Int64FromPtr(input.OrganizationID),
+	}
+	if input.Line3 != nil {
+		request.Line3 = *input.Line3
+	}
+	if input.IsDefault != nil {
+		request.IsDefault = *input.IsDefault
+	}
+
+	obj, err := r.services.AddressService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) AddressUpdate(ctx context.Context, id int64, input graph.UpdateAddress) (*models.Address, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.AddressService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := *current
+	if input.Tag != nil {
+		request.Tag = input.Tag.String
+	}
+	if input.Line1 != nil {
+		request.Line1 = input.Line1.String
+	}
+	if input.Line2 != nil {
+		request.Line2 = input.Line2.String
+	}
+	if input.Line3 != nil {
+		request.Line3 = *input.Line3
+	}
+	if input.City != nil {
+		request.City = input.City.String
+	}
+	if input.State != nil {
+		request.State = input.State.String
+	}
+	if input.Country != nil {
+		request.Country = input.Country.String
+	}
+	if input.Pincode != nil {
+		request.Pincode = input.Pincode.String
+	}
+	if input.IsDefault != nil {
+		request.IsDefault = input.IsDefault.Bool
+	}
+
+	obj, err := r.services.AddressService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) AddressSetDefault(ctx context.Context, id int64) (*models.Address, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.AddressService.SetDefault(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) AddressDelete(ctx context.Context, id int64) (bool, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return false, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, true); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+
+	if err := r.services.AddressService.Delete(ctx, id, auther); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+
+	return true, nil
+}
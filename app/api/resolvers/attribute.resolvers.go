@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+	"strings"
+)
+
+type attributeDefinitionResolver struct{ *Resolver }
+
+// AttributeDefinition returns graph.AttributeDefinitionResolver implementation.
+func (r *Resolver) AttributeDefinition() graph.AttributeDefinitionResolver {
+	return &attributeDefinitionResolver{r}
+}
+
+func (r *attributeDefinitionResolver) Organization(ctx context.Context, obj *models.AttributeDefinition) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) OrganizationAttributeDefinitions(ctx context.Context, organizationID int64) ([]models.AttributeDefinition, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	defs, err := r.services.OrganizationService.ListAttributeDefinitions(ctx, organizationID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return defs, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) OrganizationAttributeDefinitionSet(ctx context.Context, organizationID int64, input graph.UpdateAttributeDefinition) (*models.AttributeDefinition, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.AttributeDefinition{
+		OrganizationID: organizationID,
+		Entity:         input.Entity,
+		Key:            strings.TrimSpace(input.Key),
+		Type:           input.Type,
+		EnumValues:     input.EnumValues,
+	}
+	if input.Label != nil {
+		request.Label = input.Label.String
+	}
+	if input.Required != nil {
+		request.Required = *input.Required
+	}
+
+	result, err := r.services.OrganizationService.SetAttributeDefinition(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) OrganizationAttributeDefinitionDelete(ctx context.Context, id int64) (bool, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return false, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, false); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+
+	if err := r.services.OrganizationService.DeleteAttributeDefinition(ctx, id, auther); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+	return true, nil
+}
+
+///////////////
+//  Helpers  //
+///////////////
+
+// mergeAttributes applies attribute inputs to the current attributes of a pallet or container,
+// a null value removes the attribute
+func mergeAttributes(current map[string]interface{}, inputs []graph.AttributeInput) map[string]interface{} {
+	attrs := make(map[string]interface{}, len(current)+len(inputs))
+	for key, value := range current {
+		attrs[key] = value
+	}
+	for _, input := range inputs {
+		if input.Value == nil {
+			delete(attrs, input.Key)
+			continue
+		}
+		attrs[input.Key] = *input.Value
+	}
+	return attrs
+}
@@ -0,0 +1,212 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type consumerOrderResolver struct{ *Resolver }
+
+// ConsumerOrder returns graph.ConsumerOrderResolver implementation.
+func (r *Resolver) ConsumerOrder() graph.ConsumerOrderResolver { return &consumerOrderResolver{r} }
+
+func (r *consumerOrderResolver) UID(ctx context.Context, obj *models.ConsumerOrder) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *consumerOrderResolver) Customer(ctx context.Context, obj *models.ConsumerOrder) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CustomerID)
+}
+
+func (r *consumerOrderResolver) Address(ctx context.Context, obj *models.ConsumerOrder) (*models.Address, error) {
+	return dataloaders.AddressLoaderFromContext(ctx, obj.AddressID)
+}
+
+func (r *consumerOrderResolver) Items(ctx context.Context, obj *models.ConsumerOrder) ([]models.ConsumerOrderItem, error) {
+	items, err := r.services.ConsumerOrderService.ListItems(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return items, nil
+}
+
+func (r *consumerOrderResolver) AmountDue(ctx context.Context, obj *models.ConsumerOrder) (int, error) {
+	return int(obj.Total - obj.WalletPoints), nil
+}
+
+func (r *consumerOrderResolver) Organization(ctx context.Context, obj *models.ConsumerOrder) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+type consumerOrderItemResolver struct{ *Resolver }
+
+// ConsumerOrderItem returns graph.ConsumerOrderItemResolver implementation.
+func (r *Resolver) ConsumerOrderItem() graph.ConsumerOrderItemResolver {
+	return &consumerOrderItemResolver{r}
+}
+
+func (r *consumerOrderItemResolver) Sku(ctx context.Context, obj *models.ConsumerOrderItem) (*models.Sku, error) {
+	return dataloaders.SkuLoaderFromContext(ctx, obj.SkuID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) ConsumerOrders(
+	ctx context.Context,
+	search graph.SearchFilter,
+	limit int,
+	offset int,
+	status *string,
+) (*graph.ConsumerOrderResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadConsumerOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	orders, err := r.services.ConsumerOrderService.List(ctx, null.StringFromPtr(status), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.ConsumerOrderResult{ConsumerOrders: orders, Total: len(orders)}, nil
+}
+
+func (r *queryResolver) MyConsumerOrders(
+	ctx context.Context,
+	search graph.SearchFilter,
+	limit int,
+	offset int,
+	status *string,
+) (*graph.ConsumerOrderResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadConsumerOrder, false, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	orders, err := r.services.ConsumerOrderService.ListMine(ctx, null.StringFromPtr(status), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.ConsumerOrderResult{ConsumerOrders: orders, Total: len(orders)}, nil
+}
+
+func (r *queryResolver) ConsumerOrderByID(ctx context.Context, id int64) (*models.ConsumerOrder, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadConsumerOrder, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ConsumerOrderService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) ConsumerOrderByUID(ctx context.Context, uid string) (*models.ConsumerOrder, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadConsumerOrder, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.ConsumerOrderService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) ConsumerOrderByCode(ctx context.Context, code string) (*models.ConsumerOrder, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadConsumerOrder, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ConsumerOrderService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) ConsumerOrderCreate(ctx context.Context, input graph.NewConsumerOrder) (*models.ConsumerOrder, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateConsumerOrder, false, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ConsumerOrderRequest{
+		AddressID: input.AddressID,
+		Items:     []models.ConsumerOrderItemRequest{},
+	}
+	for _, item := range input.Items {
+		request.Items = append(request.Items, models.ConsumerOrderItemRequest{
+			SkuID:    item.SkuID,
+			Quantity: int64(item.Quantity),
+		})
+	}
+	if input.WalletPoints != nil {
+		request.WalletPoints = input.WalletPoints.Int64
+	}
+
+	obj, err := r.services.ConsumerOrderService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ConsumerOrderUpdateStatus(ctx context.Context, id int64, status string) (*models.ConsumerOrder, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateConsumerOrder, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ConsumerOrderService.UpdateStatus(ctx, id, status, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
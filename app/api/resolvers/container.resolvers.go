@@ -6,8 +6,10 @@ import (
 	"orijinplus/app/api/dataloaders"
 	"orijinplus/app/api/graphql/generated/graph"
 	"orijinplus/app/models"
+	"orijinplus/app/services"
 
 	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
 )
 
 type containerResolver struct{ *Resolver }
@@ -26,11 +28,115 @@ func (r *containerResolver) Organization(ctx context.Context, obj *models.Contai
 	return nil, nil
 }
 
+func (r *containerResolver) Pallets(ctx context.Context, obj *models.Container) ([]models.Pallet, error) {
+	return dataloaders.ContainerPalletsLoaderFromContext(ctx, obj.ID)
+}
+
+func (r *containerResolver) PalletCount(ctx context.Context, obj *models.Container) (int, error) {
+	pallets, err := dataloaders.ContainerPalletsLoaderFromContext(ctx, obj.ID)
+	if err != nil {
+		return 0, err
+	}
+	return len(pallets), nil
+}
+
+func (r *containerResolver) Timeline(ctx context.Context, obj *models.Container) ([]models.TrackAction, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	actions, err := r.services.TrackActionService.ListByContainerID(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return actions, nil
+}
+
+func (r *containerResolver) Transitions(ctx context.Context, obj *models.Container) ([]models.ContainerTransition, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	transitions, err := r.services.ContainerService.ListTransitions(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return transitions, nil
+}
+
+func (r *containerResolver) Location(ctx context.Context, obj *models.Container) (*models.Location, error) {
+	if obj.LocationID.Valid {
+		return dataloaders.LocationLoaderFromContext(ctx, obj.LocationID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *containerResolver) Attributes(ctx context.Context, obj *models.Container) ([]models.Attribute, error) {
+	return models.AttributeList(obj.Attributes), nil
+}
+
+func (r *containerResolver) Attachments(ctx context.Context, obj *models.Container) ([]models.StoredFile, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	files, err := r.services.FileService.ListByOwner(ctx, models.CodeContainer, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return files, nil
+}
+
+func (r *containerResolver) Recalls(ctx context.Context, obj *models.Container) ([]models.Recall, error) {
+	recalls, err := r.services.RecallService.ListOpenByItem(ctx, models.CodeContainer, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return recalls, nil
+}
+
+func (r *containerResolver) LocationHistory(ctx context.Context, obj *models.Container) ([]models.LocationMove, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	moves, err := r.services.ContainerService.ListLocationMoves(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return moves, nil
+}
+
+type containerTransitionResolver struct{ *Resolver }
+
+// ContainerTransition returns graph.ContainerTransitionResolver implementation.
+func (r *Resolver) ContainerTransition() graph.ContainerTransitionResolver {
+	return &containerTransitionResolver{r}
+}
+
+func (r *containerTransitionResolver) Container(ctx context.Context, obj *models.ContainerTransition) (*models.Container, error) {
+	return dataloaders.ContainerLoaderFromContext(ctx, obj.ContainerID)
+}
+
+func (r *containerTransitionResolver) Actor(ctx context.Context, obj *models.ContainerTransition) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.ActorID)
+}
+
 ///////////////
 //   Query   //
 ///////////////
 
-func (r *queryResolver) Containers(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ContainerResult, error) {
+func (r *queryResolver) Containers(
+	ctx context.Context,
+	search graph.SearchFilter,
+	limit int,
+	offset int,
+	attributes []models.AttributeFilter,
+) (*graph.ContainerResult, error) {
 	auther, authErr := r.GetAuther(ctx)
 	if authErr != nil {
 		return nil, authErr
@@ -39,6 +145,14 @@ func (r *queryResolver) Containers(ctx context.Context, search graph.SearchFilte
 		return nil, fmt.Errorf(err.Message)
 	}
 
+	if len(attributes) > 0 {
+		containers, err := r.services.ContainerService.ListByAttributes(ctx, attributes, auther)
+		if err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+		return &graph.ContainerResult{Containers: containers, Total: len(containers)}, nil
+	}
+
 	containers, err := r.services.ContainerService.List(ctx, auther)
 	if err != nil {
 		return nil, fmt.Errorf(err.Message)
@@ -102,6 +216,23 @@ func (r *queryResolver) ContainerByCode(ctx context.Context, code string) (*mode
 	return obj, nil
 }
 
+func (r *queryResolver) ContainerBySscc(ctx context.Context, sscc string) (*models.Container, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadContainer, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContainerService.GetBySSCC(ctx, sscc, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
 ///////////////
 // Mutations //
 ///////////////
@@ -122,6 +253,7 @@ func (r *mutationResolver) ContainerCreate(ctx context.Context, input graph.Upda
 	if input.OrganizationID != nil {
 		request.OrganizationID = *input.OrganizationID
 	}
+	request.Attributes = mergeAttributes(nil, input.Attributes)
 
 	obj, err := r.services.ContainerService.Create(ctx, request, auther)
 	if err != nil {
@@ -131,6 +263,37 @@ func (r *mutationResolver) ContainerCreate(ctx context.Context, input graph.Upda
 	return obj, nil
 }
 
+func (r *mutationResolver) ContainerCreateBulk(ctx context.Context, count int, input graph.UpdateContainer, withLabels *bool) (*graph.ContainerBulkResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateContainer, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ContainerRequest{}
+	if input.Description != nil {
+		request.Description = input.Description.String
+	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+	request.Attributes = mergeAttributes(nil, input.Attributes)
+
+	containers, err := r.services.ContainerService.CreateBulk(ctx, request, count, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	result := &graph.ContainerBulkResult{Containers: containers, Total: len(containers)}
+	if withLabels != nil && *withLabels {
+		result.LabelFile = r.uploadBulkLabels(models.LabelContainer, auther.OrganizationID.Int64, services.ContainerLabels(containers))
+	}
+
+	return result, nil
+}
+
 func (r *mutationResolver) ContainerUpdate(ctx context.Context, id int64, input graph.UpdateContainer) (*models.Container, error) {
 	auther, authErr := r.GetAuther(ctx)
 	if authErr != nil {
@@ -140,7 +303,16 @@ func (r *mutationResolver) ContainerUpdate(ctx context.Context, id int64, input
 		return nil, fmt.Errorf(err.Message)
 	}
 
-	request := models.ContainerRequest{}
+	current, err := r.services.ContainerService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ContainerRequest{
+		Description:    current.Description,
+		OrganizationID: current.OrganizationID,
+		Attributes:     mergeAttributes(current.Attributes, input.Attributes),
+	}
 	if input.Description != nil {
 		request.Description = input.Description.String
 	}
@@ -153,6 +325,66 @@ func (r *mutationResolver) ContainerUpdate(ctx context.Context, id int64, input
 	return obj, nil
 }
 
+func (r *mutationResolver) ContainerStartPacking(ctx context.Context, id int64) (*models.Container, error) {
+	return r.transitionContainer(ctx, id, models.ContainerPacking)
+}
+
+func (r *mutationResolver) ContainerReopen(ctx context.Context, id int64) (*models.Container, error) {
+	return r.transitionContainer(ctx, id, models.ContainerOpen)
+}
+
+func (r *mutationResolver) ContainerSeal(ctx context.Context, id int64) (*models.Container, error) {
+	return r.transitionContainer(ctx, id, models.ContainerSealed)
+}
+
+func (r *mutationResolver) ContainerDispatch(ctx context.Context, id int64) (*models.Container, error) {
+	return r.transitionContainer(ctx, id, models.ContainerInTransit)
+}
+
+func (r *mutationResolver) ContainerArrive(ctx context.Context, id int64) (*models.Container, error) {
+	return r.transitionContainer(ctx, id, models.ContainerArrived)
+}
+
+func (r *mutationResolver) ContainerUnpack(ctx context.Context, id int64) (*models.Container, error) {
+	return r.transitionContainer(ctx, id, models.ContainerUnpacked)
+}
+
+func (r *mutationResolver) ContainerPlace(ctx context.Context, id int64, locationID *int64) (*models.Container, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContainer, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	location := null.Int64FromPtr(locationID)
+	obj, err := r.services.ContainerService.Place(ctx, id, location, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// ContainerSSCCAssign gives a container created before its organization set a GS1 company prefix its SSCC
+func (r *mutationResolver) ContainerSSCCAssign(ctx context.Context, id int64) (*models.Container, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContainer, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContainerService.AssignSSCC(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
 func (r *mutationResolver) ContainerArchive(ctx context.Context, id int64) (*models.Container, error) {
 	auther, authErr := r.GetAuther(ctx)
 	if authErr != nil {
@@ -186,3 +418,21 @@ func (r *mutationResolver) ContainerUnarchive(ctx context.Context, id int64) (*m
 
 	return obj, nil
 }
+
+// transitionContainer moves a container to the given status of its lifecycle
+func (r *mutationResolver) transitionContainer(ctx context.Context, id int64, status string) (*models.Container, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContainer, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContainerService.Transition(ctx, id, status, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
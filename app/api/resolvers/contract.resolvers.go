@@ -0,0 +1,320 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type contractResolver struct{ *Resolver }
+
+// Contract returns graph.ContractResolver implementation.
+func (r *Resolver) Contract() graph.ContractResolver { return &contractResolver{r} }
+
+func (r *contractResolver) UID(ctx context.Context, obj *models.Contract) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *contractResolver) Status(ctx context.Context, obj *models.Contract) (string, error) {
+	return obj.StatusAt(time.Now()), nil
+}
+
+func (r *contractResolver) Organization(ctx context.Context, obj *models.Contract) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *contractResolver) Buyer(ctx context.Context, obj *models.Contract) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.BuyerOrganizationID)
+}
+
+func (r *contractResolver) SupplierApprovedBy(ctx context.Context, obj *models.Contract) (*models.User, error) {
+	if obj.SupplierApprovedByID.Valid {
+		return dataloaders.UserLoaderFromContext(ctx, obj.SupplierApprovedByID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *contractResolver) BuyerApprovedBy(ctx context.Context, obj *models.Contract) (*models.User, error) {
+	if obj.BuyerApprovedByID.Valid {
+		return dataloaders.UserLoaderFromContext(ctx, obj.BuyerApprovedByID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *contractResolver) Documents(ctx context.Context, obj *models.Contract) ([]models.ContractDocument, error) {
+	documents, err := r.services.ContractService.ListDocuments(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return documents, nil
+}
+
+func (r *contractResolver) Orders(ctx context.Context, obj *models.Contract) ([]models.Order, error) {
+	orders, err := r.services.ContractService.ListOrders(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return orders, nil
+}
+
+type contractDocumentResolver struct{ *Resolver }
+
+// ContractDocument returns graph.ContractDocumentResolver implementation.
+func (r *Resolver) ContractDocument() graph.ContractDocumentResolver {
+	return &contractDocumentResolver{r}
+}
+
+func (r *contractDocumentResolver) UploadedBy(ctx context.Context, obj *models.ContractDocument) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.UploadedByID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Contracts(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ContractResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	contracts, err := r.services.ContractService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.ContractResult{Contracts: contracts, Total: len(contracts)}, nil
+}
+
+func (r *queryResolver) ContractByID(ctx context.Context, id int64) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) ContractByUID(ctx context.Context, uid string) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.ContractService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) ContractByCode(ctx context.Context, code string) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) ContractCreate(ctx context.Context, input graph.UpdateContract) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ContractRequest{}
+	contractRequest(&request, input)
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.ContractService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ContractUpdate(ctx context.Context, id int64, input graph.UpdateContract) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.ContractService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ContractRequest{
+		Title:               current.Title,
+		Description:         current.Description,
+		BuyerOrganizationID: current.BuyerOrganizationID,
+		StartDate:           current.StartDate,
+		EndDate:             current.EndDate,
+		PricingTerms:        current.PricingTerms,
+		VolumeTerms:         current.VolumeTerms,
+	}
+	contractRequest(&request, input)
+
+	obj, err := r.services.ContractService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ContractApprove(ctx context.Context, id int64) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.Approve(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ContractAddDocument(ctx context.Context, id int64, file graph.FileInput) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.AddDocument(ctx, id, models.File{Name: file.Name, URL: file.URL}, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ContractRemoveDocument(ctx context.Context, id int64) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.RemoveDocument(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ContractArchive(ctx context.Context, id int64) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.Archive(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ContractUnarchive(ctx context.Context, id int64) (*models.Contract, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateContract, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ContractService.Unarchive(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// contractRequest copies the fields set in the input onto the request
+func contractRequest(request *models.ContractRequest, input graph.UpdateContract) {
+	if input.Title != nil {
+		request.Title = input.Title.String
+	}
+	if input.Description != nil {
+		request.Description = input.Description.String
+	}
+	if input.BuyerOrganizationID != nil {
+		request.BuyerOrganizationID = input.BuyerOrganizationID.Int64
+	}
+	if input.StartDate != nil {
+		request.StartDate = input.StartDate.Time
+	}
+	if input.EndDate != nil {
+		request.EndDate = input.EndDate.Time
+	}
+	if input.PricingTerms != nil {
+		request.PricingTerms = input.PricingTerms.String
+	}
+	if input.VolumeTerms != nil {
+		request.VolumeTerms = input.VolumeTerms.String
+	}
+}
@@ -0,0 +1,248 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type distributorResolver struct{ *Resolver }
+
+// Distributor returns graph.DistributorResolver implementation.
+func (r *Resolver) Distributor() graph.DistributorResolver { return &distributorResolver{r} }
+
+func (r *distributorResolver) UID(ctx context.Context, obj *models.Distributor) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *distributorResolver) Address(ctx context.Context, obj *models.Distributor) (*models.Address, error) {
+	if obj.AddressID.Valid {
+		return dataloaders.AddressLoaderFromContext(ctx, obj.AddressID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *distributorResolver) Organization(ctx context.Context, obj *models.Distributor) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *distributorResolver) Orders(ctx context.Context, obj *models.Distributor) ([]models.Order, error) {
+	orders, err := r.services.DistributorService.ListOrders(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return orders, nil
+}
+
+func (r *distributorResolver) Pallets(ctx context.Context, obj *models.Distributor) ([]models.Pallet, error) {
+	pallets, err := r.services.DistributorService.ListPallets(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return pallets, nil
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Distributors(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.DistributorResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	distributors, err := r.services.DistributorService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.DistributorResult{Distributors: distributors, Total: len(distributors)}, nil
+}
+
+func (r *queryResolver) DistributorByID(ctx context.Context, id int64) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.DistributorService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) DistributorByUID(ctx context.Context, uid string) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.DistributorService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) DistributorByCode(ctx context.Context, code string) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.DistributorService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) DistributorCreate(ctx context.Context, input graph.UpdateDistributor) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.DistributorRequest{}
+	distributorRequest(&request, input)
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.DistributorService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) DistributorUpdate(ctx context.Context, id int64, input graph.UpdateDistributor) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.DistributorService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.DistributorRequest{
+		Name:        current.Name,
+		ContactName: current.ContactName,
+		Email:       current.Email,
+		Phone:       current.Phone,
+		Regions:     current.Regions,
+	}
+	distributorRequest(&request, input)
+
+	obj, err := r.services.DistributorService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) DistributorArchive(ctx context.Context, id int64) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.DistributorService.Archive(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) DistributorUnarchive(ctx context.Context, id int64) (*models.Distributor, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateDistributor, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.DistributorService.Unarchive(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// distributorRequest copies the fields set in the input onto the request
+func distributorRequest(request *models.DistributorRequest, input graph.UpdateDistributor) {
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+	if input.ContactName != nil {
+		request.ContactName = input.ContactName.String
+	}
+	if input.Email != nil {
+		request.Email = input.Email.String
+	}
+	if input.Phone != nil {
+		request.Phone = input.Phone.String
+	}
+	if input.Regions != nil {
+		request.Regions = input.Regions
+	}
+	if input.Address != nil {
+		request.Address = &models.Address{
+			Tag:     input.Address.Tag,
+			Line1:   input.Address.Line1,
+			Line2:   input.Address.Line2,
+			City:    input.Address.City,
+			State:   input.Address.State,
+			Country: input.Address.Country,
+			Pincode: input.Address.Pincode,
+		}
+		if input.Address.Line3 != nil {
+			request.Address.Line3 = *input.Address.Line3
+		}
+	}
+}
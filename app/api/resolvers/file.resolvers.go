@@ -2,14 +2,65 @@ package resolvers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
 	"orijinplus/app/models"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/h2non/filetype"
+	"github.com/volatiletech/null"
 )
 
+type storedFileResolver struct{ *Resolver }
+
+// StoredFile returns graph.StoredFileResolver implementation.
+func (r *Resolver) StoredFile() graph.StoredFileResolver { return &storedFileResolver{r} }
+
+func (r *storedFileResolver) Organization(ctx context.Context, obj *models.StoredFile) (*models.Organization, error) {
+	if !obj.OrganizationID.Valid {
+		return nil, nil
+	}
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID.Int64)
+}
+
+func (r *storedFileResolver) UploadedBy(ctx context.Context, obj *models.StoredFile) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.UploadedByID)
+}
+
+// DownloadURL signs a download link, files are only resolved for users of their organization
+func (r *storedFileResolver) DownloadURL(ctx context.Context, obj *models.StoredFile) (string, error) {
+	url, err := r.filestore.DownloadURL(obj.Name, models.FileDownloadExpiry)
+	if err != nil {
+		return "", fmt.Errorf(err.Message)
+	}
+	return url, nil
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) FileByID(ctx context.Context, id int64) (*models.StoredFile, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	file, err := r.services.FileService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return file, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
 func (r *mutationResolver) FileUpload(ctx context.Context, file graphql.Upload) (*models.File, error) {
 	auther, authErr := r.GetAuther(ctx)
 	if authErr != nil {
@@ -50,6 +101,50 @@ func (r *mutationResolver) FileUploadMultiple(ctx context.Context, files []graph
 	return objects, nil
 }
 
+func (r *mutationResolver) FileAttach(ctx context.Context, id int64, ownerType string, ownerID int64) (*models.StoredFile, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	perm, ok := models.FileOwnerPermissions[ownerType]
+	if !ok {
+		return nil, fmt.Errorf("ownerType must be %s, %s or %s", models.CodeOrganization, models.CodeContainer, models.CodePallet)
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, perm, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	file, err := r.services.FileService.Attach(ctx, id, ownerType, ownerID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return file, nil
+}
+
+func (r *mutationResolver) FileDetach(ctx context.Context, id int64) (*models.StoredFile, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	// Detaching a file takes the permission to attach files to its owner
+	current, err := r.services.FileService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	if perm, ok := models.FileOwnerPermissions[current.OwnerType.String]; ok {
+		if err := r.services.AuthService.GrantPermission(ctx, auther, perm, true, false); err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+	}
+
+	file, err := r.services.FileService.Detach(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return file, nil
+}
+
 // Upload adds a new blob to the db
 func (r *mutationResolver) Upload(ctx context.Context, file graphql.Upload, auther *models.Auther) (*models.File, error) {
 	// Read file data
@@ -68,13 +163,26 @@ func (r *mutationResolver) Upload(ctx context.Context, file graphql.Upload, auth
 		return nil, fmt.Errorf("file upload - image type is unknown")
 	}
 
-	// mimeType := kind.MIME.Value
-	// extension := kind.Extension
-
 	obj, uploadErr := r.filestore.UploadFile(file.Filename, fileObj)
 	if uploadErr != nil {
 		return nil, fmt.Errorf(uploadErr.Message)
 	}
 
+	// Record the file so it can be attached to what it belongs to
+	sum := sha256.Sum256(fileObj)
+	record := models.StoredFile{
+		Name:         obj.Name,
+		OriginalName: file.Filename,
+		URL:          obj.URL,
+		MimeType:     kind.MIME.Value,
+		Size:         int64(len(fileObj)),
+		Checksum:     hex.EncodeToString(sum[:]),
+	}
+	stored, recordErr := r.services.FileService.Create(ctx, record, auther)
+	if recordErr != nil {
+		return nil, fmt.Errorf(recordErr.Message)
+	}
+	obj.ID = null.Int64From(stored.ID)
+
 	return obj, nil
 }
@@ -0,0 +1,138 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+	"orijinplus/app/services"
+	"orijinplus/utils/logger"
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+func (r *mutationResolver) LabelSheetCreate(ctx context.Context, kind string, ids []int64, template *string, customTemplate *graph.LabelTemplateInput, format *string) (*models.File, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	perm, ok := models.LabelReadPermissions[kind]
+	if !ok {
+		return nil, fmt.Errorf("kind must be %s, %s or %s", models.LabelPallet, models.LabelContainer, models.LabelSku)
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, perm, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	sheetFormat := models.LabelPDF
+	if format != nil {
+		sheetFormat = *format
+	}
+	if sheetFormat != models.LabelPDF && sheetFormat != models.LabelCSV {
+		return nil, fmt.Errorf("format must be %s or %s", models.LabelPDF, models.LabelCSV)
+	}
+
+	// A custom template takes precedence over a named one
+	var sheetTemplate models.LabelTemplate
+	if customTemplate != nil {
+		sheetTemplate = models.LabelTemplate{
+			Name:        "custom",
+			PageWidth:   customTemplate.PageWidth,
+			PageHeight:  customTemplate.PageHeight,
+			LabelWidth:  customTemplate.LabelWidth,
+			LabelHeight: customTemplate.LabelHeight,
+			Columns:     customTemplate.Columns,
+			Rows:        customTemplate.Rows,
+			MarginLeft:  customTemplate.MarginLeft,
+			MarginTop:   customTemplate.MarginTop,
+			GapX:        customTemplate.GapX,
+			GapY:        customTemplate.GapY,
+			ShowQR:      customTemplate.ShowQr,
+			ShowBarcode: customTemplate.ShowBarcode,
+			ShowText:    customTemplate.ShowText,
+		}
+		if err := sheetTemplate.Validate(); err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+	} else {
+		name := ""
+		if template != nil {
+			name = *template
+		}
+		named, err := services.LabelTemplate(name)
+		if err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+		sheetTemplate = named
+	}
+
+	labels, err := r.services.LabelService.Labels(ctx, kind, ids, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	var content []byte
+	if sheetFormat == models.LabelPDF {
+		content, err = services.LabelSheetPDF(labels, sheetTemplate)
+	} else {
+		content, err = services.LabelSheetCSV(labels)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	name, nameErr := labelSheetName(kind, auther.OrganizationID.Int64, sheetFormat)
+	if nameErr != nil {
+		return nil, nameErr
+	}
+	file, err := r.filestore.UploadFile(name, content)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return file, nil
+}
+
+func (r *queryResolver) LabelTemplates(ctx context.Context) ([]models.LabelTemplate, error) {
+	if _, authErr := r.GetAuther(ctx); authErr != nil {
+		return nil, authErr
+	}
+
+	templates := make([]models.LabelTemplate, 0, len(models.LabelTemplates))
+	for _, t := range models.LabelTemplates {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// uploadBulkLabels uploads the CSV label sheet of pallets or containers created in bulk. They are created by then,
+// so a sheet which cannot be made or uploaded is left out instead of failing the mutation and having it retried
+func (r *mutationResolver) uploadBulkLabels(kind string, orgID int64, labels []models.Label) *models.File {
+	sheet, err := services.LabelSheetCSV(labels)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Bulk %s label sheet failed: %s", kind, err.Message))
+		return nil
+	}
+	name, nameErr := labelSheetName(kind, orgID, models.LabelCSV)
+	if nameErr != nil {
+		logger.Info(fmt.Sprintf("Bulk %s label sheet failed: %s", kind, nameErr))
+		return nil
+	}
+	file, err := r.filestore.UploadFile(name, sheet)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Bulk %s label sheet upload failed: %s", kind, err.Message))
+		return nil
+	}
+	return file
+}
+
+// labelSheetName names a label sheet after its organization and a new UID, so sheets made at the same time never share a name
+func labelSheetName(kind string, orgID int64, format string) (string, error) {
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s_labels_%d_%s.%s", kind, orgID, uid, format), nil
+}
@@ -0,0 +1,213 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+type lotResolver struct{ *Resolver }
+
+// Lot returns graph.LotResolver implementation.
+func (r *Resolver) Lot() graph.LotResolver { return &lotResolver{r} }
+
+func (r *lotResolver) UID(ctx context.Context, obj *models.Lot) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *lotResolver) Sku(ctx context.Context, obj *models.Lot) (*models.Sku, error) {
+	return dataloaders.SkuLoaderFromContext(ctx, obj.SkuID)
+}
+
+func (r *lotResolver) Organization(ctx context.Context, obj *models.Lot) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *lotResolver) Pallets(ctx context.Context, obj *models.Lot) ([]models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	pallets, err := r.services.LotService.ListPallets(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return pallets, nil
+}
+
+func (r *lotResolver) CreatedBy(ctx context.Context, obj *models.Lot) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Lots(ctx context.Context, skuID int64) ([]models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	lots, err := r.services.LotService.ListBySkuID(ctx, skuID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return lots, nil
+}
+
+func (r *queryResolver) LotByID(ctx context.Context, id int64) (*models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.LotService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *queryResolver) LotsExpiring(ctx context.Context, days int) ([]models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	lots, err := r.services.LotService.ListExpiring(ctx, days, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return lots, nil
+}
+
+func (r *queryResolver) FefoPallets(ctx context.Context, skuID int64, limit *int) ([]models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	n := 0
+	if limit != nil {
+		n = *limit
+	}
+	pallets, err := r.services.LotService.ListFEFO(ctx, skuID, n, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return pallets, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) LotCreate(ctx context.Context, input graph.NewLot) (*models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.LotRequest{
+		Code:           input.Code,
+		SkuID:          input.SkuID,
+		ProductionDate: input.ProductionDate,
+	}
+	if input.ExpiryDate != nil {
+		request.ExpiryDate = *input.ExpiryDate
+	}
+
+	obj, err := r.services.LotService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *mutationResolver) LotUpdate(ctx context.Context, id int64, input graph.UpdateLot) (*models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.LotService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.LotRequest{
+		Code:           current.Code,
+		SkuID:          current.SkuID,
+		ProductionDate: current.ProductionDate,
+		ExpiryDate:     current.ExpiryDate,
+	}
+	if input.Code != nil {
+		request.Code = input.Code.String
+	}
+	if input.ProductionDate != nil && input.ProductionDate.Valid {
+		request.ProductionDate = input.ProductionDate.Time
+	}
+	if input.ExpiryDate != nil {
+		request.ExpiryDate = *input.ExpiryDate
+	}
+
+	obj, err := r.services.LotService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *mutationResolver) LotAssignPallets(ctx context.Context, id int64, palletIDs []int64) (*models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.LotService.AssignPallets(ctx, id, palletIDs, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *mutationResolver) LotUnassignPallet(ctx context.Context, id int64, palletID int64) (*models.Lot, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateLot, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.LotService.UnassignPallet(ctx, id, palletID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
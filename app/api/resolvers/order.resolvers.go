@@ -0,0 +1,348 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type orderResolver struct{ *Resolver }
+
+// Order returns graph.OrderResolver implementation.
+func (r *Resolver) Order() graph.OrderResolver { return &orderResolver{r} }
+
+func (r *orderResolver) UID(ctx context.Context, obj *models.Order) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *orderResolver) Organization(ctx context.Context, obj *models.Order) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *orderResolver) Buyer(ctx context.Context, obj *models.Order) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.BuyerOrganizationID)
+}
+
+func (r *orderResolver) Contract(ctx context.Context, obj *models.Order) (*models.Contract, error) {
+	if obj.ContractID.Valid {
+		return dataloaders.ContractLoaderFromContext(ctx, obj.ContractID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *orderResolver) Distributor(ctx context.Context, obj *models.Order) (*models.Distributor, error) {
+	if obj.DistributorID.Valid {
+		return dataloaders.DistributorLoaderFromContext(ctx, obj.DistributorID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *orderResolver) Items(ctx context.Context, obj *models.Order) ([]models.OrderItem, error) {
+	items, err := r.services.OrderService.ListItems(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return items, nil
+}
+
+func (r *orderResolver) Pallets(ctx context.Context, obj *models.Order) ([]models.Pallet, error) {
+	allocations, err := r.services.OrderService.ListAllocations(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	pallets := []models.Pallet{}
+	for _, allocation := range allocations {
+		pallet, loadErr := dataloaders.PalletLoaderFromContext(ctx, allocation.PalletID)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		if pallet != nil {
+			pallets = append(pallets, *pallet)
+		}
+	}
+	return pallets, nil
+}
+
+type orderItemResolver struct{ *Resolver }
+
+// OrderItem returns graph.OrderItemResolver implementation.
+func (r *Resolver) OrderItem() graph.OrderItemResolver { return &orderItemResolver{r} }
+
+func (r *orderItemResolver) Sku(ctx context.Context, obj *models.OrderItem) (*models.Sku, error) {
+	return dataloaders.SkuLoaderFromContext(ctx, obj.SkuID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Orders(
+	ctx context.Context,
+	search graph.SearchFilter,
+	limit int,
+	offset int,
+	status *string,
+) (*graph.OrderResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	orders, err := r.services.OrderService.List(ctx, null.StringFromPtr(status), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.OrderResult{Orders: orders, Total: len(orders)}, nil
+}
+
+func (r *queryResolver) OrderByID(ctx context.Context, id int64) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) OrderByUID(ctx context.Context, uid string) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.OrderService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) OrderByCode(ctx context.Context, code string) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) OrderCreate(ctx context.Context, input graph.UpdateOrder) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.OrderRequest{}
+	if input.Notes != nil {
+		request.Notes = input.Notes.String
+	}
+	if input.BuyerOrganizationID != nil {
+		request.BuyerOrganizationID = input.BuyerOrganizationID.Int64
+	}
+	if input.ContractID != nil {
+		request.ContractID = input.ContractID.Int64
+	}
+	if input.DistributorID != nil {
+		request.DistributorID = *input.DistributorID
+	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.OrderService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderUpdate(ctx context.Context, id int64, input graph.UpdateOrder) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.OrderService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.OrderRequest{
+		Notes:               current.Notes,
+		BuyerOrganizationID: current.BuyerOrganizationID,
+		ContractID:          current.ContractID.Int64,
+		DistributorID:       current.DistributorID,
+	}
+	if input.Notes != nil {
+		request.Notes = input.Notes.String
+	}
+	if input.BuyerOrganizationID != nil {
+		request.BuyerOrganizationID = input.BuyerOrganizationID.Int64
+	}
+	if input.ContractID != nil {
+		request.ContractID = input.ContractID.Int64
+	}
+	if input.DistributorID != nil {
+		request.DistributorID = *input.DistributorID
+	}
+
+	obj, err := r.services.OrderService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderUpdateStatus(ctx context.Context, id int64, status string) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.UpdateStatus(ctx, id, status, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderAddItem(ctx context.Context, orderID int64, skuID int64, quantity int) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.OrderItemRequest{
+		OrderID:  orderID,
+		SkuID:    skuID,
+		Quantity: int64(quantity),
+	}
+
+	obj, err := r.services.OrderService.AddItem(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderUpdateItem(ctx context.Context, id int64, quantity int) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.UpdateItem(ctx, id, int64(quantity), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderRemoveItem(ctx context.Context, id int64) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.RemoveItem(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderAllocatePallet(ctx context.Context, orderID int64, palletID int64) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.Allocate(ctx, orderID, palletID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) OrderDeallocatePallet(ctx context.Context, orderID int64, palletID int64) (*models.Order, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrder, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.OrderService.Deallocate(ctx, orderID, palletID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
@@ -3,10 +3,67 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"orijinplus/app/api/dataloaders"
 	"orijinplus/app/api/graphql/generated/graph"
 	"orijinplus/app/models"
+	"strings"
+	"time"
 )
 
+type organizationResolver struct{ *Resolver }
+
+// Organization returns graph.OrganizationResolver implementation.
+func (r *Resolver) Organization() graph.OrganizationResolver { return &organizationResolver{r} }
+
+func (r *organizationResolver) Attachments(ctx context.Context, obj *models.Organization) ([]models.StoredFile, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	files, err := r.services.FileService.ListByOwner(ctx, models.CodeOrganization, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return files, nil
+}
+
+type codeFormatResolver struct{ *Resolver }
+
+// CodeFormat returns graph.CodeFormatResolver implementation.
+func (r *Resolver) CodeFormat() graph.CodeFormatResolver { return &codeFormatResolver{r} }
+
+func (r *codeFormatResolver) Organization(ctx context.Context, obj *models.CodeFormat) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+// Example shows the code the format would hand out first
+func (r *codeFormatResolver) Example(ctx context.Context, obj *models.CodeFormat) (string, error) {
+	return obj.Format(1, obj.Period(time.Now().UTC())), nil
+}
+
+type provenanceSettingsResolver struct{ *Resolver }
+
+// ProvenanceSettings returns graph.ProvenanceSettingsResolver implementation.
+func (r *Resolver) ProvenanceSettings() graph.ProvenanceSettingsResolver {
+	return &provenanceSettingsResolver{r}
+}
+
+func (r *provenanceSettingsResolver) Organization(ctx context.Context, obj *models.ProvenanceSettings) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+type gS1SettingsResolver struct{ *Resolver }
+
+// GS1Settings returns graph.GS1SettingsResolver implementation.
+func (r *Resolver) GS1Settings() graph.GS1SettingsResolver {
+	return &gS1SettingsResolver{r}
+}
+
+func (r *gS1SettingsResolver) Organization(ctx context.Context, obj *models.GS1Settings) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
 ///////////////
 //   Query   //
 ///////////////
@@ -92,6 +149,54 @@ func (r *queryResolver) OrganizationByCode(ctx context.Context, code string) (*m
 	return obj, nil
 }
 
+func (r *queryResolver) OrganizationCodeFormats(ctx context.Context, organizationID int64) ([]models.CodeFormat, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	formats, err := r.services.OrganizationService.ListCodeFormats(ctx, organizationID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return formats, nil
+}
+
+func (r *queryResolver) OrganizationProvenanceSettings(ctx context.Context, organizationID int64) (*models.ProvenanceSettings, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	settings, err := r.services.ProvenanceService.GetSettings(ctx, organizationID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return settings, nil
+}
+
+func (r *queryResolver) OrganizationGS1Settings(ctx context.Context, organizationID int64) (*models.GS1Settings, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	settings, err := r.services.OrganizationService.GetGS1Settings(ctx, organizationID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return settings, nil
+}
+
 ///////////////
 // Mutations //
 ///////////////
@@ -117,3 +222,112 @@ func (r *mutationResolver) OrganizationUpdate(ctx context.Context, id int64, inp
 	}
 	return result, nil
 }
+
+func (r *mutationResolver) OrganizationCodeFormatSet(ctx context.Context, organizationID int64, input graph.UpdateCodeFormat) (*models.CodeFormat, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.CodeFormat{
+		OrganizationID: organizationID,
+		Entity:         input.Entity,
+		Prefix:         strings.ToUpper(strings.TrimSpace(input.Prefix)),
+		Padding:        models.DefaultCodePadding,
+	}
+	if input.Padding != nil {
+		request.Padding = *input.Padding
+	}
+	if input.ResetYearly != nil {
+		request.ResetYearly = *input.ResetYearly
+	}
+
+	result, err := r.services.OrganizationService.SetCodeFormat(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) OrganizationCodeFormatDelete(ctx context.Context, organizationID int64, entity string) (bool, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return false, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, false); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+
+	if err := r.services.OrganizationService.DeleteCodeFormat(ctx, organizationID, entity, auther); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) OrganizationProvenanceSettingsSet(ctx context.Context, organizationID int64, input graph.UpdateProvenanceSettings) (*models.ProvenanceSettings, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	// Settings left out of the input keep their current value
+	current, err := r.services.ProvenanceService.GetSettings(ctx, organizationID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	request := *current
+	if input.IsPublic != nil {
+		request.IsPublic = *input.IsPublic
+	}
+	if input.ShowOrganization != nil {
+		request.ShowOrganization = *input.ShowOrganization
+	}
+	if input.ShowDescription != nil {
+		request.ShowDescription = *input.ShowDescription
+	}
+	if input.ShowLocations != nil {
+		request.ShowLocations = *input.ShowLocations
+	}
+	if input.ShowEventDetails != nil {
+		request.ShowEventDetails = *input.ShowEventDetails
+	}
+	if input.ShowAnchoring != nil {
+		request.ShowAnchoring = *input.ShowAnchoring
+	}
+
+	result, err := r.services.ProvenanceService.SetSettings(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) OrganizationGS1SettingsSet(ctx context.Context, organizationID int64, input graph.UpdateGS1Settings) (*models.GS1Settings, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateOrganization, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.GS1Settings{
+		OrganizationID: organizationID,
+		CompanyPrefix:  input.CompanyPrefix,
+	}
+	if input.ExtensionDigit != nil {
+		request.ExtensionDigit = *input.ExtensionDigit
+	}
+
+	result, err := r.services.OrganizationService.SetGS1Settings(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return result, nil
+}
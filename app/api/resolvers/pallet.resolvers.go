@@ -3,11 +3,14 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"orijinplus/app/api/dataloaders"
 	"orijinplus/app/api/graphql/generated/graph"
 	"orijinplus/app/models"
+	"orijinplus/app/services"
 
 	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
 )
 
 type palletResolver struct{ *Resolver }
@@ -26,6 +29,13 @@ func (r *palletResolver) Container(ctx context.Context, obj *models.Pallet) (*mo
 	return nil, nil
 }
 
+func (r *palletResolver) Lot(ctx context.Context, obj *models.Pallet) (*models.Lot, error) {
+	if obj.LotID.Valid {
+		return dataloaders.LotLoaderFromContext(ctx, obj.LotID.Int64)
+	}
+	return nil, nil
+}
+
 func (r *palletResolver) Organization(ctx context.Context, obj *models.Pallet) (*models.Organization, error) {
 	if obj.OrganizationID.Valid {
 		return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID.Int64)
@@ -33,6 +43,122 @@ func (r *palletResolver) Organization(ctx context.Context, obj *models.Pallet) (
 	return nil, nil
 }
 
+func (r *palletResolver) Distributor(ctx context.Context, obj *models.Pallet) (*models.Distributor, error) {
+	distributor, err := r.services.DistributorService.GetByPalletID(ctx, obj.ID)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(err.Message)
+	}
+	return distributor, nil
+}
+
+func (r *palletResolver) Timeline(ctx context.Context, obj *models.Pallet) ([]models.TrackAction, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	actions, err := r.services.TrackActionService.ListByPalletID(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return actions, nil
+}
+
+func (r *palletResolver) History(ctx context.Context, obj *models.Pallet) ([]models.PalletAssignment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	history, err := r.services.PalletService.ListHistory(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return history, nil
+}
+
+func (r *palletResolver) Location(ctx context.Context, obj *models.Pallet) (*models.Location, error) {
+	locationID := obj.LocationID
+	if obj.ContainerID.Valid {
+		container, err := dataloaders.ContainerLoaderFromContext(ctx, obj.ContainerID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		locationID = container.LocationID
+	}
+	if locationID.Valid {
+		return dataloaders.LocationLoaderFromContext(ctx, locationID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *palletResolver) Attributes(ctx context.Context, obj *models.Pallet) ([]models.Attribute, error) {
+	return models.AttributeList(obj.Attributes), nil
+}
+
+func (r *palletResolver) Attachments(ctx context.Context, obj *models.Pallet) ([]models.StoredFile, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	files, err := r.services.FileService.ListByOwner(ctx, models.CodePallet, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return files, nil
+}
+
+func (r *palletResolver) Recalls(ctx context.Context, obj *models.Pallet) ([]models.Recall, error) {
+	recalls, err := r.services.RecallService.ListOpenByItem(ctx, models.CodePallet, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return recalls, nil
+}
+
+func (r *palletResolver) LocationHistory(ctx context.Context, obj *models.Pallet) ([]models.LocationMove, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	moves, err := r.services.PalletService.ListLocationMoves(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return moves, nil
+}
+
+type palletAssignmentResolver struct{ *Resolver }
+
+// PalletAssignment returns graph.PalletAssignmentResolver implementation.
+func (r *Resolver) PalletAssignment() graph.PalletAssignmentResolver {
+	return &palletAssignmentResolver{r}
+}
+
+func (r *palletAssignmentResolver) Pallet(ctx context.Context, obj *models.PalletAssignment) (*models.Pallet, error) {
+	return dataloaders.PalletLoaderFromContext(ctx, obj.PalletID)
+}
+
+func (r *palletAssignmentResolver) Container(ctx context.Context, obj *models.PalletAssignment) (*models.Container, error) {
+	return dataloaders.ContainerLoaderFromContext(ctx, obj.ContainerID)
+}
+
+func (r *palletAssignmentResolver) LoadedBy(ctx context.Context, obj *models.PalletAssignment) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.LoadedByID)
+}
+
+func (r *palletAssignmentResolver) UnloadedBy(ctx context.Context, obj *models.PalletAssignment) (*models.User, error) {
+	if obj.UnloadedByID.Valid {
+		return dataloaders.UserLoaderFromContext(ctx, obj.UnloadedByID.Int64)
+	}
+	return nil, nil
+}
+
 ///////////////
 //   Query   //
 ///////////////
@@ -43,6 +169,7 @@ func (r *queryResolver) Pallets(
 	limit int,
 	offset int,
 	containerID *int64,
+	attributes []models.AttributeFilter,
 ) (*graph.PalletResult, error) {
 	auther, authErr := r.GetAuther(ctx)
 	if authErr != nil {
@@ -52,8 +179,20 @@ func (r *queryResolver) Pallets(
 		return nil, fmt.Errorf(err.Message)
 	}
 
+	if len(attributes) > 0 {
+		scope := null.Int64{}
+		if containerID != nil && *containerID != 0 {
+			scope = null.Int64From(*containerID)
+		}
+		pallets, err := r.services.PalletService.ListByAttributes(ctx, scope, attributes, auther)
+		if err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+		return &graph.PalletResult{Pallets: pallets, Total: len(pallets)}, nil
+	}
+
 	if containerID != nil && *containerID != 0 {
-		pallets, err := r.services.PalletService.List(ctx, auther)
+		pallets, err := r.services.PalletService.ListByContainerID(ctx, *containerID, auther)
 		if err != nil {
 			return nil, fmt.Errorf(err.Message)
 		}
@@ -123,6 +262,41 @@ func (r *queryResolver) PalletByCode(ctx context.Context, code string) (*models.
 	return obj, nil
 }
 
+func (r *queryResolver) PalletBySscc(ctx context.Context, sscc string) (*models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PalletService.GetBySSCC(ctx, sscc, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// PalletHistory lists the containers a pallet has been in, latest first
+func (r *queryResolver) PalletHistory(ctx context.Context, palletID int64) ([]models.PalletAssignment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	history, err := r.services.PalletService.ListHistory(ctx, palletID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return history, nil
+}
+
 ///////////////
 // Mutations //
 ///////////////
@@ -146,6 +320,7 @@ func (r *mutationResolver) PalletCreate(ctx context.Context, input graph.UpdateP
 	if input.OrganizationID != nil {
 		request.OrganizationID = *input.OrganizationID
 	}
+	request.Attributes = mergeAttributes(nil, input.Attributes)
 
 	obj, err := r.services.PalletService.Create(ctx, request, auther)
 	if err != nil {
@@ -155,12 +330,12 @@ func (r *mutationResolver) PalletCreate(ctx context.Context, input graph.UpdateP
 	return obj, nil
 }
 
-func (r *mutationResolver) PalletUpdate(ctx context.Context, id int64, input graph.UpdatePallet) (*models.Pallet, error) {
+func (r *mutationResolver) PalletCreateBulk(ctx context.Context, count int, input graph.UpdatePallet, withLabels *bool) (*graph.PalletBulkResult, error) {
 	auther, authErr := r.GetAuther(ctx)
 	if authErr != nil {
 		return nil, authErr
 	}
-	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePallet, true, false); err != nil {
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreatePallet, true, false); err != nil {
 		return nil, fmt.Errorf(err.Message)
 	}
 
@@ -171,8 +346,124 @@ func (r *mutationResolver) PalletUpdate(ctx context.Context, id int64, input gra
 	if input.ContainerID != nil {
 		request.ContainerID = *input.ContainerID
 	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+	request.Attributes = mergeAttributes(nil, input.Attributes)
 
-	obj, err := r.services.PalletService.Create(ctx, request, auther)
+	pallets, err := r.services.PalletService.CreateBulk(ctx, request, count, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	result := &graph.PalletBulkResult{Pallets: pallets, Total: len(pallets)}
+	if withLabels != nil && *withLabels {
+		result.LabelFile = r.uploadBulkLabels(models.LabelPallet, auther.OrganizationID.Int64, services.PalletLabels(pallets))
+	}
+
+	return result, nil
+}
+
+func (r *mutationResolver) PalletUpdate(ctx context.Context, id int64, input graph.UpdatePallet) (*models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	// Pallets change containers through palletMove and palletUnload so their custody history is kept
+	if input.ContainerID != nil {
+		return nil, fmt.Errorf("container cannot be updated, use palletMove or palletUnload")
+	}
+
+	current, err := r.services.PalletService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.PalletRequest{
+		Description:    current.Description,
+		ContainerID:    current.ContainerID,
+		OrganizationID: current.OrganizationID,
+		Attributes:     mergeAttributes(current.Attributes, input.Attributes),
+	}
+	if input.Description != nil {
+		request.Description = input.Description.String
+	}
+
+	obj, err := r.services.PalletService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) PalletMove(ctx context.Context, palletID int64, toContainerID int64) (*models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PalletService.Move(ctx, palletID, toContainerID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) PalletUnload(ctx context.Context, palletID int64) (*models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PalletService.Unload(ctx, palletID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) PalletPlace(ctx context.Context, palletID int64, locationID *int64) (*models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	location := null.Int64FromPtr(locationID)
+	obj, err := r.services.PalletService.Place(ctx, palletID, location, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// PalletSSCCAssign gives a pallet created before its organization set a GS1 company prefix its SSCC
+func (r *mutationResolver) PalletSSCCAssign(ctx context.Context, id int64) (*models.Pallet, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePallet, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PalletService.AssignSSCC(ctx, id, auther)
 	if err != nil {
 		return nil, fmt.Errorf(err.Message)
 	}
@@ -0,0 +1,234 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type purchaseRecordResolver struct{ *Resolver }
+
+// PurchaseRecord returns graph.PurchaseRecordResolver implementation.
+func (r *Resolver) PurchaseRecord() graph.PurchaseRecordResolver { return &purchaseRecordResolver{r} }
+
+func (r *purchaseRecordResolver) UID(ctx context.Context, obj *models.PurchaseRecord) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *purchaseRecordResolver) ProductUID(ctx context.Context, obj *models.PurchaseRecord) (string, error) {
+	return obj.ProductUID.String(), nil
+}
+
+func (r *purchaseRecordResolver) RedeemedBy(ctx context.Context, obj *models.PurchaseRecord) (*models.User, error) {
+	if obj.RedeemedByID.Valid {
+		return dataloaders.UserLoaderFromContext(ctx, obj.RedeemedByID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *purchaseRecordResolver) Organization(ctx context.Context, obj *models.PurchaseRecord) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *purchaseRecordResolver) CreatedBy(ctx context.Context, obj *models.PurchaseRecord) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) PurchaseRecords(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.PurchaseRecordResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPurchaseRecord, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	records, err := r.services.PurchaseRecordService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.PurchaseRecordResult{PurchaseRecords: records, Total: len(records)}, nil
+}
+
+func (r *queryResolver) MyPurchaseRecords(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.PurchaseRecordResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPurchaseRecord, false, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	records, err := r.services.PurchaseRecordService.ListMine(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.PurchaseRecordResult{PurchaseRecords: records, Total: len(records)}, nil
+}
+
+func (r *queryResolver) PurchaseRecordByID(ctx context.Context, id int64) (*models.PurchaseRecord, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPurchaseRecord, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PurchaseRecordService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) PurchaseRecordByUID(ctx context.Context, uid string) (*models.PurchaseRecord, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPurchaseRecord, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.PurchaseRecordService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) PurchaseRecordByCode(ctx context.Context, code string) (*models.PurchaseRecord, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadPurchaseRecord, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PurchaseRecordService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) PurchaseRecordCreate(ctx context.Context, input graph.UpdatePurchaseRecord) (*models.PurchaseRecord, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreatePurchaseRecord, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.PurchaseRecordRequest{}
+	if err := purchaseRecordRequest(&request, input); err != nil {
+		return nil, err
+	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.PurchaseRecordService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) PurchaseRecordUpdate(ctx context.Context, id int64, input graph.UpdatePurchaseRecord) (*models.PurchaseRecord, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePurchaseRecord, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.PurchaseRecordService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.PurchaseRecordRequest{
+		ProductUID: current.ProductUID,
+		BuyerEmail: current.BuyerEmail,
+		BuyerPhone: current.BuyerPhone,
+		Retailer:   current.Retailer,
+		Points:     current.Points,
+	}
+	if err := purchaseRecordRequest(&request, input); err != nil {
+		return nil, err
+	}
+
+	obj, err := r.services.PurchaseRecordService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) PurchaseRedeem(ctx context.Context, token string) (*models.PurchaseRecord, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdatePurchaseRecord, false, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.PurchaseRecordService.Redeem(ctx, token, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// purchaseRecordRequest copies the fields set in the input onto the request
+func purchaseRecordRequest(request *models.PurchaseRecordRequest, input graph.UpdatePurchaseRecord) error {
+	if input.ProductUID != nil {
+		productUID, err := uuid.FromString(input.ProductUID.String)
+		if err != nil {
+			return fmt.Errorf("invalid product uid")
+		}
+		request.ProductUID = productUID
+	}
+	if input.BuyerEmail != nil {
+		request.BuyerEmail = *input.BuyerEmail
+	}
+	if input.BuyerPhone != nil {
+		request.BuyerPhone = *input.BuyerPhone
+	}
+	if input.Retailer != nil {
+		request.Retailer = input.Retailer.String
+	}
+	if input.Points != nil {
+		request.Points = input.Points.Int64
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+type recallResolver struct{ *Resolver }
+
+// Recall returns graph.RecallResolver implementation.
+func (r *Resolver) Recall() graph.RecallResolver { return &recallResolver{r} }
+
+func (r *recallResolver) UID(ctx context.Context, obj *models.Recall) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *recallResolver) Sku(ctx context.Context, obj *models.Recall) (*models.Sku, error) {
+	if !obj.SkuID.Valid {
+		return nil, nil
+	}
+	return dataloaders.SkuLoaderFromContext(ctx, obj.SkuID.Int64)
+}
+
+func (r *recallResolver) Lot(ctx context.Context, obj *models.Recall) (*models.Lot, error) {
+	if !obj.LotID.Valid {
+		return nil, nil
+	}
+	return dataloaders.LotLoaderFromContext(ctx, obj.LotID.Int64)
+}
+
+func (r *recallResolver) Organization(ctx context.Context, obj *models.Recall) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *recallResolver) Items(ctx context.Context, obj *models.Recall) ([]models.RecallItem, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	items, err := r.services.RecallService.ListItems(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return items, nil
+}
+
+func (r *recallResolver) Progress(ctx context.Context, obj *models.Recall) (*models.RecallProgress, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	progress, err := r.services.RecallService.Progress(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return progress, nil
+}
+
+func (r *recallResolver) CreatedBy(ctx context.Context, obj *models.Recall) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID)
+}
+
+type recallItemResolver struct{ *Resolver }
+
+// RecallItem returns graph.RecallItemResolver implementation.
+func (r *Resolver) RecallItem() graph.RecallItemResolver { return &recallItemResolver{r} }
+
+func (r *recallItemResolver) Organization(ctx context.Context, obj *models.RecallItem) (*models.Organization, error) {
+	if !obj.OrganizationID.Valid {
+		return nil, nil
+	}
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID.Int64)
+}
+
+func (r *recallItemResolver) Customer(ctx context.Context, obj *models.RecallItem) (*models.User, error) {
+	if !obj.CustomerID.Valid {
+		return nil, nil
+	}
+	return dataloaders.UserLoaderFromContext(ctx, obj.CustomerID.Int64)
+}
+
+func (r *recallItemResolver) ResolvedBy(ctx context.Context, obj *models.RecallItem) (*models.User, error) {
+	if !obj.ResolvedByID.Valid {
+		return nil, nil
+	}
+	return dataloaders.UserLoaderFromContext(ctx, obj.ResolvedByID.Int64)
+}
+
+type notificationResolver struct{ *Resolver }
+
+// Notification returns graph.NotificationResolver implementation.
+func (r *Resolver) Notification() graph.NotificationResolver { return &notificationResolver{r} }
+
+func (r *notificationResolver) Organization(ctx context.Context, obj *models.Notification) (*models.Organization, error) {
+	if !obj.OrganizationID.Valid {
+		return nil, nil
+	}
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID.Int64)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Recalls(ctx context.Context, limit int, offset int) (*graph.RecallResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadRecall, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	recalls, err := r.services.RecallService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.RecallResult{Recalls: recalls, Total: len(recalls)}, nil
+}
+
+func (r *queryResolver) RecallByID(ctx context.Context, id int64) (*models.Recall, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	// Customers read the recalls of products they bought, which they are notified of
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadRecall, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.RecallService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *queryResolver) MyNotifications(ctx context.Context) ([]models.Notification, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	notifications, err := r.services.NotificationService.ListMine(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return notifications, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) RecallCreate(ctx context.Context, input graph.NewRecall) (*models.Recall, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateRecall, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.RecallRequest{
+		Title: input.Title,
+		Scope: input.Scope,
+		UIDs:  input.Uids,
+	}
+	if input.Reason != nil {
+		request.Reason = input.Reason.String
+	}
+	if input.SkuID != nil {
+		request.SkuID = *input.SkuID
+	}
+	if input.LotID != nil {
+		request.LotID = *input.LotID
+	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.RecallService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *mutationResolver) RecallResolveItem(ctx context.Context, id int64, itemID int64) (*models.RecallItem, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateRecall, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.RecallService.ResolveItem(ctx, id, itemID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *mutationResolver) RecallClose(ctx context.Context, id int64) (*models.Recall, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateRecall, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.RecallService.Close(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
+
+func (r *mutationResolver) NotificationMarkRead(ctx context.Context, id int64) (*models.Notification, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadOrganization, true, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.NotificationService.MarkRead(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return obj, nil
+}
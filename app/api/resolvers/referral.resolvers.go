@@ -0,0 +1,167 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/volatiletech/null"
+)
+
+type referralResolver struct{ *Resolver }
+
+// Referral returns graph.ReferralResolver implementation.
+func (r *Resolver) Referral() graph.ReferralResolver { return &referralResolver{r} }
+
+func (r *referralResolver) Referrer(ctx context.Context, obj *models.Referral) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.ReferrerID)
+}
+
+func (r *referralResolver) Referee(ctx context.Context, obj *models.Referral) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.RefereeID)
+}
+
+type referralRuleResolver struct{ *Resolver }
+
+// ReferralRule returns graph.ReferralRuleResolver implementation.
+func (r *Resolver) ReferralRule() graph.ReferralRuleResolver { return &referralRuleResolver{r} }
+
+func (r *referralRuleResolver) CreatedBy(ctx context.Context, obj *models.ReferralRule) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Referrals(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ReferralResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadUser, false, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	referrals, err := r.services.ReferralService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.ReferralResult{Referrals: referrals, Total: len(referrals)}, nil
+}
+
+func (r *queryResolver) MyReferrals(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.ReferralResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadUser, false, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	referrals, err := r.services.ReferralService.ListMine(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.ReferralResult{Referrals: referrals, Total: len(referrals)}, nil
+}
+
+func (r *queryResolver) ReferralStats(ctx context.Context, userID *int64) (*models.ReferralStats, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadUser, false, true); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	stats, err := r.services.ReferralService.GetStats(ctx, null.Int64FromPtr(userID), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return stats, nil
+}
+
+func (r *queryResolver) ReferralRules(ctx context.Context) ([]models.ReferralRule, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadUser, false, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	rules, err := r.services.ReferralService.ListRules(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return rules, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) ReferralRuleCreate(ctx context.Context, input graph.UpdateReferralRule) (*models.ReferralRule, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateUser, false, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ReferralRuleRequest{IsActive: true}
+	referralRuleRequest(&request, input)
+
+	obj, err := r.services.ReferralService.CreateRule(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ReferralRuleUpdate(ctx context.Context, id int64, input graph.UpdateReferralRule) (*models.ReferralRule, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateUser, false, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.ReferralService.GetRuleByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ReferralRuleRequest{
+		ReferrerPoints: current.ReferrerPoints,
+		RefereePoints:  current.RefereePoints,
+		IsActive:       current.IsActive,
+	}
+	referralRuleRequest(&request, input)
+
+	obj, err := r.services.ReferralService.UpdateRule(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// referralRuleRequest copies the fields set in the input onto the request
+func referralRuleRequest(request *models.ReferralRuleRequest, input graph.UpdateReferralRule) {
+	if input.ReferrerPoints != nil {
+		request.ReferrerPoints = input.ReferrerPoints.Int64
+	}
+	if input.RefereePoints != nil {
+		request.RefereePoints = input.RefereePoints.Int64
+	}
+	if input.IsActive != nil {
+		request.IsActive = input.IsActive.Bool
+	}
+}
@@ -0,0 +1,269 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+	"orijinplus/app/services"
+)
+
+type shipmentResolver struct{ *Resolver }
+
+// Shipment returns graph.ShipmentResolver implementation.
+func (r *Resolver) Shipment() graph.ShipmentResolver { return &shipmentResolver{r} }
+
+func (r *shipmentResolver) UID(ctx context.Context, obj *models.Shipment) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *shipmentResolver) OriginAddress(ctx context.Context, obj *models.Shipment) (*models.Address, error) {
+	return dataloaders.AddressLoaderFromContext(ctx, obj.OriginAddressID)
+}
+
+func (r *shipmentResolver) DestinationAddress(ctx context.Context, obj *models.Shipment) (*models.Address, error) {
+	return dataloaders.AddressLoaderFromContext(ctx, obj.DestinationAddressID)
+}
+
+func (r *shipmentResolver) Organization(ctx context.Context, obj *models.Shipment) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *shipmentResolver) Containers(ctx context.Context, obj *models.Shipment) ([]models.Container, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	containers, err := r.services.ShipmentService.ListContainers(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return containers, nil
+}
+
+func (r *shipmentResolver) CreatedBy(ctx context.Context, obj *models.Shipment) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Shipments(ctx context.Context, limit int, offset int) (*graph.ShipmentResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	shipments, err := r.services.ShipmentService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.ShipmentResult{Shipments: shipments, Total: len(shipments)}, nil
+}
+
+func (r *queryResolver) ShipmentByID(ctx context.Context, id int64) (*models.Shipment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ShipmentService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) ShipmentCreate(ctx context.Context, input graph.UpdateShipment) (*models.Shipment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.ShipmentRequest{}
+	applyShipmentInput(&request, input)
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.ShipmentService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ShipmentUpdate(ctx context.Context, id int64, input graph.UpdateShipment) (*models.Shipment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.ShipmentService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	// Start from the current values, only the given fields are changed
+	request := models.ShipmentRequest{
+		OriginAddressID:      current.OriginAddressID,
+		DestinationAddressID: current.DestinationAddressID,
+		Carrier:              current.Carrier,
+		TransportReference:   current.TransportReference,
+		ETD:                  current.ETD,
+		ETA:                  current.ETA,
+		Notes:                current.Notes,
+	}
+	applyShipmentInput(&request, input)
+
+	obj, err := r.services.ShipmentService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ShipmentAddContainer(ctx context.Context, id int64, containerID int64) (*models.Shipment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ShipmentService.AddContainer(ctx, id, containerID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ShipmentRemoveContainer(ctx context.Context, id int64, containerID int64) (*models.Shipment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ShipmentService.RemoveContainer(ctx, id, containerID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) ShipmentDepart(ctx context.Context, id int64) (*models.Shipment, error) {
+	return r.updateShipmentStatus(ctx, id, models.ShipmentDeparted)
+}
+
+func (r *mutationResolver) ShipmentArrive(ctx context.Context, id int64) (*models.Shipment, error) {
+	return r.updateShipmentStatus(ctx, id, models.ShipmentArrived)
+}
+
+func (r *mutationResolver) ShipmentCancel(ctx context.Context, id int64) (*models.Shipment, error) {
+	return r.updateShipmentStatus(ctx, id, models.ShipmentCancelled)
+}
+
+func (r *mutationResolver) ShipmentManifest(ctx context.Context, id int64, format string) (*models.File, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	if format != models.ManifestPDF && format != models.ManifestCSV {
+		return nil, fmt.Errorf("format must be %s or %s", models.ManifestPDF, models.ManifestCSV)
+	}
+
+	manifest, err := r.services.ShipmentService.Manifest(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	content := []byte{}
+	if format == models.ManifestPDF {
+		content = services.ManifestPDF(manifest)
+	} else {
+		content, err = services.ManifestCSV(manifest)
+		if err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+	}
+
+	file, err := r.filestore.UploadFile(manifest.Shipment.Code+"_manifest."+format, content)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return file, nil
+}
+
+// updateShipmentStatus moves a shipment to the given status of its lifecycle
+func (r *mutationResolver) updateShipmentStatus(ctx context.Context, id int64, status string) (*models.Shipment, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateShipment, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.ShipmentService.UpdateStatus(ctx, id, status, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func applyShipmentInput(request *models.ShipmentRequest, input graph.UpdateShipment) {
+	if input.OriginAddressID != nil {
+		request.OriginAddressID = input.OriginAddressID.Int64
+	}
+	if input.DestinationAddressID != nil {
+		request.DestinationAddressID = input.DestinationAddressID.Int64
+	}
+	if input.Carrier != nil {
+		request.Carrier = input.Carrier.String
+	}
+	if input.TransportReference != nil {
+		request.TransportReference = input.TransportReference.String
+	}
+	if input.Etd != nil {
+		request.ETD = *input.Etd
+	}
+	if input.Eta != nil {
+		request.ETA = *input.Eta
+	}
+	if input.Notes != nil {
+		request.Notes = input.Notes.String
+	}
+}
@@ -0,0 +1,213 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type skuResolver struct{ *Resolver }
+
+// Sku returns graph.SkuResolver implementation.
+func (r *Resolver) Sku() graph.SkuResolver { return &skuResolver{r} }
+
+func (r *skuResolver) UID(ctx context.Context, obj *models.Sku) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *skuResolver) Organization(ctx context.Context, obj *models.Sku) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Skus(ctx context.Context, search graph.SearchFilter, limit int, offset int) (*graph.SkuResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	skus, err := r.services.SkuService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.SkuResult{Skus: skus, Total: len(skus)}, nil
+}
+
+func (r *queryResolver) SkuByID(ctx context.Context, id int64) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.SkuService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) SkuByUID(ctx context.Context, uid string) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.SkuService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) SkuByCode(ctx context.Context, code string) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.SkuService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) SkuCreate(ctx context.Context, input graph.UpdateSku) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.SkuRequest{}
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+	if input.Description != nil {
+		request.Description = input.Description.String
+	}
+	if input.Price != nil {
+		request.Price = input.Price.Int64
+	}
+	if input.Gtin != nil {
+		request.GTIN = *input.Gtin
+	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.SkuService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) SkuUpdate(ctx context.Context, id int64, input graph.UpdateSku) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.SkuService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.SkuRequest{
+		Name:        current.Name,
+		Description: current.Description,
+		Price:       current.Price,
+		GTIN:        current.GTIN,
+	}
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+	if input.Description != nil {
+		request.Description = input.Description.String
+	}
+	if input.Price != nil {
+		request.Price = input.Price.Int64
+	}
+	if input.Gtin != nil {
+		request.GTIN = *input.Gtin
+	}
+
+	obj, err := r.services.SkuService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) SkuArchive(ctx context.Context, id int64) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.SkuService.Archive(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) SkuUnarchive(ctx context.Context, id int64) (*models.Sku, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateSKU, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.SkuService.Unarchive(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
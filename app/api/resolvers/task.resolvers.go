@@ -0,0 +1,297 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type taskResolver struct{ *Resolver }
+
+// Task returns graph.TaskResolver implementation.
+func (r *Resolver) Task() graph.TaskResolver { return &taskResolver{r} }
+
+func (r *taskResolver) UID(ctx context.Context, obj *models.Task) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *taskResolver) Assignee(ctx context.Context, obj *models.Task) (*models.User, error) {
+	if obj.AssigneeUserID.Valid {
+		return dataloaders.UserLoaderFromContext(ctx, obj.AssigneeUserID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *taskResolver) AssigneeRole(ctx context.Context, obj *models.Task) (*models.Role, error) {
+	if obj.AssigneeRoleID.Valid {
+		return dataloaders.RoleLoaderFromContext(ctx, obj.AssigneeRoleID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *taskResolver) Container(ctx context.Context, obj *models.Task) (*models.Container, error) {
+	if obj.ContainerID.Valid {
+		return dataloaders.ContainerLoaderFromContext(ctx, obj.ContainerID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *taskResolver) Pallet(ctx context.Context, obj *models.Task) (*models.Pallet, error) {
+	if obj.PalletID.Valid {
+		return dataloaders.PalletLoaderFromContext(ctx, obj.PalletID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *taskResolver) Comments(ctx context.Context, obj *models.Task) ([]models.TaskComment, error) {
+	comments, err := r.services.TaskService.ListComments(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return comments, nil
+}
+
+func (r *taskResolver) Organization(ctx context.Context, obj *models.Task) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *taskResolver) CreatedBy(ctx context.Context, obj *models.Task) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID)
+}
+
+type taskCommentResolver struct{ *Resolver }
+
+// TaskComment returns graph.TaskCommentResolver implementation.
+func (r *Resolver) TaskComment() graph.TaskCommentResolver { return &taskCommentResolver{r} }
+
+func (r *taskCommentResolver) Author(ctx context.Context, obj *models.TaskComment) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.UserID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Tasks(
+	ctx context.Context,
+	search graph.SearchFilter,
+	limit int,
+	offset int,
+	status *string,
+) (*graph.TaskResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	tasks, err := r.services.TaskService.List(ctx, null.StringFromPtr(status), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.TaskResult{Tasks: tasks, Total: len(tasks)}, nil
+}
+
+func (r *queryResolver) MyTasks(
+	ctx context.Context,
+	search graph.SearchFilter,
+	limit int,
+	offset int,
+	status *string,
+) (*graph.TaskResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	tasks, err := r.services.TaskService.ListMine(ctx, null.StringFromPtr(status), auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.TaskResult{Tasks: tasks, Total: len(tasks)}, nil
+}
+
+func (r *queryResolver) TaskByID(ctx context.Context, id int64) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.TaskService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) TaskByUID(ctx context.Context, uid string) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.TaskService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) TaskByCode(ctx context.Context, code string) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.TaskService.GetByCode(ctx, code, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) TaskCreate(ctx context.Context, input graph.UpdateTask) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.TaskRequest{}
+	taskRequest(&request, input)
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.TaskService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) TaskUpdate(ctx context.Context, id int64, input graph.UpdateTask) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.TaskService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.TaskRequest{
+		Title:          current.Title,
+		Description:    current.Description,
+		DueDate:        current.DueDate,
+		AssigneeUserID: current.AssigneeUserID,
+		AssigneeRoleID: current.AssigneeRoleID,
+		ContainerID:    current.ContainerID,
+		PalletID:       current.PalletID,
+	}
+	taskRequest(&request, input)
+
+	obj, err := r.services.TaskService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) TaskUpdateStatus(ctx context.Context, id int64, status string) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.TaskService.UpdateStatus(ctx, id, status, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) TaskAddComment(ctx context.Context, id int64, body string) (*models.Task, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTask, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.TaskService.AddComment(ctx, id, body, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+// taskRequest copies the fields set in the input onto the request
+func taskRequest(request *models.TaskRequest, input graph.UpdateTask) {
+	if input.Title != nil {
+		request.Title = input.Title.String
+	}
+	if input.Description != nil {
+		request.Description = input.Description.String
+	}
+	if input.DueDate != nil {
+		request.DueDate = *input.DueDate
+	}
+	if input.AssigneeUserID != nil {
+		request.AssigneeUserID = *input.AssigneeUserID
+	}
+	if input.AssigneeRoleID != nil {
+		request.AssigneeRoleID = *input.AssigneeRoleID
+	}
+	if input.ContainerID != nil {
+		request.ContainerID = *input.ContainerID
+	}
+	if input.PalletID != nil {
+		request.PalletID = *input.PalletID
+	}
+}
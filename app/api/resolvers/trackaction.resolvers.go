@@ -0,0 +1,151 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type trackActionResolver struct{ *Resolver }
+
+// TrackAction returns graph.TrackActionResolver implementation.
+func (r *Resolver) TrackAction() graph.TrackActionResolver { return &trackActionResolver{r} }
+
+func (r *trackActionResolver) UID(ctx context.Context, obj *models.TrackAction) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *trackActionResolver) Container(ctx context.Context, obj *models.TrackAction) (*models.Container, error) {
+	if obj.ContainerID.Valid {
+		return dataloaders.ContainerLoaderFromContext(ctx, obj.ContainerID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *trackActionResolver) Pallet(ctx context.Context, obj *models.TrackAction) (*models.Pallet, error) {
+	if obj.PalletID.Valid {
+		return dataloaders.PalletLoaderFromContext(ctx, obj.PalletID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *trackActionResolver) Actor(ctx context.Context, obj *models.TrackAction) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.ActorID)
+}
+
+func (r *trackActionResolver) Organization(ctx context.Context, obj *models.TrackAction) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) TrackActions(ctx context.Context, containerID *int64, palletID *int64) (*graph.TrackActionResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTrackAction, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	if (containerID == nil) == (palletID == nil) {
+		return nil, fmt.Errorf("either a container id or a pallet id is required")
+	}
+
+	if containerID != nil {
+		actions, err := r.services.TrackActionService.ListByContainerID(ctx, *containerID, auther)
+		if err != nil {
+			return nil, fmt.Errorf(err.Message)
+		}
+		return &graph.TrackActionResult{TrackActions: actions, Total: len(actions)}, nil
+	}
+
+	actions, err := r.services.TrackActionService.ListByPalletID(ctx, *palletID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.TrackActionResult{TrackActions: actions, Total: len(actions)}, nil
+}
+
+func (r *queryResolver) TrackActionByID(ctx context.Context, id int64) (*models.TrackAction, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTrackAction, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.TrackActionService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) TrackActionByUID(ctx context.Context, uid string) (*models.TrackAction, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadTrackAction, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	objUUID, uuidErr := uuid.FromString(uid)
+	if uuidErr != nil {
+		return nil, fmt.Errorf("invalid uid")
+	}
+
+	obj, err := r.services.TrackActionService.GetByUID(ctx, objUUID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) TrackActionCreate(ctx context.Context, input graph.NewTrackAction) (*models.TrackAction, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateTrackAction, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.TrackActionRequest{
+		Action:  input.Action,
+		Payload: input.Payload,
+	}
+	if input.ContainerID != nil {
+		request.ContainerID = *input.ContainerID
+	}
+	if input.PalletID != nil {
+		request.PalletID = *input.PalletID
+	}
+	if input.Location != nil {
+		request.Location = input.Location.String
+	}
+	if input.OccurredAt != nil {
+		request.OccurredAt = *input.OccurredAt
+	}
+
+	obj, err := r.services.TrackActionService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
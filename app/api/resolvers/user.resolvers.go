@@ -40,6 +40,45 @@ func (r *userResolver) Profile(ctx context.Context, obj *models.User) (*models.P
 	return dataloaders.ProfileLoaderFromContext(ctx, obj.ID)
 }
 
+// Profile returns graph.ProfileResolver implementation.
+func (r *Resolver) Profile() graph.ProfileResolver { return &profileResolver{r} }
+
+type profileResolver struct{ *Resolver }
+
+// WalletBalance is only shown to the owner of the wallet and to admins
+func (r *profileResolver) WalletBalance(ctx context.Context, obj *models.Profile) (*models.WalletBalance, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if !auther.IsAdmin && auther.ID != obj.UserID {
+		return nil, nil
+	}
+
+	balance, err := r.services.WalletService.GetBalance(ctx, obj.UserID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return balance, nil
+}
+
+// WalletHistory is only shown to the owner of the wallet and to admins
+func (r *profileResolver) WalletHistory(ctx context.Context, obj *models.Profile) (*graph.WalletPointEntryResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if !auther.IsAdmin && auther.ID != obj.UserID {
+		return nil, nil
+	}
+
+	entries, err := r.services.WalletService.ListEntries(ctx, obj.UserID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.WalletPointEntryResult{WalletPointEntries: entries, Total: len(entries)}, nil
+}
+
 ///////////////
 //   Query   //
 ///////////////
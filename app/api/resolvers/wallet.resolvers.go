@@ -0,0 +1,74 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+)
+
+type walletPointEntryResolver struct{ *Resolver }
+
+// WalletPointEntry returns graph.WalletPointEntryResolver implementation.
+func (r *Resolver) WalletPointEntry() graph.WalletPointEntryResolver {
+	return &walletPointEntryResolver{r}
+}
+
+func (r *walletPointEntryResolver) UID(ctx context.Context, obj *models.WalletPointEntry) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *walletPointEntryResolver) User(ctx context.Context, obj *models.WalletPointEntry) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.UserID)
+}
+
+func (r *walletPointEntryResolver) CreatedBy(ctx context.Context, obj *models.WalletPointEntry) (*models.User, error) {
+	if obj.CreatedByID.Valid {
+		return dataloaders.UserLoaderFromContext(ctx, obj.CreatedByID.Int64)
+	}
+	return nil, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) WalletAdjust(ctx context.Context, input graph.NewWalletAdjustment) (*models.WalletPointEntry, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateUser, false, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.WalletAdjustmentRequest{
+		UserID: input.UserID,
+		Points: int64(input.Points),
+		Reason: input.Reason,
+	}
+
+	obj, err := r.services.WalletService.Adjust(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) WalletExpirePoints(ctx context.Context) (*graph.WalletPointEntryResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateUser, false, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	entries, err := r.services.WalletService.ExpireAll(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.WalletPointEntryResult{WalletPointEntries: entries, Total: len(entries)}, nil
+}
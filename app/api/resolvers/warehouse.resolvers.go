@@ -0,0 +1,386 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/graphql/generated/graph"
+	"orijinplus/app/models"
+	"strings"
+)
+
+type warehouseResolver struct{ *Resolver }
+
+// Warehouse returns graph.WarehouseResolver implementation.
+func (r *Resolver) Warehouse() graph.WarehouseResolver { return &warehouseResolver{r} }
+
+func (r *warehouseResolver) UID(ctx context.Context, obj *models.Warehouse) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *warehouseResolver) Address(ctx context.Context, obj *models.Warehouse) (*models.Address, error) {
+	if obj.AddressID.Valid {
+		return dataloaders.AddressLoaderFromContext(ctx, obj.AddressID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *warehouseResolver) Organization(ctx context.Context, obj *models.Warehouse) (*models.Organization, error) {
+	return dataloaders.OrganizationLoaderFromContext(ctx, obj.OrganizationID)
+}
+
+func (r *warehouseResolver) Locations(ctx context.Context, obj *models.Warehouse) ([]models.Location, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	locations, err := r.services.WarehouseService.ListLocations(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return locations, nil
+}
+
+type locationResolver struct{ *Resolver }
+
+// Location returns graph.LocationResolver implementation.
+func (r *Resolver) Location() graph.LocationResolver { return &locationResolver{r} }
+
+func (r *locationResolver) UID(ctx context.Context, obj *models.Location) (string, error) {
+	return obj.UID.String(), nil
+}
+
+func (r *locationResolver) Warehouse(ctx context.Context, obj *models.Location) (*models.Warehouse, error) {
+	return dataloaders.WarehouseLoaderFromContext(ctx, obj.WarehouseID)
+}
+
+func (r *locationResolver) Parent(ctx context.Context, obj *models.Location) (*models.Location, error) {
+	if obj.ParentID.Valid {
+		return dataloaders.LocationLoaderFromContext(ctx, obj.ParentID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *locationResolver) Children(ctx context.Context, obj *models.Location) ([]models.Location, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	children, err := r.services.WarehouseService.ListChildLocations(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return children, nil
+}
+
+func (r *locationResolver) Path(ctx context.Context, obj *models.Location) (string, error) {
+	codes := []string{obj.Code}
+	for parentID := obj.ParentID; parentID.Valid; {
+		parent, err := dataloaders.LocationLoaderFromContext(ctx, parentID.Int64)
+		if err != nil {
+			return "", err
+		}
+		codes = append([]string{parent.Code}, codes...)
+		parentID = parent.ParentID
+	}
+
+	warehouse, err := dataloaders.WarehouseLoaderFromContext(ctx, obj.WarehouseID)
+	if err != nil {
+		return "", err
+	}
+	codes = append([]string{warehouse.Code}, codes...)
+
+	return strings.Join(codes, " / "), nil
+}
+
+type locationMoveResolver struct{ *Resolver }
+
+// LocationMove returns graph.LocationMoveResolver implementation.
+func (r *Resolver) LocationMove() graph.LocationMoveResolver { return &locationMoveResolver{r} }
+
+func (r *locationMoveResolver) Pallet(ctx context.Context, obj *models.LocationMove) (*models.Pallet, error) {
+	if obj.PalletID.Valid {
+		return dataloaders.PalletLoaderFromContext(ctx, obj.PalletID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *locationMoveResolver) Container(ctx context.Context, obj *models.LocationMove) (*models.Container, error) {
+	if obj.ContainerID.Valid {
+		return dataloaders.ContainerLoaderFromContext(ctx, obj.ContainerID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *locationMoveResolver) FromLocation(ctx context.Context, obj *models.LocationMove) (*models.Location, error) {
+	if obj.FromLocationID.Valid {
+		return dataloaders.LocationLoaderFromContext(ctx, obj.FromLocationID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *locationMoveResolver) ToLocation(ctx context.Context, obj *models.LocationMove) (*models.Location, error) {
+	if obj.ToLocationID.Valid {
+		return dataloaders.LocationLoaderFromContext(ctx, obj.ToLocationID.Int64)
+	}
+	return nil, nil
+}
+
+func (r *locationMoveResolver) Actor(ctx context.Context, obj *models.LocationMove) (*models.User, error) {
+	return dataloaders.UserLoaderFromContext(ctx, obj.ActorID)
+}
+
+///////////////
+//   Query   //
+///////////////
+
+func (r *queryResolver) Warehouses(ctx context.Context, limit int, offset int) (*graph.WarehouseResult, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	warehouses, err := r.services.WarehouseService.List(ctx, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+	return &graph.WarehouseResult{Warehouses: warehouses, Total: len(warehouses)}, nil
+}
+
+func (r *queryResolver) WarehouseByID(ctx context.Context, id int64) (*models.Warehouse, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.WarehouseService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) LocationByID(ctx context.Context, id int64) (*models.Location, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.WarehouseService.GetLocation(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *queryResolver) LocationContents(ctx context.Context, id int64, nested *bool) (*graph.LocationContents, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.ReadWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	pallets, containers, err := r.services.WarehouseService.ListContents(ctx, id, nested != nil && *nested, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return &graph.LocationContents{Pallets: pallets, Containers: containers}, nil
+}
+
+///////////////
+// Mutations //
+///////////////
+
+func (r *mutationResolver) WarehouseCreate(ctx context.Context, input graph.UpdateWarehouse) (*models.Warehouse, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.CreateWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.WarehouseRequest{}
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+	if input.AddressID != nil {
+		request.AddressID = *input.AddressID
+	}
+	if input.OrganizationID != nil {
+		request.OrganizationID = *input.OrganizationID
+	}
+
+	obj, err := r.services.WarehouseService.Create(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) WarehouseUpdate(ctx context.Context, id int64, input graph.UpdateWarehouse) (*models.Warehouse, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.WarehouseService.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.WarehouseRequest{
+		Name:      current.Name,
+		AddressID: current.AddressID,
+	}
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+	if input.AddressID != nil {
+		request.AddressID = *input.AddressID
+	}
+
+	obj, err := r.services.WarehouseService.Update(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) WarehouseArchive(ctx context.Context, id int64) (*models.Warehouse, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.WarehouseService.SetArchived(ctx, id, true, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) WarehouseUnarchive(ctx context.Context, id int64) (*models.Warehouse, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	obj, err := r.services.WarehouseService.SetArchived(ctx, id, false, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) LocationCreate(ctx context.Context, input graph.UpdateLocation) (*models.Location, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.LocationRequest{}
+	if input.Code != nil {
+		request.Code = input.Code.String
+	}
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+	if input.Kind != nil {
+		request.Kind = input.Kind.String
+	}
+	if input.WarehouseID != nil {
+		request.WarehouseID = input.WarehouseID.Int64
+	}
+	if input.ParentID != nil {
+		request.ParentID = *input.ParentID
+	}
+
+	obj, err := r.services.WarehouseService.CreateLocation(ctx, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) LocationUpdate(ctx context.Context, id int64, input graph.UpdateLocation) (*models.Location, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return nil, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.UpdateWarehouse, true, false); err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	current, err := r.services.WarehouseService.GetLocation(ctx, id, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	request := models.LocationRequest{
+		Code: current.Code,
+		Name: current.Name,
+	}
+	if input.Code != nil {
+		request.Code = input.Code.String
+	}
+	if input.Name != nil {
+		request.Name = input.Name.String
+	}
+
+	obj, err := r.services.WarehouseService.UpdateLocation(ctx, id, request, auther)
+	if err != nil {
+		return nil, fmt.Errorf(err.Message)
+	}
+
+	return obj, nil
+}
+
+func (r *mutationResolver) LocationDelete(ctx context.Context, id int64) (bool, error) {
+	auther, authErr := r.GetAuther(ctx)
+	if authErr != nil {
+		return false, authErr
+	}
+	if err := r.services.AuthService.GrantPermission(ctx, auther, models.DeleteWarehouse, true, false); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+
+	if err := r.services.WarehouseService.DeleteLocation(ctx, id, auther); err != nil {
+		return false, fmt.Errorf(err.Message)
+	}
+
+	return true, nil
+}
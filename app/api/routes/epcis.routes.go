@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"orijinplus/app/api/authentication"
+
+	"github.com/go-chi/chi"
+)
+
+// EPCIS Routes function
+func (rt *Routes) EPCIS(r chi.Router) {
+	h := rt.Handlers.EPCISHandler
+
+	r.Route("/epcis", func(r chi.Router) {
+		r.Use(authentication.Middleware())
+		r.Get("/events", h.Events)
+		r.Post("/capture", h.Capture)
+	})
+}
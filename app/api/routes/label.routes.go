@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"orijinplus/app/api/authentication"
+
+	"github.com/go-chi/chi"
+)
+
+// Labels Routes function
+func (rt *Routes) Labels(r chi.Router) {
+	h := rt.Handlers.LabelHandler
+
+	r.Route("/labels/{kind}", func(r chi.Router) {
+		r.Use(authentication.Middleware())
+		r.Get("/sheet.pdf", h.Sheet)
+		r.Get("/{uid}/{file}", h.Download)
+	})
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"net"
+	"orijinplus/utils/ratelimit"
+
+	"github.com/go-chi/chi"
+)
+
+// Public lookups allow a burst of publicBurst requests per client, refilled at publicRate per second
+const (
+	publicRate  = 0.5
+	publicBurst = 20
+)
+
+// TrustedProxies are the load balancers whose forwarding headers are honoured, set from the configuration on start
+var TrustedProxies []*net.IPNet
+
+// Public Routes function, these routes need no credentials so they are rate limited per client
+func (rt *Routes) Public(r chi.Router) {
+	h := rt.Handlers.ProvenanceHandler
+	limiter := ratelimit.New(publicRate, publicBurst)
+
+	r.Route("/public", func(r chi.Router) {
+		// Clients are behind the load balancer, their address is taken from the hops it appends
+		r.Use(ratelimit.Middleware(limiter, TrustedProxies))
+		r.Get("/provenance/{uid}", h.Lookup)
+		// GS1 Digital Link keys, /00/{sscc} and /01/{gtin}
+		r.Get("/provenance/{ai}/{key}", h.LookupGS1)
+	})
+}
@@ -0,0 +1,156 @@
+package master
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type AddressMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewAddressMaster(s *dbstore.DBStore) *AddressMaster {
+	return &AddressMaster{s}
+}
+
+// Create saves an address of a customer or an organization,
+// the first address of an owner becomes its default address
+func (m *AddressMaster) Create(ctx context.Context, tx pgx.Tx, a models.Address) (*models.Address, *faulterr.FaultErr) {
+	trimAddress(&a)
+	if err := m.validate(a); err != nil {
+		return nil, err
+	}
+
+	addresses, err := m.ownerAddresses(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		a.IsDefault = true
+	}
+	if a.IsDefault {
+		if err := m.clearDefault(ctx, tx, a); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.dbstore.AddressStore.Insert(ctx, tx, a)
+}
+
+func (m *AddressMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Address,
+	req models.Address,
+) (*models.Address, *faulterr.FaultErr) {
+	trimAddress(&req)
+
+	// Orders and shipments keep pointing at the address they were shipped to, so its location cannot change once used
+	if !sameLocation(*obj, req) {
+		inUse, err := m.dbstore.AddressStore.IsInUse(ctx, obj.ID)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			return nil, faulterr.NewBadRequestError("address is used by orders or shipments and cannot be changed, add a new address instead")
+		}
+	}
+
+	// Update fields
+	obj.Tag = req.Tag
+	obj.Line1 = req.Line1
+	obj.Line2 = req.Line2
+	obj.Line3 = req.Line3
+	obj.City = req.City
+	obj.State = req.State
+	obj.Country = req.Country
+	obj.Pincode = req.Pincode
+
+	if err := m.validate(*obj); err != nil {
+		return nil, err
+	}
+
+	// The default address can only be replaced by making another address the default
+	if req.IsDefault && !obj.IsDefault {
+		if err := m.clearDefault(ctx, tx, *obj); err != nil {
+			return nil, err
+		}
+		obj.IsDefault = true
+	}
+
+	if err := m.dbstore.AddressStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// SetDefault makes the address the default address of its owner
+func (m *AddressMaster) SetDefault(ctx context.Context, tx pgx.Tx, obj *models.Address) (*models.Address, *faulterr.FaultErr) {
+	req := *obj
+	req.IsDefault = true
+	return m.Update(ctx, tx, obj, req)
+}
+
+// Delete removes an address which is not used by consumer orders, distributors or shipments
+func (m *AddressMaster) Delete(ctx context.Context, tx pgx.Tx, obj *models.Address) *faulterr.FaultErr {
+	inUse, err := m.dbstore.AddressStore.IsInUse(ctx, obj.ID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return faulterr.NewBadRequestError("address is used by orders or shipments and cannot be deleted")
+	}
+	return m.dbstore.AddressStore.Delete(ctx, tx, obj.ID)
+}
+
+// sameLocation tells whether two addresses lead to the same place, their tags may differ
+func sameLocation(a models.Address, b models.Address) bool {
+	return a.Line1 == b.Line1 &&
+		a.Line2 == b.Line2 &&
+		a.Line3 == b.Line3 &&
+		a.City == b.City &&
+		a.State == b.State &&
+		a.Country == b.Country &&
+		a.Pincode == b.Pincode
+}
+
+func (m *AddressMaster) validate(a models.Address) *faulterr.FaultErr {
+	if a.UserID.Valid == a.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Address must belong to either a customer or an organization")
+	}
+	return a.Validate()
+}
+
+func (m *AddressMaster) ownerAddresses(ctx context.Context, a models.Address) ([]models.Address, *faulterr.FaultErr) {
+	if a.UserID.Valid {
+		return m.dbstore.AddressStore.ListByUserID(ctx, a.UserID.Int64)
+	}
+	return m.dbstore.AddressStore.ListByOrgID(ctx, a.OrganizationID.Int64)
+}
+
+func (m *AddressMaster) clearDefault(ctx context.Context, tx pgx.Tx, a models.Address) *faulterr.FaultErr {
+	if a.UserID.Valid {
+		return m.dbstore.AddressStore.ClearDefaultByUserID(ctx, tx, a.UserID.Int64)
+	}
+	return m.dbstore.AddressStore.ClearDefaultByOrgID(ctx, tx, a.OrganizationID.Int64)
+}
+
+func trimAddress(a *models.Address) {
+	a.Tag = strings.TrimSpace(a.Tag)
+	a.Line1 = strings.TrimSpace(a.Line1)
+	a.Line2 = strings.TrimSpace(a.Line2)
+	if a.Line3.Valid {
+		line3 := strings.TrimSpace(a.Line3.String)
+		a.Line3 = null.NewString(line3, line3 != "")
+	}
+	a.City = strings.TrimSpace(a.City)
+	a.State = strings.TrimSpace(a.State)
+	a.Country = strings.TrimSpace(a.Country)
+	a.Pincode = strings.ToUpper(strings.TrimSpace(a.Pincode))
+}
@@ -3,11 +3,30 @@ package master
 import "orijinplus/app/store/dbstore"
 
 type Master struct {
-	OrganizationMaster *OrganizationMaster
-	RoleMaster         *RoleMaster
-	UserMaster         *UserMaster
-	ContainerMaster    *ContainerMaster
-	PalletMaster       *PalletMaster
+	OrganizationMaster   *OrganizationMaster
+	RoleMaster           *RoleMaster
+	UserMaster           *UserMaster
+	ContainerMaster      *ContainerMaster
+	PalletMaster         *PalletMaster
+	SkuMaster            *SkuMaster
+	OrderMaster          *OrderMaster
+	ContractMaster       *ContractMaster
+	DistributorMaster    *DistributorMaster
+	TaskMaster           *TaskMaster
+	PurchaseRecordMaster *PurchaseRecordMaster
+	ConsumerOrderMaster  *ConsumerOrderMaster
+	TrackActionMaster    *TrackActionMaster
+	ReferralMaster       *ReferralMaster
+	WalletMaster         *WalletMaster
+	AddressMaster        *AddressMaster
+	CodeMaster           *CodeMaster
+	ShipmentMaster       *ShipmentMaster
+	WarehouseMaster      *WarehouseMaster
+	LocationMaster       *LocationMaster
+	AttributeMaster      *AttributeMaster
+	FileMaster           *FileMaster
+	RecallMaster         *RecallMaster
+	LotMaster            *LotMaster
 }
 
 func NewMaster(dbStore *dbstore.DBStore) *Master {
@@ -17,5 +36,24 @@ func NewMaster(dbStore *dbstore.DBStore) *Master {
 		NewUserMaster(dbStore),
 		NewContainerMaster(dbStore),
 		NewPalletMaster(dbStore),
+		NewSkuMaster(dbStore),
+		NewOrderMaster(dbStore),
+		NewContractMaster(dbStore),
+		NewDistributorMaster(dbStore),
+		NewTaskMaster(dbStore),
+		NewPurchaseRecordMaster(dbStore),
+		NewConsumerOrderMaster(dbStore),
+		NewTrackActionMaster(dbStore),
+		NewReferralMaster(dbStore),
+		NewWalletMaster(dbStore),
+		NewAddressMaster(dbStore),
+		NewCodeMaster(dbStore),
+		NewShipmentMaster(dbStore),
+		NewWarehouseMaster(dbStore),
+		NewLocationMaster(dbStore),
+		NewAttributeMaster(dbStore),
+		NewFileMaster(dbStore),
+		NewRecallMaster(dbStore),
+		NewLotMaster(dbStore),
 	}
 }
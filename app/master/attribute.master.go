@@ -0,0 +1,75 @@
+package master
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type AttributeMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewAttributeMaster(s *dbstore.DBStore) *AttributeMaster {
+	return &AttributeMaster{s}
+}
+
+// SetDefinition saves a custom attribute an organization defines for its pallets or containers
+func (m *AttributeMaster) SetDefinition(ctx context.Context, tx pgx.Tx, r models.AttributeDefinition) (*models.AttributeDefinition, *faulterr.FaultErr) {
+	r.Label = strings.TrimSpace(r.Label)
+	if r.EnumValues == nil {
+		r.EnumValues = []string{}
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return m.dbstore.AttributeDefinitionStore.Upsert(ctx, tx, r)
+}
+
+// DeleteDefinition removes a custom attribute along with the values pallets or containers have for it,
+// values of an attribute which is not defined would fail their next update
+func (m *AttributeMaster) DeleteDefinition(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	def, err := m.dbstore.AttributeDefinitionStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := m.dbstore.AttributeDefinitionStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if def.Entity == models.CodePallet {
+		return m.dbstore.PalletStore.RemoveAttribute(ctx, tx, def.OrganizationID, def.Key)
+	}
+	return m.dbstore.ContainerStore.RemoveAttribute(ctx, tx, def.OrganizationID, def.Key)
+}
+
+// Validate checks the custom attributes of a pallet or container against the attributes its organization defined
+func (m *AttributeMaster) Validate(ctx context.Context, orgID null.Int64, entity string, attrs map[string]interface{}) (map[string]interface{}, *faulterr.FaultErr) {
+	defs, err := m.definitions(ctx, orgID, entity)
+	if err != nil {
+		return nil, err
+	}
+	return models.ValidateAttributes(defs, attrs)
+}
+
+// FilterValues gets the attribute values pallets or containers of an organization are filtered by
+func (m *AttributeMaster) FilterValues(ctx context.Context, orgID null.Int64, entity string, filters []models.AttributeFilter) (map[string]interface{}, *faulterr.FaultErr) {
+	defs, err := m.definitions(ctx, orgID, entity)
+	if err != nil {
+		return nil, err
+	}
+	return models.AttributeFilterValues(defs, filters)
+}
+
+// definitions gets the attributes an organization defined for an entity, none without an organization
+func (m *AttributeMaster) definitions(ctx context.Context, orgID null.Int64, entity string) ([]models.AttributeDefinition, *faulterr.FaultErr) {
+	if !orgID.Valid {
+		return []models.AttributeDefinition{}, nil
+	}
+	return m.dbstore.AttributeDefinitionStore.ListByEntity(ctx, orgID.Int64, entity)
+}
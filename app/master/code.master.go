@@ -0,0 +1,150 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// CodeMaster hands out entity codes from counters locked by the creating transaction, so concurrent
+// creates never get the same code. Organizations with a code format for the entity get codes of their own
+type CodeMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewCodeMaster(s *dbstore.DBStore) *CodeMaster {
+	return &CodeMaster{s}
+}
+
+// Next gets the next code of an entity owned by the organization
+func (m *CodeMaster) Next(ctx context.Context, tx pgx.Tx, entity string, orgID null.Int64) (string, *faulterr.FaultErr) {
+	codes, err := m.NextN(ctx, tx, entity, orgID, 1)
+	if err != nil {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+// NextN gets the next n sequential codes of an entity owned by the organization
+func (m *CodeMaster) NextN(ctx context.Context, tx pgx.Tx, entity string, orgID null.Int64, n int) ([]string, *faulterr.FaultErr) {
+	format, err := m.format(ctx, entity, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := models.DefaultCodePrefixes[entity]
+	period := 0
+	if format != nil {
+		prefix = format.Prefix
+		period = format.Period(time.Now().UTC())
+	}
+
+	last, err := m.dbstore.CodeCounterStore.Increment(ctx, tx, entity, prefix, period, int64(n))
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, n)
+	for i := range codes {
+		number := last - int64(n) + int64(i) + 1
+		if format != nil {
+			codes[i] = format.Format(number, period)
+		} else {
+			codes[i] = models.DefaultCode(entity, number)
+		}
+	}
+	return codes, nil
+}
+
+// NextSSCCs gets the next n SSCCs of the organization, shared by its pallets and containers.
+// They are left unset when the organization has no GS1 company prefix
+func (m *CodeMaster) NextSSCCs(ctx context.Context, tx pgx.Tx, orgID null.Int64, n int) ([]null.String, *faulterr.FaultErr) {
+	ssccs := make([]null.String, n)
+	if !orgID.Valid {
+		return ssccs, nil
+	}
+	settings, err := m.dbstore.GS1SettingsStore.GetByOrgID(ctx, orgID.Int64)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return ssccs, nil
+		}
+		return nil, err
+	}
+
+	// Serials are counted per prefix, so changing the company prefix starts them over
+	last, err := m.dbstore.CodeCounterStore.Increment(ctx, tx, models.SSCCCounter, settings.SSCCPrefix(), 0, int64(n))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ssccs {
+		sscc, ok := settings.SSCC(last - int64(n) + int64(i) + 1)
+		if !ok {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("SSCCs of company prefix %s are used up", settings.CompanyPrefix))
+		}
+		ssccs[i] = null.StringFrom(sscc)
+	}
+	return ssccs, nil
+}
+
+// SetFormat saves the code format of an organization for an entity
+func (m *CodeMaster) SetFormat(ctx context.Context, tx pgx.Tx, r models.CodeFormat) (*models.CodeFormat, *faulterr.FaultErr) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Prefixes are unique per entity so the codes of organizations stay apart
+	current, err := m.dbstore.CodeFormatStore.GetByPrefix(ctx, r.Entity, r.Prefix)
+	if err != nil && err.Status != http.StatusNotFound {
+		return nil, err
+	}
+	if current != nil && current.OrganizationID != r.OrganizationID {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Prefix %s is already used by another organization", r.Prefix))
+	}
+
+	return m.dbstore.CodeFormatStore.Upsert(ctx, tx, r)
+}
+
+// DeleteFormat makes an organization use the default codes for an entity again
+func (m *CodeMaster) DeleteFormat(ctx context.Context, tx pgx.Tx, orgID int64, entity string) *faulterr.FaultErr {
+	return m.dbstore.CodeFormatStore.Delete(ctx, tx, orgID, entity)
+}
+
+// SetGS1Settings saves the GS1 company prefix of an organization, prefixes are licensed to a single company
+func (m *CodeMaster) SetGS1Settings(ctx context.Context, tx pgx.Tx, r models.GS1Settings) (*models.GS1Settings, *faulterr.FaultErr) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	current, err := m.dbstore.GS1SettingsStore.GetByPrefix(ctx, r.CompanyPrefix)
+	if err != nil && err.Status != http.StatusNotFound {
+		return nil, err
+	}
+	if current != nil && current.OrganizationID != r.OrganizationID {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Company prefix %s is already used by another organization", r.CompanyPrefix))
+	}
+
+	return m.dbstore.GS1SettingsStore.Upsert(ctx, tx, r)
+}
+
+// format gets the code format of the organization for the entity, nil when it uses the default codes
+func (m *CodeMaster) format(ctx context.Context, entity string, orgID null.Int64) (*models.CodeFormat, *faulterr.FaultErr) {
+	if !orgID.Valid {
+		return nil, nil
+	}
+	format, err := m.dbstore.CodeFormatStore.GetByOrgID(ctx, orgID.Int64, entity)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return format, nil
+}
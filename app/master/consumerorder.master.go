@@ -0,0 +1,167 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type ConsumerOrderMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+	wallet  *WalletMaster
+}
+
+func NewConsumerOrderMaster(s *dbstore.DBStore) *ConsumerOrderMaster {
+	return &ConsumerOrderMaster{s, NewCodeMaster(s), NewWalletMaster(s)}
+}
+
+// Create places a consumer order for the customer and pays part of the total with wallet points
+func (m *ConsumerOrderMaster) Create(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.ConsumerOrderRequest,
+	customerID int64,
+) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+
+	// Verify shipping address
+	address, err := m.dbstore.AddressStore.GetByID(ctx, r.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	if !address.UserID.Valid || address.UserID.Int64 != customerID {
+		return nil, faulterr.NewNotFoundError("no address found with given address id")
+	}
+
+	// Verify skus and price the line items
+	items := []models.ConsumerOrderItem{}
+	quantities := map[int64]int64{}
+	var organizationID int64
+	var total int64
+	for _, item := range r.Items {
+		if _, ok := quantities[item.SkuID]; !ok {
+			items = append(items, models.ConsumerOrderItem{SkuID: item.SkuID})
+		}
+		quantities[item.SkuID] += item.Quantity
+	}
+	for i := range items {
+		sku, err := m.dbstore.SkuStore.GetByID(ctx, items[i].SkuID)
+		if err != nil {
+			return nil, err
+		}
+		if sku.IsArchived {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("sku %s is archived", sku.Code))
+		}
+		if sku.Price <= 0 {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("sku %s is not for sale", sku.Code))
+		}
+		if organizationID == 0 {
+			organizationID = sku.OrganizationID
+		}
+		if sku.OrganizationID != organizationID {
+			return nil, faulterr.NewBadRequestError("all items must be sold by the same organization")
+		}
+
+		items[i].Quantity = quantities[sku.ID]
+		items[i].UnitPrice = sku.Price
+		total += items[i].Quantity * items[i].UnitPrice
+	}
+	if r.WalletPoints > total {
+		return nil, faulterr.NewBadRequestError("wallet points cannot exceed the order total")
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeConsumerOrder, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.ConsumerOrder{
+		UID:            uid,
+		Code:           code,
+		Status:         models.ConsumerOrderPending,
+		CustomerID:     customerID,
+		AddressID:      address.ID,
+		Total:          total,
+		WalletPoints:   r.WalletPoints,
+		OrganizationID: organizationID,
+	}
+
+	order, err := m.dbstore.ConsumerOrderStore.Insert(ctx, tx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		item.ConsumerOrderID = order.ID
+		if _, err := m.dbstore.ConsumerOrderItemStore.Insert(ctx, tx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	if order.WalletPoints > 0 {
+		ref := WalletReference{models.WalletRefConsumerOrder, order.ID}
+		if _, err := m.wallet.Redeem(ctx, tx, customerID, order.WalletPoints, "Consumer order "+order.Code, ref); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// UpdateStatus moves a consumer order through its lifecycle and refunds wallet points on cancellation
+func (m *ConsumerOrderMaster) UpdateStatus(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.ConsumerOrder,
+	status string,
+) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	if !canTransition(models.ConsumerOrderTransitions, obj.Status, status) {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("consumer order cannot move from %s to %s", obj.Status, status))
+	}
+
+	if status == models.ConsumerOrderCancelled && obj.WalletPoints > 0 {
+		ref := WalletReference{models.WalletRefConsumerOrder, obj.ID}
+		if _, err := m.wallet.Earn(ctx, tx, obj.CustomerID, obj.WalletPoints, "Consumer order "+obj.Code+" cancelled", ref); err != nil {
+			return nil, err
+		}
+	}
+
+	obj.Status = status
+	if err := m.dbstore.ConsumerOrderStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (m *ConsumerOrderMaster) validate(r models.ConsumerOrderRequest) *faulterr.FaultErr {
+	if r.AddressID <= 0 {
+		return faulterr.NewBadRequestError("Address ID is required")
+	}
+	if len(r.Items) == 0 {
+		return faulterr.NewBadRequestError("Order items are required")
+	}
+	for _, item := range r.Items {
+		if item.Quantity <= 0 {
+			return faulterr.NewBadRequestError("quantity must be greater than zero")
+		}
+	}
+	if r.WalletPoints < 0 {
+		return faulterr.NewBadRequestError("Wallet points cannot be negative")
+	}
+	return nil
+}
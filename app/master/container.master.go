@@ -9,23 +9,31 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
 )
 
 type ContainerMaster struct {
-	dbstore *dbstore.DBStore
+	dbstore    *dbstore.DBStore
+	codes      *CodeMaster
+	locations  *LocationMaster
+	attributes *AttributeMaster
 }
 
 func NewContainerMaster(s *dbstore.DBStore) *ContainerMaster {
-	return &ContainerMaster{s}
+	return &ContainerMaster{s, NewCodeMaster(s), NewLocationMaster(s), NewAttributeMaster(s)}
 }
 
 func (m *ContainerMaster) Create(ctx context.Context, tx pgx.Tx, r models.ContainerRequest, createdByID int64) (*models.Container, *faulterr.FaultErr) {
-	if err := m.validate(r); err != nil {
+	if err := m.validate(ctx, &r); err != nil {
 		return nil, err
 	}
 
-	// Get last inserted row ID
-	lastRowID, err := m.dbstore.ContainerStore.GetLastInsertedRow(ctx)
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeContainer, r.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	ssccs, err := m.codes.NextSSCCs(ctx, tx, r.OrganizationID, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -35,11 +43,12 @@ func (m *ContainerMaster) Create(ctx context.Context, tx pgx.Tx, r models.Contai
 		return nil, faulterr.NewInternalServerError(uidErr.Error())
 	}
 
-	count := lastRowID + 1
 	obj := models.Container{
 		UID:            uid,
-		Code:           fmt.Sprintf("CNT%05d", count),
+		Code:           code,
+		SSCC:           ssccs[0],
 		Description:    r.Description,
+		Attributes:     r.Attributes,
 		IsArchived:     false,
 		OrganizationID: r.OrganizationID,
 		CreatedByID:    createdByID,
@@ -48,6 +57,52 @@ func (m *ContainerMaster) Create(ctx context.Context, tx pgx.Tx, r models.Contai
 	return m.dbstore.ContainerStore.Insert(ctx, tx, obj)
 }
 
+// CreateBulk creates a number of identical containers with sequential codes
+func (m *ContainerMaster) CreateBulk(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.ContainerRequest,
+	count int,
+	createdByID int64,
+) ([]models.Container, *faulterr.FaultErr) {
+	if err := validateBulkCount(count); err != nil {
+		return nil, err
+	}
+	if err := m.validate(ctx, &r); err != nil {
+		return nil, err
+	}
+
+	// Get the next codes
+	codes, err := m.codes.NextN(ctx, tx, models.CodeContainer, r.OrganizationID, count)
+	if err != nil {
+		return nil, err
+	}
+	ssccs, err := m.codes.NextSSCCs(ctx, tx, r.OrganizationID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]models.Container, count)
+	for i := range objs {
+		uid, uidErr := uuid.NewV4()
+		if uidErr != nil {
+			return nil, faulterr.NewInternalServerError(uidErr.Error())
+		}
+		objs[i] = models.Container{
+			UID:            uid,
+			Code:           codes[i],
+			SSCC:           ssccs[i],
+			Description:    r.Description,
+			Attributes:     r.Attributes,
+			IsArchived:     false,
+			OrganizationID: r.OrganizationID,
+			CreatedByID:    createdByID,
+		}
+	}
+
+	return m.dbstore.ContainerStore.InsertMany(ctx, tx, objs)
+}
+
 func (m *ContainerMaster) Update(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -55,12 +110,13 @@ func (m *ContainerMaster) Update(
 	req models.ContainerRequest,
 ) (*models.Container, *faulterr.FaultErr) {
 	// Validate request
-	if err := m.validate(req); err != nil {
+	if err := m.validate(ctx, &req); err != nil {
 		return nil, err
 	}
 
 	// Update fields
 	obj.Description = req.Description
+	obj.Attributes = req.Attributes
 
 	if err := m.dbstore.ContainerStore.Update(ctx, tx, *obj); err != nil {
 		return nil, err
@@ -68,9 +124,98 @@ func (m *ContainerMaster) Update(
 	return obj, nil
 }
 
-func (m *ContainerMaster) validate(r models.ContainerRequest) *faulterr.FaultErr {
-	// if !r.Name.Valid {
-	// 	return faulterr.NewBadRequestError("contract name is required")
-	// }
+// AssignSSCC gives a container created before its organization set a GS1 company prefix its SSCC
+func (m *ContainerMaster) AssignSSCC(ctx context.Context, tx pgx.Tx, obj *models.Container) (*models.Container, *faulterr.FaultErr) {
+	if obj.SSCC.Valid {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Container %s already has SSCC %s", obj.Code, obj.SSCC.String))
+	}
+	ssccs, err := m.codes.NextSSCCs(ctx, tx, obj.OrganizationID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !ssccs[0].Valid {
+		return nil, faulterr.NewBadRequestError("Organization has no GS1 company prefix")
+	}
+
+	if err := m.dbstore.ContainerStore.SetSSCC(ctx, tx, obj.ID, ssccs[0].String); err != nil {
+		return nil, err
+	}
+	obj.SSCC = ssccs[0]
+	return obj, nil
+}
+
+// Transition moves a container through its lifecycle and records who did it
+func (m *ContainerMaster) Transition(ctx context.Context, tx pgx.Tx, id int64, status string, actorID int64) (*models.Container, *faulterr.FaultErr) {
+	container, err := m.dbstore.ContainerStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if container.IsArchived {
+		return nil, faulterr.NewBadRequestError("Archived containers cannot change status")
+	}
+	if !canTransition(models.ContainerTransitions, container.Status, status) {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("container cannot move from %s to %s", container.Status, status))
+	}
+
+	switch status {
+	case models.ContainerSealed:
+		pallets, err := m.dbstore.PalletStore.ListByContainerID(ctx, container.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(pallets) == 0 {
+			return nil, faulterr.NewBadRequestError("container has no pallets")
+		}
+	case models.ContainerUnpacked:
+		pallets, err := m.dbstore.PalletStore.ListByContainerID(ctx, container.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(pallets) > 0 {
+			return nil, faulterr.NewBadRequestError("container still has pallets")
+		}
+	case models.ContainerInTransit:
+		// A container in transit leaves the warehouse it was stored in
+		if container.LocationID.Valid {
+			if err := m.locations.moveContainer(ctx, tx, container, null.Int64{}, actorID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	transition := models.ContainerTransition{
+		ContainerID: container.ID,
+		FromStatus:  container.Status,
+		ToStatus:    status,
+		ActorID:     actorID,
+	}
+	if _, err := m.dbstore.ContainerTransitionStore.Insert(ctx, tx, transition); err != nil {
+		return nil, err
+	}
+
+	container.Status = status
+	if err := m.dbstore.ContainerStore.Update(ctx, tx, *container); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// validate checks the request and converts its custom attributes to the types its organization defined
+func (m *ContainerMaster) validate(ctx context.Context, r *models.ContainerRequest) *faulterr.FaultErr {
+	attrs, err := m.attributes.Validate(ctx, r.OrganizationID, models.CodeContainer, r.Attributes)
+	if err != nil {
+		return err
+	}
+	r.Attributes = attrs
+	return nil
+}
+
+func validateBulkCount(count int) *faulterr.FaultErr {
+	if count <= 0 {
+		return faulterr.NewBadRequestError("Count must be greater than zero")
+	}
+	if count > models.BulkCreateLimit {
+		return faulterr.NewBadRequestError(fmt.Sprintf("Count cannot be more than %d", models.BulkCreateLimit))
+	}
 	return nil
 }
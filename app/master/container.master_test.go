@@ -0,0 +1,27 @@
+package master
+
+import (
+	"orijinplus/app/models"
+	"testing"
+)
+
+var containerTransitionResults = []transitionResult{
+	{models.ContainerOpen, models.ContainerPacking, true},
+	{models.ContainerOpen, models.ContainerSealed, false},
+	{models.ContainerPacking, models.ContainerSealed, true},
+	{models.ContainerSealed, models.ContainerPacking, true},
+	{models.ContainerSealed, models.ContainerArrived, false},
+	{models.ContainerInTransit, models.ContainerArrived, true},
+	{models.ContainerInTransit, models.ContainerPacking, false},
+	{models.ContainerArrived, models.ContainerUnpacked, true},
+	{models.ContainerUnpacked, models.ContainerOpen, true},
+}
+
+func TestContainerTransitions(t *testing.T) {
+	for _, test := range containerTransitionResults {
+		result := canTransition(models.ContainerTransitions, test.from, test.to)
+		if result != test.expected {
+			t.Fatalf("canTransition: %s -> %s is not expected result", test.from, test.to)
+		}
+	}
+}
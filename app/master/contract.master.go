@@ -0,0 +1,209 @@
+package master
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type ContractMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewContractMaster(s *dbstore.DBStore) *ContractMaster {
+	return &ContractMaster{s, NewCodeMaster(s)}
+}
+
+func (m *ContractMaster) Create(ctx context.Context, tx pgx.Tx, r models.ContractRequest, createdByID int64) (*models.Contract, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+
+	// Verify buyer organization
+	if _, err := m.dbstore.OrganizationStore.GetByID(ctx, r.BuyerOrganizationID); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeContract, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Contract{
+		UID:                 uid,
+		Code:                code,
+		Title:               r.Title,
+		Description:         r.Description,
+		BuyerOrganizationID: r.BuyerOrganizationID,
+		StartDate:           r.StartDate,
+		EndDate:             r.EndDate,
+		PricingTerms:        r.PricingTerms,
+		VolumeTerms:         r.VolumeTerms,
+		IsArchived:          false,
+		OrganizationID:      r.OrganizationID.Int64,
+		CreatedByID:         createdByID,
+	}
+
+	return m.dbstore.ContractStore.Insert(ctx, tx, obj)
+}
+
+// Update changes the terms of a contract. Both parties have to approve the new terms again.
+func (m *ContractMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Contract,
+	req models.ContractRequest,
+) (*models.Contract, *faulterr.FaultErr) {
+	// Validate request
+	if err := m.validate(req); err != nil {
+		return nil, err
+	}
+	if req.BuyerOrganizationID != obj.BuyerOrganizationID {
+		if _, err := m.dbstore.OrganizationStore.GetByID(ctx, req.BuyerOrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update fields
+	obj.Title = req.Title
+	obj.Description = req.Description
+	obj.BuyerOrganizationID = req.BuyerOrganizationID
+	obj.StartDate = req.StartDate
+	obj.EndDate = req.EndDate
+	obj.PricingTerms = req.PricingTerms
+	obj.VolumeTerms = req.VolumeTerms
+
+	if err := m.resetApprovals(ctx, tx, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Approve records the approval of the supplier or the buyer, depending on the organization of the approver
+func (m *ContractMaster) Approve(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Contract,
+	orgID int64,
+	approvedByID int64,
+) (*models.Contract, *faulterr.FaultErr) {
+	if obj.IsArchived {
+		return nil, faulterr.NewBadRequestError("contract is archived")
+	}
+
+	now := time.Now().UTC()
+	switch orgID {
+	case obj.OrganizationID:
+		if obj.SupplierApprovedAt.Valid {
+			return nil, faulterr.NewBadRequestError("contract is already approved by the supplier")
+		}
+		obj.SupplierApprovedByID = null.Int64From(approvedByID)
+		obj.SupplierApprovedAt = null.TimeFrom(now)
+	case obj.BuyerOrganizationID:
+		if obj.BuyerApprovedAt.Valid {
+			return nil, faulterr.NewBadRequestError("contract is already approved by the buyer")
+		}
+		obj.BuyerApprovedByID = null.Int64From(approvedByID)
+		obj.BuyerApprovedAt = null.TimeFrom(now)
+	default:
+		return nil, faulterr.NewUnauthorizedError("only the parties of a contract can approve it")
+	}
+
+	if err := m.dbstore.ContractStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// AddDocument attaches a file uploaded to the filestore to a contract
+func (m *ContractMaster) AddDocument(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Contract,
+	file models.File,
+	uploadedByID int64,
+) (*models.ContractDocument, *faulterr.FaultErr) {
+	if file.Name == "" || file.URL == "" {
+		return nil, faulterr.NewBadRequestError("file name and url are required")
+	}
+
+	document := models.ContractDocument{
+		ContractID:   obj.ID,
+		Name:         file.Name,
+		URL:          file.URL,
+		UploadedByID: uploadedByID,
+	}
+
+	// Documents are part of the terms, both sides approve the contract again
+	if err := m.resetApprovals(ctx, tx, obj); err != nil {
+		return nil, err
+	}
+	return m.dbstore.ContractDocumentStore.Insert(ctx, tx, document)
+}
+
+// RemoveDocument removes a document of a contract, both sides approve the contract again
+func (m *ContractMaster) RemoveDocument(ctx context.Context, tx pgx.Tx, obj *models.Contract, documentID int64) *faulterr.FaultErr {
+	if err := m.resetApprovals(ctx, tx, obj); err != nil {
+		return err
+	}
+	return m.dbstore.ContractDocumentStore.Delete(ctx, tx, documentID)
+}
+
+// resetApprovals withdraws the approvals of both sides after the terms of a contract changed
+func (m *ContractMaster) resetApprovals(ctx context.Context, tx pgx.Tx, obj *models.Contract) *faulterr.FaultErr {
+	obj.SupplierApprovedByID = null.Int64{}
+	obj.SupplierApprovedAt = null.Time{}
+	obj.BuyerApprovedByID = null.Int64{}
+	obj.BuyerApprovedAt = null.Time{}
+
+	return m.dbstore.ContractStore.Update(ctx, tx, *obj)
+}
+
+func (m *ContractMaster) validate(r models.ContractRequest) *faulterr.FaultErr {
+	if r.Title == "" {
+		return faulterr.NewBadRequestError("Contract Title is required")
+	}
+	if r.BuyerOrganizationID <= 0 {
+		return faulterr.NewBadRequestError("Buyer Organization ID is required")
+	}
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	if r.BuyerOrganizationID == r.OrganizationID.Int64 {
+		return faulterr.NewBadRequestError("Buyer cannot be the supplying organization")
+	}
+	if r.StartDate.IsZero() || r.EndDate.IsZero() {
+		return faulterr.NewBadRequestError("Start and End Date are required")
+	}
+	if !r.StartDate.Before(r.EndDate) {
+		return faulterr.NewBadRequestError("Start Date must be before End Date")
+	}
+	return nil
+}
+
+// verifyActiveContract checks that orders between the supplier and the buyer can be placed against a contract
+func verifyActiveContract(obj *models.Contract, supplierID int64, buyerID int64) *faulterr.FaultErr {
+	if obj.OrganizationID != supplierID || obj.BuyerOrganizationID != buyerID {
+		return faulterr.NewBadRequestError("contract is not between the selling and the buying organization")
+	}
+	if obj.IsArchived {
+		return faulterr.NewBadRequestError("contract is archived")
+	}
+	if obj.StatusAt(time.Now()) != models.ContractActive {
+		return faulterr.NewBadRequestError("contract is not active")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package master
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type DistributorMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewDistributorMaster(s *dbstore.DBStore) *DistributorMaster {
+	return &DistributorMaster{s, NewCodeMaster(s)}
+}
+
+func (m *DistributorMaster) Create(ctx context.Context, tx pgx.Tx, r models.DistributorRequest, createdByID int64) (*models.Distributor, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeDistributor, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	// Save contact address
+	addressID := null.Int64{}
+	if r.Address != nil {
+		address, err := m.dbstore.AddressStore.Insert(ctx, tx, *r.Address)
+		if err != nil {
+			return nil, err
+		}
+		addressID = null.Int64From(address.ID)
+	}
+
+	obj := models.Distributor{
+		UID:            uid,
+		Code:           code,
+		Name:           r.Name,
+		ContactName:    r.ContactName,
+		Email:          r.Email,
+		Phone:          r.Phone,
+		Regions:        r.Regions,
+		AddressID:      addressID,
+		IsArchived:     false,
+		OrganizationID: r.OrganizationID.Int64,
+		CreatedByID:    createdByID,
+	}
+
+	return m.dbstore.DistributorStore.Insert(ctx, tx, obj)
+}
+
+func (m *DistributorMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Distributor,
+	req models.DistributorRequest,
+) (*models.Distributor, *faulterr.FaultErr) {
+	// Validate request
+	if err := m.validate(req); err != nil {
+		return nil, err
+	}
+
+	// Save contact address
+	if req.Address != nil {
+		if obj.AddressID.Valid {
+			req.Address.ID = obj.AddressID.Int64
+			if err := m.dbstore.AddressStore.Update(ctx, tx, *req.Address); err != nil {
+				return nil, err
+			}
+		} else {
+			address, err := m.dbstore.AddressStore.Insert(ctx, tx, *req.Address)
+			if err != nil {
+				return nil, err
+			}
+			obj.AddressID = null.Int64From(address.ID)
+		}
+	}
+
+	// Update fields
+	obj.Name = req.Name
+	obj.ContactName = req.ContactName
+	obj.Email = req.Email
+	obj.Phone = req.Phone
+	obj.Regions = req.Regions
+
+	if err := m.dbstore.DistributorStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (m *DistributorMaster) validate(r models.DistributorRequest) *faulterr.FaultErr {
+	if r.Name == "" {
+		return faulterr.NewBadRequestError("Distributor Name is required")
+	}
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	if r.Address != nil {
+		if err := r.Address.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
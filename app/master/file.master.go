@@ -0,0 +1,95 @@
+package master
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type FileMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewFileMaster(s *dbstore.DBStore) *FileMaster {
+	return &FileMaster{s}
+}
+
+// Record keeps track of a file uploaded to the filestore
+func (m *FileMaster) Record(ctx context.Context, tx pgx.Tx, obj models.StoredFile) (*models.StoredFile, *faulterr.FaultErr) {
+	if obj.Name == "" || obj.URL == "" {
+		return nil, faulterr.NewBadRequestError("file name and url are required")
+	}
+	return m.dbstore.StoredFileStore.Insert(ctx, tx, obj)
+}
+
+// Attach attaches a file to an organization, container or pallet of the organization of the file,
+// files uploaded without an organization take the organization of their owner
+func (m *FileMaster) Attach(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.StoredFile,
+	ownerType string,
+	ownerID int64,
+) (*models.StoredFile, *faulterr.FaultErr) {
+	orgID, err := m.ownerOrganization(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if obj.OrganizationID.Valid && obj.OrganizationID != orgID {
+		return nil, faulterr.NewNotFoundError("no " + ownerType + " found with given id")
+	}
+
+	obj.OwnerType = null.StringFrom(ownerType)
+	obj.OwnerID = null.Int64From(ownerID)
+	obj.OrganizationID = orgID
+	obj.AttachedAt = null.TimeFrom(time.Now())
+	if err := m.dbstore.StoredFileStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Detach takes a file off its owner, the file is kept by its organization
+func (m *FileMaster) Detach(ctx context.Context, tx pgx.Tx, obj *models.StoredFile) (*models.StoredFile, *faulterr.FaultErr) {
+	if !obj.OwnerType.Valid {
+		return nil, faulterr.NewBadRequestError("File is not attached")
+	}
+
+	obj.OwnerType = null.String{}
+	obj.OwnerID = null.Int64{}
+	obj.AttachedAt = null.Time{}
+	if err := m.dbstore.StoredFileStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// ownerOrganization gets the organization of the owner of a file
+func (m *FileMaster) ownerOrganization(ctx context.Context, ownerType string, ownerID int64) (null.Int64, *faulterr.FaultErr) {
+	switch ownerType {
+	case models.CodeOrganization:
+		org, err := m.dbstore.OrganizationStore.GetByID(ctx, ownerID)
+		if err != nil {
+			return null.Int64{}, err
+		}
+		return null.Int64From(org.ID), nil
+	case models.CodeContainer:
+		container, err := m.dbstore.ContainerStore.GetByID(ctx, ownerID)
+		if err != nil {
+			return null.Int64{}, err
+		}
+		return container.OrganizationID, nil
+	case models.CodePallet:
+		pallet, err := m.dbstore.PalletStore.GetByID(ctx, ownerID)
+		if err != nil {
+			return null.Int64{}, err
+		}
+		return pallet.OrganizationID, nil
+	}
+	return null.Int64{}, faulterr.NewBadRequestError("Files can only be attached to organizations, containers and pallets")
+}
@@ -0,0 +1,130 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// LocationMaster puts pallets and containers at warehouse locations and records every move
+type LocationMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewLocationMaster(s *dbstore.DBStore) *LocationMaster {
+	return &LocationMaster{s}
+}
+
+// PlacePallet puts a loose pallet at a location of its organization, a null location takes it off its location.
+// Pallets inside a container are where their container is
+func (m *LocationMaster) PlacePallet(ctx context.Context, tx pgx.Tx, palletID int64, locationID null.Int64, actorID int64) (*models.Pallet, *faulterr.FaultErr) {
+	pallet, err := m.dbstore.PalletStore.LockByID(ctx, tx, palletID)
+	if err != nil {
+		return nil, err
+	}
+	if pallet.IsArchived {
+		return nil, faulterr.NewBadRequestError("Archived pallets cannot be moved")
+	}
+	if pallet.ContainerID.Valid {
+		return nil, faulterr.NewBadRequestError("Pallet is in a container, move the container instead")
+	}
+	if pallet.LocationID == locationID {
+		return nil, faulterr.NewBadRequestError("Pallet is already at the location")
+	}
+	if err := m.verifyLocation(ctx, locationID, pallet.OrganizationID.Int64); err != nil {
+		return nil, err
+	}
+
+	if err := m.movePallet(ctx, tx, pallet, locationID, actorID); err != nil {
+		return nil, err
+	}
+	return pallet, nil
+}
+
+// PlaceContainer puts a container at a location of its organization, a null location takes it off its location
+func (m *LocationMaster) PlaceContainer(ctx context.Context, tx pgx.Tx, containerID int64, locationID null.Int64, actorID int64) (*models.Container, *faulterr.FaultErr) {
+	container, err := m.dbstore.ContainerStore.LockByID(ctx, tx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if container.IsArchived {
+		return nil, faulterr.NewBadRequestError("Archived containers cannot be moved")
+	}
+	if locationID.Valid && container.Status == models.ContainerInTransit {
+		return nil, faulterr.NewBadRequestError("Containers in transit cannot be put at a location")
+	}
+	if container.LocationID == locationID {
+		return nil, faulterr.NewBadRequestError("Container is already at the location")
+	}
+	if err := m.verifyLocation(ctx, locationID, container.OrganizationID.Int64); err != nil {
+		return nil, err
+	}
+
+	if err := m.moveContainer(ctx, tx, container, locationID, actorID); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// movePallet records a pallet leaving its location for another one
+func (m *LocationMaster) movePallet(ctx context.Context, tx pgx.Tx, pallet *models.Pallet, to null.Int64, actorID int64) *faulterr.FaultErr {
+	move := models.LocationMove{
+		PalletID:       null.Int64From(pallet.ID),
+		FromLocationID: pallet.LocationID,
+		ToLocationID:   to,
+		ActorID:        actorID,
+	}
+	if _, err := m.dbstore.LocationMoveStore.Insert(ctx, tx, move); err != nil {
+		return err
+	}
+	if err := m.dbstore.PalletStore.SetLocation(ctx, tx, pallet.ID, to); err != nil {
+		return err
+	}
+	pallet.LocationID = to
+	return nil
+}
+
+// moveContainer records a container leaving its location for another one
+func (m *LocationMaster) moveContainer(ctx context.Context, tx pgx.Tx, container *models.Container, to null.Int64, actorID int64) *faulterr.FaultErr {
+	move := models.LocationMove{
+		ContainerID:    null.Int64From(container.ID),
+		FromLocationID: container.LocationID,
+		ToLocationID:   to,
+		ActorID:        actorID,
+	}
+	if _, err := m.dbstore.LocationMoveStore.Insert(ctx, tx, move); err != nil {
+		return err
+	}
+	if err := m.dbstore.ContainerStore.SetLocation(ctx, tx, container.ID, to); err != nil {
+		return err
+	}
+	container.LocationID = to
+	return nil
+}
+
+// verifyLocation checks a location is in an active warehouse of the organization
+func (m *LocationMaster) verifyLocation(ctx context.Context, locationID null.Int64, orgID int64) *faulterr.FaultErr {
+	if !locationID.Valid {
+		return nil
+	}
+	location, err := m.dbstore.LocationStore.GetByID(ctx, locationID.Int64)
+	if err != nil {
+		return err
+	}
+	warehouse, err := m.dbstore.WarehouseStore.GetByID(ctx, location.WarehouseID)
+	if err != nil {
+		return err
+	}
+	if warehouse.OrganizationID != orgID {
+		return faulterr.NewNotFoundError("no location found with given location id")
+	}
+	if warehouse.IsArchived {
+		return faulterr.NewBadRequestError(fmt.Sprintf("Warehouse %s is archived", warehouse.Code))
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// LotMaster keeps the production lots of SKUs, the pallets produced in them and flags the lots
+// which reached their expiry date
+type LotMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewLotMaster(s *dbstore.DBStore) *LotMaster {
+	return &LotMaster{s}
+}
+
+func (m *LotMaster) Create(ctx context.Context, tx pgx.Tx, r models.LotRequest, orgID int64, createdByID int64) (*models.Lot, *faulterr.FaultErr) {
+	r.Code = strings.TrimSpace(r.Code)
+	if err := m.validate(ctx, r, orgID); err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Lot{
+		UID:            uid,
+		Code:           r.Code,
+		SkuID:          r.SkuID,
+		ProductionDate: r.ProductionDate,
+		ExpiryDate:     r.ExpiryDate,
+		OrganizationID: orgID,
+		CreatedByID:    createdByID,
+	}
+
+	lot, err := m.dbstore.LotStore.Insert(ctx, tx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// A lot recorded after its expiry date is flagged right away
+	if lot.HasExpired(time.Now()) {
+		lot.IsExpired = true
+		lot.ExpiredAt = null.TimeFrom(time.Now())
+		if err := m.dbstore.LotStore.Update(ctx, tx, *lot); err != nil {
+			return nil, err
+		}
+	}
+	return lot, nil
+}
+
+// Update changes the code and dates of a lot, moving the expiry date of an expired lot
+// into the future clears its expired flag
+func (m *LotMaster) Update(ctx context.Context, tx pgx.Tx, id int64, r models.LotRequest) (*models.Lot, *faulterr.FaultErr) {
+	obj, err := m.dbstore.LotStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Code = strings.TrimSpace(r.Code)
+	r.SkuID = obj.SkuID
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.Code = r.Code
+	obj.ProductionDate = r.ProductionDate
+	obj.ExpiryDate = r.ExpiryDate
+
+	now := time.Now()
+	if obj.HasExpired(now) != obj.IsExpired {
+		obj.IsExpired = !obj.IsExpired
+		obj.ExpiredAt = null.Time{}
+		if obj.IsExpired {
+			obj.ExpiredAt = null.TimeFrom(now)
+		}
+	}
+
+	if err := m.dbstore.LotStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// AssignPallets puts pallets of the lot's organization in the lot, a pallet belongs to a single lot
+// so pallets in another lot are moved
+func (m *LotMaster) AssignPallets(ctx context.Context, tx pgx.Tx, id int64, palletIDs []int64) (*models.Lot, *faulterr.FaultErr) {
+	lot, err := m.dbstore.LotStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(palletIDs) > models.BulkCreateLimit {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("at most %d pallets can be assigned at once", models.BulkCreateLimit))
+	}
+
+	for _, palletID := range palletIDs {
+		pallet, err := m.dbstore.PalletStore.LockByID(ctx, tx, palletID)
+		if err != nil {
+			return nil, err
+		}
+		if pallet.OrganizationID.Int64 != lot.OrganizationID {
+			return nil, faulterr.NewNotFoundError(fmt.Sprintf("no pallet found with id %d", palletID))
+		}
+		if err := m.dbstore.PalletStore.SetLot(ctx, tx, palletID, null.Int64From(lot.ID)); err != nil {
+			return nil, err
+		}
+	}
+	return lot, nil
+}
+
+// UnassignPallet takes a pallet out of its lot
+func (m *LotMaster) UnassignPallet(ctx context.Context, tx pgx.Tx, id int64, palletID int64) *faulterr.FaultErr {
+	pallet, err := m.dbstore.PalletStore.LockByID(ctx, tx, palletID)
+	if err != nil {
+		return err
+	}
+	if !pallet.LotID.Valid || pallet.LotID.Int64 != id {
+		return faulterr.NewNotFoundError("pallet is not in the lot")
+	}
+	return m.dbstore.PalletStore.SetLot(ctx, tx, palletID, null.Int64{})
+}
+
+// FlagExpired flags the lots which reached their expiry date and notifies their organizations
+func (m *LotMaster) FlagExpired(ctx context.Context, tx pgx.Tx, now time.Time) ([]models.Lot, *faulterr.FaultErr) {
+	lots, err := m.dbstore.LotStore.FlagExpired(ctx, tx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lot := range lots {
+		notification := models.Notification{
+			OrganizationID: null.Int64From(lot.OrganizationID),
+			Kind:           models.NotificationLotExpired,
+			Subject:        fmt.Sprintf("Lot %s has expired", lot.Code),
+			Body:           fmt.Sprintf("Lot %s expired on %s, its pallets should not be shipped.", lot.Code, lot.ExpiryDate.Time.Format("2006-01-02")),
+			EntityType:     null.StringFrom(models.CodeLot),
+			EntityID:       null.Int64From(lot.ID),
+		}
+		if _, err := m.dbstore.NotificationStore.Insert(ctx, tx, notification); err != nil {
+			return nil, err
+		}
+	}
+	return lots, nil
+}
+
+func (m *LotMaster) validate(ctx context.Context, r models.LotRequest, orgID int64) *faulterr.FaultErr {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	sku, err := m.dbstore.SkuStore.GetByID(ctx, r.SkuID)
+	if err != nil {
+		return err
+	}
+	if sku.OrganizationID != orgID {
+		return faulterr.NewNotFoundError("no sku found with given sku id")
+	}
+	return nil
+}
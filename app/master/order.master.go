@@ -0,0 +1,337 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type OrderMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewOrderMaster(s *dbstore.DBStore) *OrderMaster {
+	return &OrderMaster{s, NewCodeMaster(s)}
+}
+
+func (m *OrderMaster) Create(ctx context.Context, tx pgx.Tx, r models.OrderRequest, createdByID int64) (*models.Order, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+
+	// Verify buyer organization
+	if _, err := m.dbstore.OrganizationStore.GetByID(ctx, r.BuyerOrganizationID); err != nil {
+		return nil, err
+	}
+
+	// Verify contract
+	if err := m.verifyContract(ctx, r); err != nil {
+		return nil, err
+	}
+
+	// Verify distributor
+	if err := m.verifyDistributor(ctx, r); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeOrder, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Order{
+		UID:                 uid,
+		Code:                code,
+		Status:              models.OrderDraft,
+		Notes:               r.Notes,
+		BuyerOrganizationID: r.BuyerOrganizationID,
+		IsArchived:          false,
+		OrganizationID:      r.OrganizationID.Int64,
+		CreatedByID:         createdByID,
+		ContractID:          null.Int64From(r.ContractID),
+		DistributorID:       r.DistributorID,
+	}
+
+	return m.dbstore.OrderStore.Insert(ctx, tx, obj)
+}
+
+func (m *OrderMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Order,
+	req models.OrderRequest,
+) (*models.Order, *faulterr.FaultErr) {
+	if obj.Status != models.OrderDraft {
+		return nil, faulterr.NewBadRequestError("only draft orders can be updated")
+	}
+	// Validate request
+	if err := m.validate(req); err != nil {
+		return nil, err
+	}
+	if req.BuyerOrganizationID != obj.BuyerOrganizationID {
+		if _, err := m.dbstore.OrganizationStore.GetByID(ctx, req.BuyerOrganizationID); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.verifyContract(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := m.verifyDistributor(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.Notes = req.Notes
+	obj.BuyerOrganizationID = req.BuyerOrganizationID
+	obj.ContractID = null.Int64From(req.ContractID)
+	obj.DistributorID = req.DistributorID
+
+	if err := m.dbstore.OrderStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// AddItem adds a sku line item to a draft order, merging quantities of the same sku
+func (m *OrderMaster) AddItem(
+	ctx context.Context,
+	tx pgx.Tx,
+	order *models.Order,
+	req models.OrderItemRequest,
+) (*models.OrderItem, *faulterr.FaultErr) {
+	if order.Status != models.OrderDraft {
+		return nil, faulterr.NewBadRequestError("items can only be changed on draft orders")
+	}
+	if req.Quantity <= 0 {
+		return nil, faulterr.NewBadRequestError("quantity must be greater than zero")
+	}
+
+	sku, err := m.dbstore.SkuStore.GetByID(ctx, req.SkuID)
+	if err != nil {
+		return nil, err
+	}
+	if sku.OrganizationID != order.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no sku found with given sku id")
+	}
+	if sku.IsArchived {
+		return nil, faulterr.NewBadRequestError("sku is archived")
+	}
+
+	items, err := m.dbstore.OrderItemStore.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.SkuID == sku.ID {
+			item.Quantity += req.Quantity
+			if err := m.dbstore.OrderItemStore.Update(ctx, tx, item); err != nil {
+				return nil, err
+			}
+			return &item, nil
+		}
+	}
+
+	obj := models.OrderItem{
+		OrderID:  order.ID,
+		SkuID:    sku.ID,
+		Quantity: req.Quantity,
+	}
+
+	return m.dbstore.OrderItemStore.Insert(ctx, tx, obj)
+}
+
+// UpdateItem changes the quantity of a line item on a draft order
+func (m *OrderMaster) UpdateItem(
+	ctx context.Context,
+	tx pgx.Tx,
+	order *models.Order,
+	item *models.OrderItem,
+	quantity int64,
+) (*models.OrderItem, *faulterr.FaultErr) {
+	if order.Status != models.OrderDraft {
+		return nil, faulterr.NewBadRequestError("items can only be changed on draft orders")
+	}
+	if quantity <= 0 {
+		return nil, faulterr.NewBadRequestError("quantity must be greater than zero")
+	}
+
+	item.Quantity = quantity
+	if err := m.dbstore.OrderItemStore.Update(ctx, tx, *item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// RemoveItem deletes a line item from a draft order
+func (m *OrderMaster) RemoveItem(ctx context.Context, tx pgx.Tx, order *models.Order, item *models.OrderItem) *faulterr.FaultErr {
+	if order.Status != models.OrderDraft {
+		return faulterr.NewBadRequestError("items can only be changed on draft orders")
+	}
+	return m.dbstore.OrderItemStore.Delete(ctx, tx, item.ID)
+}
+
+// Allocate reserves a pallet for an order before it is packed
+func (m *OrderMaster) Allocate(
+	ctx context.Context,
+	tx pgx.Tx,
+	order *models.Order,
+	pallet *models.Pallet,
+	allocatedByID int64,
+) (*models.OrderAllocation, *faulterr.FaultErr) {
+	if order.Status != models.OrderDraft && order.Status != models.OrderConfirmed {
+		return nil, faulterr.NewBadRequestError("pallets can only be allocated to draft or confirmed orders")
+	}
+	if pallet.OrganizationID.Int64 != order.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no pallet found with given pallet id")
+	}
+	if pallet.IsArchived {
+		return nil, faulterr.NewBadRequestError("pallet is archived")
+	}
+	_, err := m.dbstore.OrderAllocationStore.GetByPalletID(ctx, pallet.ID)
+	if err == nil {
+		return nil, faulterr.NewBadRequestError("pallet is already allocated to an order")
+	}
+	if err.Status != http.StatusNotFound {
+		return nil, err
+	}
+
+	obj := models.OrderAllocation{
+		OrderID:       order.ID,
+		PalletID:      pallet.ID,
+		AllocatedByID: allocatedByID,
+	}
+
+	return m.dbstore.OrderAllocationStore.Insert(ctx, tx, obj)
+}
+
+// Deallocate releases a pallet from an order that has not been packed yet
+func (m *OrderMaster) Deallocate(ctx context.Context, tx pgx.Tx, order *models.Order, palletID int64) *faulterr.FaultErr {
+	if order.Status != models.OrderDraft && order.Status != models.OrderConfirmed {
+		return faulterr.NewBadRequestError("pallets can only be released from draft or confirmed orders")
+	}
+
+	allocation, err := m.dbstore.OrderAllocationStore.GetByPalletID(ctx, palletID)
+	if err != nil {
+		return err
+	}
+	if allocation.OrderID != order.ID {
+		return faulterr.NewNotFoundError("pallet is not allocated to this order")
+	}
+
+	return m.dbstore.OrderAllocationStore.Delete(ctx, tx, allocation.ID)
+}
+
+// UpdateStatus moves an order through its lifecycle
+func (m *OrderMaster) UpdateStatus(ctx context.Context, tx pgx.Tx, obj *models.Order, status string) (*models.Order, *faulterr.FaultErr) {
+	if !canTransition(models.OrderTransitions, obj.Status, status) {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("order cannot move from %s to %s", obj.Status, status))
+	}
+
+	switch status {
+	case models.OrderConfirmed:
+		if !obj.ContractID.Valid {
+			return nil, faulterr.NewBadRequestError("order has no contract")
+		}
+		contract, err := m.dbstore.ContractStore.GetByID(ctx, obj.ContractID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyActiveContract(contract, obj.OrganizationID, obj.BuyerOrganizationID); err != nil {
+			return nil, err
+		}
+
+		items, err := m.dbstore.OrderItemStore.ListByOrderID(ctx, obj.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, faulterr.NewBadRequestError("order has no items")
+		}
+	case models.OrderPacked:
+		allocations, err := m.dbstore.OrderAllocationStore.ListByOrderID(ctx, obj.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(allocations) == 0 {
+			return nil, faulterr.NewBadRequestError("order has no allocated pallets")
+		}
+	case models.OrderCancelled:
+		// Pallets of a cancelled order can be allocated to other orders again
+		if err := m.dbstore.OrderAllocationStore.DeleteByOrderID(ctx, tx, obj.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	obj.Status = status
+	if err := m.dbstore.OrderStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (m *OrderMaster) validate(r models.OrderRequest) *faulterr.FaultErr {
+	if r.BuyerOrganizationID <= 0 {
+		return faulterr.NewBadRequestError("Buyer Organization ID is required")
+	}
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	if r.BuyerOrganizationID == r.OrganizationID.Int64 {
+		return faulterr.NewBadRequestError("Buyer cannot be the selling organization")
+	}
+	if r.ContractID <= 0 {
+		return faulterr.NewBadRequestError("Contract ID is required")
+	}
+	return nil
+}
+
+// verifyContract checks that the order is placed against an active contract between both organizations
+func (m *OrderMaster) verifyContract(ctx context.Context, r models.OrderRequest) *faulterr.FaultErr {
+	contract, err := m.dbstore.ContractStore.GetByID(ctx, r.ContractID)
+	if err != nil {
+		return err
+	}
+	return verifyActiveContract(contract, r.OrganizationID.Int64, r.BuyerOrganizationID)
+}
+
+// canTransition reports whether a status change is allowed by the transitions table
+func canTransition(transitions map[string][]string, from string, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyDistributor checks that the order is routed through a distributor of the selling organization
+func (m *OrderMaster) verifyDistributor(ctx context.Context, r models.OrderRequest) *faulterr.FaultErr {
+	if !r.DistributorID.Valid {
+		return nil
+	}
+	distributor, err := m.dbstore.DistributorStore.GetByID(ctx, r.DistributorID.Int64)
+	if err != nil {
+		return err
+	}
+	if distributor.OrganizationID != r.OrganizationID.Int64 {
+		return faulterr.NewNotFoundError("no distributor found with given distributor id")
+	}
+	if distributor.IsArchived {
+		return faulterr.NewBadRequestError("distributor is archived")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package master
+
+import (
+	"orijinplus/app/models"
+	"testing"
+)
+
+type transitionResult struct {
+	from     string
+	to       string
+	expected bool
+}
+
+var orderTransitionResults = []transitionResult{
+	{models.OrderDraft, models.OrderConfirmed, true},
+	{models.OrderDraft, models.OrderPacked, false},
+	{models.OrderConfirmed, models.OrderPacked, true},
+	{models.OrderPacked, models.OrderShipped, true},
+	{models.OrderShipped, models.OrderCancelled, false},
+	{models.OrderShipped, models.OrderDelivered, true},
+	{models.OrderDelivered, models.OrderDraft, false},
+	{models.OrderCancelled, models.OrderConfirmed, false},
+	{"unknown", models.OrderConfirmed, false},
+}
+
+func TestOrderTransitions(t *testing.T) {
+	for _, test := range orderTransitionResults {
+		result := canTransition(models.OrderTransitions, test.from, test.to)
+		if result != test.expected {
+			t.Fatalf("canTransition: %s -> %s is not expected result", test.from, test.to)
+		}
+	}
+}
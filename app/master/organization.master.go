@@ -2,7 +2,6 @@ package master
 
 import (
 	"context"
-	"fmt"
 	"orijinplus/app/models"
 	"orijinplus/app/store/dbstore"
 	"orijinplus/utils/faulterr"
@@ -13,10 +12,11 @@ import (
 
 type OrganizationMaster struct {
 	dbstore *dbstore.DBStore
+	codes   *CodeMaster
 }
 
 func NewOrganizationMaster(dbstore *dbstore.DBStore) *OrganizationMaster {
-	return &OrganizationMaster{dbstore}
+	return &OrganizationMaster{dbstore, NewCodeMaster(dbstore)}
 }
 
 func (m *OrganizationMaster) Create(ctx context.Context, tx pgx.Tx, r models.OrganizationRequest) (*models.Organization, *faulterr.FaultErr) {
@@ -24,15 +24,14 @@ func (m *OrganizationMaster) Create(ctx context.Context, tx pgx.Tx, r models.Org
 		return nil, err
 	}
 
-	// Get last inserted row ID
-	lastRowID, err := m.dbstore.OrganizationStore.GetLastInsertedRow(ctx)
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeOrganization, null.Int64{})
 	if err != nil {
 		return nil, err
 	}
 
-	count := lastRowID + 1
 	o := models.Organization{
-		Code:       fmt.Sprintf("ORG%05d", count),
+		Code:       code,
 		Name:       r.OrgName,
 		Website:    null.StringFrom(r.Website),
 		IsArchived: false,
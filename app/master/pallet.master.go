@@ -6,26 +6,39 @@ import (
 	"orijinplus/app/models"
 	"orijinplus/app/store/dbstore"
 	"orijinplus/utils/faulterr"
+	"sort"
+	"strings"
 
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
 )
 
 type PalletMaster struct {
-	dbstore *dbstore.DBStore
+	dbstore    *dbstore.DBStore
+	codes      *CodeMaster
+	locations  *LocationMaster
+	attributes *AttributeMaster
 }
 
 func NewPalletMaster(s *dbstore.DBStore) *PalletMaster {
-	return &PalletMaster{s}
+	return &PalletMaster{s, NewCodeMaster(s), NewLocationMaster(s), NewAttributeMaster(s)}
 }
 
 func (m *PalletMaster) Create(ctx context.Context, tx pgx.Tx, r models.PalletRequest, createdByID int64) (*models.Pallet, *faulterr.FaultErr) {
-	if err := m.validate(r); err != nil {
+	if err := m.validate(ctx, &r); err != nil {
+		return nil, err
+	}
+	if err := m.verifyContainer(ctx, tx, r); err != nil {
 		return nil, err
 	}
 
-	// Get last inserted row ID
-	lastRowID, err := m.dbstore.PalletStore.GetLastInsertedRow(ctx)
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodePallet, r.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	ssccs, err := m.codes.NextSSCCs(ctx, tx, r.OrganizationID, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -35,18 +48,106 @@ func (m *PalletMaster) Create(ctx context.Context, tx pgx.Tx, r models.PalletReq
 		return nil, faulterr.NewInternalServerError(uidErr.Error())
 	}
 
-	count := lastRowID + 1
 	obj := models.Pallet{
 		UID:            uid,
-		Code:           fmt.Sprintf("PLT%05d", count),
+		Code:           code,
+		SSCC:           ssccs[0],
 		Description:    r.Description,
+		Attributes:     r.Attributes,
 		ContainerID:    r.ContainerID,
 		IsArchived:     false,
 		OrganizationID: r.OrganizationID,
 		CreatedByID:    createdByID,
 	}
 
-	return m.dbstore.PalletStore.Insert(ctx, tx, obj)
+	pallet, err := m.dbstore.PalletStore.Insert(ctx, tx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open the custody history of a pallet created in a container
+	if pallet.ContainerID.Valid {
+		assignment := models.PalletAssignment{
+			PalletID:    pallet.ID,
+			ContainerID: pallet.ContainerID.Int64,
+			LoadedByID:  createdByID,
+		}
+		if _, err := m.dbstore.PalletAssignmentStore.Insert(ctx, tx, assignment); err != nil {
+			return nil, err
+		}
+	}
+
+	return pallet, nil
+}
+
+// CreateBulk creates a number of identical pallets with sequential codes
+func (m *PalletMaster) CreateBulk(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.PalletRequest,
+	count int,
+	createdByID int64,
+) ([]models.Pallet, *faulterr.FaultErr) {
+	if err := validateBulkCount(count); err != nil {
+		return nil, err
+	}
+	if err := m.validate(ctx, &r); err != nil {
+		return nil, err
+	}
+	if err := m.verifyContainer(ctx, tx, r); err != nil {
+		return nil, err
+	}
+
+	// Get the next codes
+	codes, err := m.codes.NextN(ctx, tx, models.CodePallet, r.OrganizationID, count)
+	if err != nil {
+		return nil, err
+	}
+	ssccs, err := m.codes.NextSSCCs(ctx, tx, r.OrganizationID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]models.Pallet, count)
+	for i := range objs {
+		uid, uidErr := uuid.NewV4()
+		if uidErr != nil {
+			return nil, faulterr.NewInternalServerError(uidErr.Error())
+		}
+		objs[i] = models.Pallet{
+			UID:            uid,
+			Code:           codes[i],
+			SSCC:           ssccs[i],
+			Description:    r.Description,
+			Attributes:     r.Attributes,
+			ContainerID:    r.ContainerID,
+			IsArchived:     false,
+			OrganizationID: r.OrganizationID,
+			CreatedByID:    createdByID,
+		}
+	}
+
+	pallets, err := m.dbstore.PalletStore.InsertMany(ctx, tx, objs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open the custody history of pallets created in a container
+	if r.ContainerID.Valid {
+		assignments := make([]models.PalletAssignment, len(pallets))
+		for i, pallet := range pallets {
+			assignments[i] = models.PalletAssignment{
+				PalletID:    pallet.ID,
+				ContainerID: r.ContainerID.Int64,
+				LoadedByID:  createdByID,
+			}
+		}
+		if err := m.dbstore.PalletAssignmentStore.InsertMany(ctx, tx, assignments); err != nil {
+			return nil, err
+		}
+	}
+
+	return pallets, nil
 }
 
 func (m *PalletMaster) Update(
@@ -56,12 +157,13 @@ func (m *PalletMaster) Update(
 	req models.PalletRequest,
 ) (*models.Pallet, *faulterr.FaultErr) {
 	// Validate request
-	if err := m.validate(req); err != nil {
+	if err := m.validate(ctx, &req); err != nil {
 		return nil, err
 	}
 
 	// Update fields
 	obj.Description = req.Description
+	obj.Attributes = req.Attributes
 
 	if err := m.dbstore.PalletStore.Update(ctx, tx, *obj); err != nil {
 		return nil, err
@@ -69,9 +171,169 @@ func (m *PalletMaster) Update(
 	return obj, nil
 }
 
-func (m *PalletMaster) validate(r models.PalletRequest) *faulterr.FaultErr {
-	// if !r.Name.Valid {
-	// 	return faulterr.NewBadRequestError("contract name is required")
-	// }
+// AssignSSCC gives a pallet created before its organization set a GS1 company prefix its SSCC
+func (m *PalletMaster) AssignSSCC(ctx context.Context, tx pgx.Tx, obj *models.Pallet) (*models.Pallet, *faulterr.FaultErr) {
+	if obj.SSCC.Valid {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Pallet %s already has SSCC %s", obj.Code, obj.SSCC.String))
+	}
+	ssccs, err := m.codes.NextSSCCs(ctx, tx, obj.OrganizationID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !ssccs[0].Valid {
+		return nil, faulterr.NewBadRequestError("Organization has no GS1 company prefix")
+	}
+
+	if err := m.dbstore.PalletStore.SetSSCC(ctx, tx, obj.ID, ssccs[0].String); err != nil {
+		return nil, err
+	}
+	obj.SSCC = ssccs[0]
+	return obj, nil
+}
+
+// Move loads a pallet into a container of its organization, unloading it from its current container
+func (m *PalletMaster) Move(ctx context.Context, tx pgx.Tx, palletID int64, containerID int64, actorID int64) (*models.Pallet, *faulterr.FaultErr) {
+	pallet, err := m.dbstore.PalletStore.LockByID(ctx, tx, palletID)
+	if err != nil {
+		return nil, err
+	}
+	if pallet.IsArchived {
+		return nil, faulterr.NewBadRequestError("Archived pallets cannot be moved")
+	}
+	if pallet.ContainerID.Valid && pallet.ContainerID.Int64 == containerID {
+		return nil, faulterr.NewBadRequestError("Pallet is already in the container")
+	}
+
+	// Lock the containers so they cannot change status while the pallet moves between them
+	ids := []int64{containerID}
+	if pallet.ContainerID.Valid {
+		ids = append(ids, pallet.ContainerID.Int64)
+	}
+	containers, err := m.lockContainers(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if pallet.ContainerID.Valid {
+		if current := containers[pallet.ContainerID.Int64]; !current.CanUnload() {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("Pallets cannot be taken out of a container which is %s", containerState(current)))
+		}
+	}
+	container := containers[containerID]
+	if container.OrganizationID != pallet.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no container found with given container id")
+	}
+	if !container.CanLoad() {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Pallets cannot be moved into a container which is %s", containerState(container)))
+	}
+
+	if err := m.dbstore.PalletAssignmentStore.CloseByPalletID(ctx, tx, pallet.ID, actorID); err != nil {
+		return nil, err
+	}
+	assignment := models.PalletAssignment{
+		PalletID:    pallet.ID,
+		ContainerID: container.ID,
+		LoadedByID:  actorID,
+	}
+	if _, err := m.dbstore.PalletAssignmentStore.Insert(ctx, tx, assignment); err != nil {
+		return nil, err
+	}
+
+	// A pallet in a container is where its container is
+	if pallet.LocationID.Valid {
+		if err := m.locations.movePallet(ctx, tx, pallet, null.Int64{}, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	pallet.ContainerID = null.Int64From(container.ID)
+	if err := m.dbstore.PalletStore.Update(ctx, tx, *pallet); err != nil {
+		return nil, err
+	}
+	return pallet, nil
+}
+
+// Unload takes a pallet out of its container
+func (m *PalletMaster) Unload(ctx context.Context, tx pgx.Tx, palletID int64, actorID int64) (*models.Pallet, *faulterr.FaultErr) {
+	pallet, err := m.dbstore.PalletStore.LockByID(ctx, tx, palletID)
+	if err != nil {
+		return nil, err
+	}
+	if !pallet.ContainerID.Valid {
+		return nil, faulterr.NewBadRequestError("Pallet is not in a container")
+	}
+	current, err := m.dbstore.ContainerStore.LockByID(ctx, tx, pallet.ContainerID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	if !current.CanUnload() {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Pallets cannot be taken out of a container which is %s", containerState(current)))
+	}
+
+	if err := m.dbstore.PalletAssignmentStore.CloseByPalletID(ctx, tx, pallet.ID, actorID); err != nil {
+		return nil, err
+	}
+
+	// The pallet stays where the container was unloaded
+	if current.LocationID.Valid {
+		if err := m.locations.movePallet(ctx, tx, pallet, current.LocationID, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	pallet.ContainerID = null.Int64{}
+	if err := m.dbstore.PalletStore.Update(ctx, tx, *pallet); err != nil {
+		return nil, err
+	}
+	return pallet, nil
+}
+
+// verifyContainer locks the container new pallets are created in and checks they can be loaded into it
+func (m *PalletMaster) verifyContainer(ctx context.Context, tx pgx.Tx, r models.PalletRequest) *faulterr.FaultErr {
+	if !r.ContainerID.Valid {
+		return nil
+	}
+	container, err := m.dbstore.ContainerStore.LockByID(ctx, tx, r.ContainerID.Int64)
+	if err != nil {
+		return err
+	}
+	if container.OrganizationID != r.OrganizationID {
+		return faulterr.NewNotFoundError("no container found with given container id")
+	}
+	if !container.CanLoad() {
+		return faulterr.NewBadRequestError(fmt.Sprintf("Pallets cannot be created in a container which is %s", containerState(container)))
+	}
 	return nil
 }
+
+// lockContainers locks containers in the order of their IDs, so concurrent moves cannot deadlock
+func (m *PalletMaster) lockContainers(ctx context.Context, tx pgx.Tx, ids []int64) (map[int64]*models.Container, *faulterr.FaultErr) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	containers := map[int64]*models.Container{}
+	for _, id := range ids {
+		container, err := m.dbstore.ContainerStore.LockByID(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		containers[id] = container
+	}
+	return containers, nil
+}
+
+// validate checks the request and converts its custom attributes to the types its organization defined
+func (m *PalletMaster) validate(ctx context.Context, r *models.PalletRequest) *faulterr.FaultErr {
+	attrs, err := m.attributes.Validate(ctx, r.OrganizationID, models.CodePallet, r.Attributes)
+	if err != nil {
+		return err
+	}
+	r.Attributes = attrs
+	return nil
+}
+
+// containerState describes the state of a container in error messages
+func containerState(c *models.Container) string {
+	if c.IsArchived {
+		return "archived"
+	}
+	return strings.ReplaceAll(c.Status, "_", " ")
+}
@@ -0,0 +1,226 @@
+package master
+
+import (
+	"context"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/encrypt"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type PurchaseRecordMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+	wallet  *WalletMaster
+}
+
+func NewPurchaseRecordMaster(s *dbstore.DBStore) *PurchaseRecordMaster {
+	return &PurchaseRecordMaster{s, NewCodeMaster(s), NewWalletMaster(s)}
+}
+
+func (m *PurchaseRecordMaster) Create(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.PurchaseRecordRequest,
+	createdByID int64,
+) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	if err := m.validate(&r); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodePurchaseRecord, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	token, err := m.generateToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := models.PurchaseRecord{
+		UID:            uid,
+		Code:           code,
+		ProductUID:     r.ProductUID,
+		BuyerEmail:     r.BuyerEmail,
+		BuyerPhone:     r.BuyerPhone,
+		PurchaseToken:  token,
+		Retailer:       r.Retailer,
+		Points:         r.Points,
+		OrganizationID: r.OrganizationID.Int64,
+		CreatedByID:    createdByID,
+	}
+
+	return m.dbstore.PurchaseRecordStore.Insert(ctx, tx, obj)
+}
+
+func (m *PurchaseRecordMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.PurchaseRecord,
+	req models.PurchaseRecordRequest,
+) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	if obj.RedeemedByID.Valid {
+		return nil, faulterr.NewBadRequestError("redeemed purchase records cannot be updated")
+	}
+	// Validate request
+	if err := m.validate(&req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.ProductUID = req.ProductUID
+	obj.BuyerEmail = req.BuyerEmail
+	obj.BuyerPhone = req.BuyerPhone
+	obj.Retailer = req.Retailer
+	obj.Points = req.Points
+
+	if err := m.dbstore.PurchaseRecordStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// RedeemByEmail redeems all open purchase records made with the email of the user
+func (m *PurchaseRecordMaster) RedeemByEmail(ctx context.Context, tx pgx.Tx, u *models.User) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	if strings.TrimSpace(u.Email) == "" {
+		return []models.PurchaseRecord{}, nil
+	}
+	records, err := m.dbstore.PurchaseRecordStore.ListUnredeemedByEmail(ctx, u.Email)
+	if err != nil {
+		return nil, err
+	}
+	return m.redeemAll(ctx, tx, records, u.ID)
+}
+
+// RedeemByPhone redeems all open purchase records made with the phone of the user,
+// users registered without a phone have no purchases to claim by it
+func (m *PurchaseRecordMaster) RedeemByPhone(ctx context.Context, tx pgx.Tx, u *models.User) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	if strings.TrimSpace(u.Phone) == "" {
+		return []models.PurchaseRecord{}, nil
+	}
+	records, err := m.dbstore.PurchaseRecordStore.ListUnredeemedByPhone(ctx, u.Phone)
+	if err != nil {
+		return nil, err
+	}
+	return m.redeemAll(ctx, tx, records, u.ID)
+}
+
+// RedeemByToken redeems the purchase record of a purchase token for the user
+func (m *PurchaseRecordMaster) RedeemByToken(ctx context.Context, tx pgx.Tx, userID int64, token string) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	if token == "" {
+		return nil, faulterr.NewBadRequestError("Purchase token is required")
+	}
+
+	obj, err := m.dbstore.PurchaseRecordStore.GetByToken(ctx, token)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, faulterr.NewBadRequestError("invalid purchase token")
+		}
+		return nil, err
+	}
+	// Already redeemed by the same user, e.g. by email on registration
+	if obj.RedeemedByID.Valid && obj.RedeemedByID.Int64 == userID {
+		return obj, nil
+	}
+
+	return m.redeem(ctx, tx, obj, userID)
+}
+
+func (m *PurchaseRecordMaster) redeemAll(
+	ctx context.Context,
+	tx pgx.Tx,
+	records []models.PurchaseRecord,
+	userID int64,
+) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	redeemed := []models.PurchaseRecord{}
+	for i := range records {
+		obj, err := m.redeem(ctx, tx, &records[i], userID)
+		if err != nil {
+			return nil, err
+		}
+		redeemed = append(redeemed, *obj)
+	}
+	return redeemed, nil
+}
+
+// redeem claims the purchase record for the user and credits its points to the user wallet
+func (m *PurchaseRecordMaster) redeem(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.PurchaseRecord,
+	userID int64,
+) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	if obj.RedeemedByID.Valid {
+		return nil, faulterr.NewBadRequestError("purchase record is already redeemed")
+	}
+
+	// The update only matches while the record is unredeemed, so concurrent redemptions cannot both succeed
+	record, err := m.dbstore.PurchaseRecordStore.Redeem(ctx, tx, obj.ID, userID)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, faulterr.NewBadRequestError("purchase record is already redeemed")
+		}
+		return nil, err
+	}
+
+	if record.Points > 0 {
+		ref := WalletReference{models.WalletRefPurchaseRecord, record.ID}
+		if _, err := m.wallet.Earn(ctx, tx, userID, record.Points, "Purchase "+record.Code, ref); err != nil {
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
+
+// generateToken returns a purchase token which is not used by another purchase record
+func (m *PurchaseRecordMaster) generateToken(ctx context.Context) (string, *faulterr.FaultErr) {
+	for i := 0; i < 5; i++ {
+		token := encrypt.GenerateRandomString(12)
+		_, err := m.dbstore.PurchaseRecordStore.GetByToken(ctx, token)
+		if err == nil {
+			continue
+		}
+		if err.Status == http.StatusNotFound {
+			return token, nil
+		}
+		return "", err
+	}
+	return "", faulterr.NewInternalServerError("could not generate a unique purchase token")
+}
+
+// validate checks a purchase record request, a blank buyer email or phone is stored as null
+// so it never matches the blank phone of a customer
+func (m *PurchaseRecordMaster) validate(r *models.PurchaseRecordRequest) *faulterr.FaultErr {
+	r.BuyerEmail = blankToNull(r.BuyerEmail)
+	r.BuyerPhone = blankToNull(r.BuyerPhone)
+	if r.ProductUID == uuid.Nil {
+		return faulterr.NewBadRequestError("Product UID is required")
+	}
+	if r.Points < 0 {
+		return faulterr.NewBadRequestError("Points cannot be negative")
+	}
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	return nil
+}
+
+// blankToNull trims a string and makes it null when nothing is left
+func blankToNull(s null.String) null.String {
+	value := strings.TrimSpace(s.String)
+	return null.NewString(value, s.Valid && value != "")
+}
@@ -0,0 +1,379 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// RecallMaster resolves a recall to the pallets, containers, orders and products it affects,
+// marks them recalled and notifies the organizations and customers which received them
+type RecallMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewRecallMaster(s *dbstore.DBStore) *RecallMaster {
+	return &RecallMaster{s, NewCodeMaster(s)}
+}
+
+func (m *RecallMaster) Create(ctx context.Context, tx pgx.Tx, r models.RecallRequest, createdByID int64) (*models.Recall, *faulterr.FaultErr) {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Reason = strings.TrimSpace(r.Reason)
+	uids, err := m.validate(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeRecall, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Recall{
+		UID:            uid,
+		Code:           code,
+		Title:          r.Title,
+		Reason:         r.Reason,
+		Scope:          r.Scope,
+		SkuID:          r.SkuID,
+		UIDs:           uids,
+		OrganizationID: r.OrganizationID.Int64,
+		CreatedByID:    createdByID,
+	}
+	if obj.Scope != models.RecallScopeUIDs {
+		obj.UIDs = []string{}
+	}
+	if obj.Scope != models.RecallScopeSku {
+		obj.SkuID = null.Int64{}
+	}
+	if obj.Scope == models.RecallScopeLot {
+		obj.LotID = r.LotID
+	}
+
+	recall, err := m.dbstore.RecallStore.Insert(ctx, tx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := m.resolve(ctx, recall)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 && recall.Scope == models.RecallScopeUIDs {
+		return nil, faulterr.NewNotFoundError("no pallets, containers or products found with given uids")
+	}
+
+	// Keep each insert under the bind parameter limit
+	for start := 0; start < len(items); start += models.BulkCreateLimit {
+		end := start + models.BulkCreateLimit
+		if end > len(items) {
+			end = len(items)
+		}
+		if _, err := m.dbstore.RecallItemStore.InsertMany(ctx, tx, items[start:end]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.notify(ctx, tx, recall, items); err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
+
+// ResolveItem marks an item of an open recall as dealt with, returned or destroyed
+func (m *RecallMaster) ResolveItem(ctx context.Context, tx pgx.Tx, id int64, itemID int64, actorID int64) (*models.RecallItem, *faulterr.FaultErr) {
+	recall, err := m.dbstore.RecallStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recall.Status != models.RecallOpen {
+		return nil, faulterr.NewBadRequestError("items of a closed recall cannot be resolved")
+	}
+
+	item, err := m.dbstore.RecallItemStore.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.RecallID != recall.ID {
+		return nil, faulterr.NewNotFoundError("no item found with given item id")
+	}
+	if item.ResolvedAt.Valid {
+		return nil, faulterr.NewBadRequestError("item is already resolved")
+	}
+
+	item.ResolvedByID = null.Int64From(actorID)
+	item.ResolvedAt = null.TimeFrom(time.Now())
+	if err := m.dbstore.RecallItemStore.Update(ctx, tx, *item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Close ends a recall, its unresolved items are no longer marked recalled
+func (m *RecallMaster) Close(ctx context.Context, tx pgx.Tx, id int64) (*models.Recall, *faulterr.FaultErr) {
+	obj, err := m.dbstore.RecallStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Status != models.RecallOpen {
+		return nil, faulterr.NewBadRequestError("recall is already closed")
+	}
+
+	obj.Status = models.RecallClosed
+	obj.ClosedAt = null.TimeFrom(time.Now())
+	if err := m.dbstore.RecallStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// validate checks a recall request and gets the UIDs it is scoped by in their canonical form
+func (m *RecallMaster) validate(ctx context.Context, r models.RecallRequest) ([]string, *faulterr.FaultErr) {
+	if !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("Organization ID is required")
+	}
+	if r.Title == "" {
+		return nil, faulterr.NewBadRequestError("Title is required")
+	}
+
+	switch r.Scope {
+	case models.RecallScopeSku:
+		if !r.SkuID.Valid {
+			return nil, faulterr.NewBadRequestError("SKU is required")
+		}
+		sku, err := m.dbstore.SkuStore.GetByID(ctx, r.SkuID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		if sku.OrganizationID != r.OrganizationID.Int64 {
+			return nil, faulterr.NewNotFoundError("no sku found with given sku id")
+		}
+		return nil, nil
+	case models.RecallScopeLot:
+		if !r.LotID.Valid {
+			return nil, faulterr.NewBadRequestError("Lot is required")
+		}
+		lot, err := m.dbstore.LotStore.GetByID(ctx, r.LotID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		if lot.OrganizationID != r.OrganizationID.Int64 {
+			return nil, faulterr.NewNotFoundError("no lot found with given lot id")
+		}
+		return nil, nil
+	case models.RecallScopeUIDs:
+		if len(r.UIDs) == 0 {
+			return nil, faulterr.NewBadRequestError("UIDs are required")
+		}
+		if len(r.UIDs) > models.BulkCreateLimit {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("a recall can have at most %d uids", models.BulkCreateLimit))
+		}
+		seen := make(map[string]bool, len(r.UIDs))
+		uids := make([]string, 0, len(r.UIDs))
+		for _, v := range r.UIDs {
+			uid, err := uuid.FromString(strings.TrimSpace(v))
+			if err != nil {
+				return nil, faulterr.NewBadRequestError(fmt.Sprintf("%s is not a valid uid", v))
+			}
+			if !seen[uid.String()] {
+				seen[uid.String()] = true
+				uids = append(uids, uid.String())
+			}
+		}
+		return uids, nil
+	default:
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("%s is not a valid recall scope", r.Scope))
+	}
+}
+
+// resolve finds the items of the organization a recall affects. A SKU recall covers the orders and
+// consumer orders of the SKU with the pallets allocated to them, a lot recall covers the pallets of
+// the lot and a UID recall covers the pallets, containers and products with those UIDs. Orders the
+// pallets were allocated to and containers holding them are affected as well.
+func (m *RecallMaster) resolve(ctx context.Context, recall *models.Recall) ([]models.RecallItem, *faulterr.FaultErr) {
+	orgID := recall.OrganizationID
+	items := []models.RecallItem{}
+	add := func(itemType string, itemID int64, holderID null.Int64, customerID null.Int64) {
+		items = append(items, models.RecallItem{
+			RecallID:       recall.ID,
+			ItemType:       itemType,
+			ItemID:         itemID,
+			OrganizationID: holderID,
+			CustomerID:     customerID,
+		})
+	}
+
+	pallets := []models.Pallet{}
+	containers := []models.Container{}
+	orders := []models.Order{}
+
+	switch recall.Scope {
+	case models.RecallScopeSku:
+		list, err := m.dbstore.OrderStore.ListBySkuID(ctx, recall.SkuID.Int64, []string{models.OrderDraft, models.OrderCancelled})
+		if err != nil {
+			return nil, err
+		}
+		orders = list
+
+		orderIDs := make([]int64, 0, len(orders))
+		for _, order := range orders {
+			orderIDs = append(orderIDs, order.ID)
+		}
+		if pallets, err = m.dbstore.PalletStore.ListByOrderIDs(ctx, orderIDs); err != nil {
+			return nil, err
+		}
+		// Pallets of the lots of the SKU are recalled too, including those still in stock
+		lotPallets, err := m.dbstore.PalletStore.ListBySkuID(ctx, recall.SkuID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		pallets = append(pallets, lotPallets...)
+
+		consumerOrders, err := m.dbstore.ConsumerOrderStore.ListBySkuID(ctx, recall.SkuID.Int64, []string{models.ConsumerOrderCancelled})
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range consumerOrders {
+			if order.OrganizationID == orgID {
+				add(models.CodeConsumerOrder, order.ID, null.Int64{}, null.Int64From(order.CustomerID))
+			}
+		}
+	case models.RecallScopeLot:
+		list, err := m.dbstore.PalletStore.ListByLotID(ctx, recall.LotID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		pallets = list
+
+		palletIDs := make([]int64, 0, len(pallets))
+		for _, pallet := range pallets {
+			palletIDs = append(palletIDs, pallet.ID)
+		}
+		if orders, err = m.dbstore.OrderStore.ListByPalletIDs(ctx, palletIDs); err != nil {
+			return nil, err
+		}
+	case models.RecallScopeUIDs:
+		list, err := m.dbstore.ContainerStore.ListByUIDs(ctx, recall.UIDs)
+		if err != nil {
+			return nil, err
+		}
+		containerIDs := []int64{}
+		for _, container := range list {
+			if container.OrganizationID.Int64 == orgID {
+				containers = append(containers, container)
+				containerIDs = append(containerIDs, container.ID)
+			}
+		}
+
+		if pallets, err = m.dbstore.PalletStore.ListByUIDs(ctx, recall.UIDs); err != nil {
+			return nil, err
+		}
+		inside, listErr := m.dbstore.PalletStore.ListByContainerIDs(ctx, containerIDs)
+		if listErr != nil {
+			return nil, faulterr.NewPostgresError(listErr, "error when trying to get pallets")
+		}
+		pallets = append(pallets, inside...)
+
+		palletIDs := make([]int64, 0, len(pallets))
+		for _, pallet := range pallets {
+			palletIDs = append(palletIDs, pallet.ID)
+		}
+		if orders, err = m.dbstore.OrderStore.ListByPalletIDs(ctx, palletIDs); err != nil {
+			return nil, err
+		}
+
+		records, err := m.dbstore.PurchaseRecordStore.ListByProductUIDs(ctx, recall.UIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record.OrganizationID == orgID {
+				add(models.CodePurchaseRecord, record.ID, null.Int64{}, record.RedeemedByID)
+			}
+		}
+	}
+
+	for _, order := range orders {
+		if order.OrganizationID == orgID && order.Status != models.OrderDraft && order.Status != models.OrderCancelled {
+			add(models.CodeOrder, order.ID, null.Int64From(order.BuyerOrganizationID), null.Int64{})
+		}
+	}
+
+	// Pallets come by UID and from their containers, or from orders and lots, add each once
+	seen := map[int64]bool{}
+	holderIDs := []int64{}
+	for _, pallet := range pallets {
+		if seen[pallet.ID] || pallet.OrganizationID.Int64 != orgID {
+			continue
+		}
+		seen[pallet.ID] = true
+		add(models.CodePallet, pallet.ID, pallet.OrganizationID, null.Int64{})
+		if pallet.ContainerID.Valid {
+			holderIDs = append(holderIDs, pallet.ContainerID.Int64)
+		}
+	}
+
+	holders, err := m.dbstore.ContainerStore.ListByIDs(ctx, holderIDs)
+	if err != nil {
+		return nil, err
+	}
+	seen = map[int64]bool{}
+	for _, container := range append(containers, holders...) {
+		if seen[container.ID] || container.OrganizationID.Int64 != orgID {
+			continue
+		}
+		seen[container.ID] = true
+		add(models.CodeContainer, container.ID, container.OrganizationID, null.Int64{})
+	}
+
+	return items, nil
+}
+
+// notify tells every organization and registered customer which received an item of the recall,
+// the organization running the recall is left out
+func (m *RecallMaster) notify(ctx context.Context, tx pgx.Tx, recall *models.Recall, items []models.RecallItem) *faulterr.FaultErr {
+	notification := models.Notification{
+		Kind:       models.NotificationRecall,
+		Subject:    fmt.Sprintf("Recall %s: %s", recall.Code, recall.Title),
+		Body:       recall.Reason,
+		EntityType: null.StringFrom(models.CodeRecall),
+		EntityID:   null.Int64From(recall.ID),
+	}
+
+	orgs := map[int64]bool{recall.OrganizationID: true}
+	customers := map[int64]bool{}
+	for _, item := range items {
+		if item.OrganizationID.Valid && !orgs[item.OrganizationID.Int64] {
+			orgs[item.OrganizationID.Int64] = true
+			notification.OrganizationID = item.OrganizationID
+			notification.UserID = null.Int64{}
+			if _, err := m.dbstore.NotificationStore.Insert(ctx, tx, notification); err != nil {
+				return err
+			}
+		}
+		if item.CustomerID.Valid && !customers[item.CustomerID.Int64] {
+			customers[item.CustomerID.Int64] = true
+			notification.OrganizationID = null.Int64{}
+			notification.UserID = item.CustomerID
+			if _, err := m.dbstore.NotificationStore.Insert(ctx, tx, notification); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package master
+
+import (
+	"context"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type ReferralMaster struct {
+	dbstore *dbstore.DBStore
+	wallet  *WalletMaster
+}
+
+func NewReferralMaster(s *dbstore.DBStore) *ReferralMaster {
+	return &ReferralMaster{s, NewWalletMaster(s)}
+}
+
+// Create records that the user registered with the referral code of another customer
+// and rewards both parties with the points of the active referral rule
+func (m *ReferralMaster) Create(ctx context.Context, tx pgx.Tx, u *models.User, referralCode string) *faulterr.FaultErr {
+	referralCode = strings.TrimSpace(referralCode)
+	if referralCode == "" {
+		return faulterr.NewBadRequestError("Referral code is required")
+	}
+
+	referrer, err := m.dbstore.ProfileStore.GetByReferralCode(ctx, referralCode)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return faulterr.NewBadRequestError("invalid referral code")
+		}
+		return err
+	}
+	if referrer.UserID == u.ID {
+		return faulterr.NewBadRequestError("customers cannot refer themselves")
+	}
+	if _, err := m.dbstore.ReferralStore.GetByRefereeID(ctx, u.ID); err == nil {
+		return faulterr.NewBadRequestError("customer has already been referred")
+	}
+
+	obj := models.Referral{
+		ReferrerID:   referrer.UserID,
+		RefereeID:    u.ID,
+		ReferralCode: referralCode,
+	}
+
+	rule, err := m.dbstore.ReferralRuleStore.GetActive(ctx)
+	if err != nil && err.Status != http.StatusNotFound {
+		return err
+	}
+	if rule != nil {
+		obj.ReferralRuleID = null.Int64From(rule.ID)
+		obj.ReferrerPoints = rule.ReferrerPoints
+		obj.RefereePoints = rule.RefereePoints
+	}
+
+	referral, err := m.dbstore.ReferralStore.Insert(ctx, tx, obj)
+	if err != nil {
+		return err
+	}
+
+	ref := WalletReference{models.WalletRefReferral, referral.ID}
+	if referral.ReferrerPoints > 0 {
+		if _, err := m.wallet.Earn(ctx, tx, referral.ReferrerID, referral.ReferrerPoints, "Referral reward", ref); err != nil {
+			return err
+		}
+	}
+	if referral.RefereePoints > 0 {
+		if _, err := m.wallet.Earn(ctx, tx, referral.RefereeID, referral.RefereePoints, "Referral welcome reward", ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateRule saves a referral reward rule, an active rule replaces the previously active one
+func (m *ReferralMaster) CreateRule(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.ReferralRuleRequest,
+	createdByID int64,
+) (*models.ReferralRule, *faulterr.FaultErr) {
+	if err := m.validateRule(r); err != nil {
+		return nil, err
+	}
+
+	obj := models.ReferralRule{
+		ReferrerPoints: r.ReferrerPoints,
+		RefereePoints:  r.RefereePoints,
+		IsActive:       r.IsActive,
+		CreatedByID:    createdByID,
+	}
+
+	rule, err := m.dbstore.ReferralRuleStore.Insert(ctx, tx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if rule.IsActive {
+		if err := m.dbstore.ReferralRuleStore.DeactivateOthers(ctx, tx, rule.ID); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+func (m *ReferralMaster) UpdateRule(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.ReferralRule,
+	req models.ReferralRuleRequest,
+) (*models.ReferralRule, *faulterr.FaultErr) {
+	if err := m.validateRule(req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.ReferrerPoints = req.ReferrerPoints
+	obj.RefereePoints = req.RefereePoints
+	obj.IsActive = req.IsActive
+
+	if err := m.dbstore.ReferralRuleStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	if obj.IsActive {
+		if err := m.dbstore.ReferralRuleStore.DeactivateOthers(ctx, tx, obj.ID); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+func (m *ReferralMaster) validateRule(r models.ReferralRuleRequest) *faulterr.FaultErr {
+	if r.ReferrerPoints < 0 || r.RefereePoints < 0 {
+		return faulterr.NewBadRequestError("Referral points cannot be negative")
+	}
+	return nil
+}
@@ -2,22 +2,21 @@ package master
 
 import (
 	"context"
-	"fmt"
 	"orijinplus/app/models"
 	"orijinplus/app/store/dbstore"
 	"orijinplus/utils/faulterr"
 
 	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
 )
 
 type RoleMaster struct {
 	dbstore *dbstore.DBStore
+	codes   *CodeMaster
 }
 
 func NewRoleMaster(s *dbstore.DBStore) *RoleMaster {
-	return &RoleMaster{
-		s,
-	}
+	return &RoleMaster{s, NewCodeMaster(s)}
 }
 
 func (m *RoleMaster) Create(ctx context.Context, tx pgx.Tx, r models.RoleCreateRequest) (*models.Role, *faulterr.FaultErr) {
@@ -25,15 +24,14 @@ func (m *RoleMaster) Create(ctx context.Context, tx pgx.Tx, r models.RoleCreateR
 		return nil, err
 	}
 
-	// Get last inserted row id
-	lastRowID, err := m.dbstore.RoleStore.GetLastInsertedRow(ctx)
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeRole, null.Int64From(r.OrganizationID))
 	if err != nil {
 		return nil, err
 	}
 
-	count := lastRowID + 1
 	role := models.Role{
-		Code:        fmt.Sprintf("ROLE%05d", count),
+		Code:        code,
 		Name:        r.Name,
 		Permissions: r.Permissions,
 		IsOrgAdmin:  r.IsOrgAdmin,
@@ -0,0 +1,292 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// ShipmentMaster groups containers into shipments, departing and arriving a shipment
+// moves its containers through their lifecycle
+type ShipmentMaster struct {
+	dbstore    *dbstore.DBStore
+	codes      *CodeMaster
+	containers *ContainerMaster
+}
+
+func NewShipmentMaster(s *dbstore.DBStore) *ShipmentMaster {
+	return &ShipmentMaster{s, NewCodeMaster(s), NewContainerMaster(s)}
+}
+
+func (m *ShipmentMaster) Create(ctx context.Context, tx pgx.Tx, r models.ShipmentRequest, createdByID int64) (*models.Shipment, *faulterr.FaultErr) {
+	trimShipment(&r)
+	if err := m.validate(ctx, r); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeShipment, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Shipment{
+		UID:                  uid,
+		Code:                 code,
+		Status:               models.ShipmentPlanned,
+		OriginAddressID:      r.OriginAddressID,
+		DestinationAddressID: r.DestinationAddressID,
+		Carrier:              r.Carrier,
+		TransportReference:   r.TransportReference,
+		ETD:                  r.ETD,
+		ETA:                  r.ETA,
+		Notes:                r.Notes,
+		OrganizationID:       r.OrganizationID.Int64,
+		CreatedByID:          createdByID,
+	}
+
+	return m.dbstore.ShipmentStore.Insert(ctx, tx, obj)
+}
+
+// Update changes the route and schedule of a shipment which has not departed yet
+func (m *ShipmentMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	id int64,
+	req models.ShipmentRequest,
+) (*models.Shipment, *faulterr.FaultErr) {
+	obj, err := m.dbstore.ShipmentStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Status != models.ShipmentPlanned {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("shipment is %s and cannot be changed", obj.Status))
+	}
+
+	trimShipment(&req)
+	req.OrganizationID = null.Int64From(obj.OrganizationID)
+	if err := m.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.OriginAddressID = req.OriginAddressID
+	obj.DestinationAddressID = req.DestinationAddressID
+	obj.Carrier = req.Carrier
+	obj.TransportReference = req.TransportReference
+	obj.ETD = req.ETD
+	obj.ETA = req.ETA
+	obj.Notes = req.Notes
+
+	if err := m.dbstore.ShipmentStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// AddContainer puts a container of the organization on a planned shipment,
+// a container travels on a single shipment at a time
+func (m *ShipmentMaster) AddContainer(ctx context.Context, tx pgx.Tx, id int64, containerID int64, actorID int64) (*models.Shipment, *faulterr.FaultErr) {
+	obj, err := m.dbstore.ShipmentStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Status != models.ShipmentPlanned {
+		return nil, faulterr.NewBadRequestError("containers can only be added to planned shipments")
+	}
+
+	container, err := m.dbstore.ContainerStore.LockByID(ctx, tx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if container.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no container found with given container id")
+	}
+	if container.IsArchived {
+		return nil, faulterr.NewBadRequestError("archived containers cannot be shipped")
+	}
+	switch container.Status {
+	case models.ContainerOpen, models.ContainerPacking, models.ContainerSealed:
+	default:
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("container %s is %s and cannot be shipped", container.Code, containerState(container)))
+	}
+
+	current, err := m.dbstore.ShipmentContainerStore.GetOpenByContainerID(ctx, container.ID)
+	if err != nil && err.Status != http.StatusNotFound {
+		return nil, err
+	}
+	if current != nil {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("container %s is already on a shipment", container.Code))
+	}
+
+	entry := models.ShipmentContainer{
+		ShipmentID:  obj.ID,
+		ContainerID: container.ID,
+		AddedByID:   actorID,
+	}
+	if _, err := m.dbstore.ShipmentContainerStore.Insert(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// RemoveContainer takes a container off a planned shipment
+func (m *ShipmentMaster) RemoveContainer(ctx context.Context, tx pgx.Tx, id int64, containerID int64) (*models.Shipment, *faulterr.FaultErr) {
+	obj, err := m.dbstore.ShipmentStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Status != models.ShipmentPlanned {
+		return nil, faulterr.NewBadRequestError("containers can only be removed from planned shipments")
+	}
+
+	entries, err := m.dbstore.ShipmentContainerStore.ListByShipmentID(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.ContainerID == containerID {
+			if err := m.dbstore.ShipmentContainerStore.Delete(ctx, tx, entry.ID); err != nil {
+				return nil, err
+			}
+			return obj, nil
+		}
+	}
+	return nil, faulterr.NewNotFoundError("container is not on this shipment")
+}
+
+// UpdateStatus moves a shipment through its lifecycle along with its containers,
+// departing puts the sealed containers in transit and arriving marks them arrived
+func (m *ShipmentMaster) UpdateStatus(ctx context.Context, tx pgx.Tx, id int64, status string, actorID int64) (*models.Shipment, *faulterr.FaultErr) {
+	obj, err := m.dbstore.ShipmentStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransition(models.ShipmentTransitions, obj.Status, status) {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("shipment cannot move from %s to %s", obj.Status, status))
+	}
+
+	entries, err := m.dbstore.ShipmentContainerStore.ListByShipmentID(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	switch status {
+	case models.ShipmentDeparted:
+		if len(entries) == 0 {
+			return nil, faulterr.NewBadRequestError("shipment has no containers")
+		}
+		if err := m.transitionContainers(ctx, tx, entries, models.ContainerSealed, models.ContainerInTransit, actorID); err != nil {
+			return nil, err
+		}
+		obj.DepartedAt = null.TimeFrom(now)
+	case models.ShipmentArrived:
+		if err := m.transitionContainers(ctx, tx, entries, models.ContainerInTransit, models.ContainerArrived, actorID); err != nil {
+			return nil, err
+		}
+		obj.ArrivedAt = null.TimeFrom(now)
+	}
+
+	obj.Status = status
+	if err := m.dbstore.ShipmentStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// transitionContainers moves every container of a shipment from one status to the next
+func (m *ShipmentMaster) transitionContainers(
+	ctx context.Context,
+	tx pgx.Tx,
+	entries []models.ShipmentContainer,
+	from string,
+	to string,
+	actorID int64,
+) *faulterr.FaultErr {
+	for _, entry := range entries {
+		container, err := m.dbstore.ContainerStore.LockByID(ctx, tx, entry.ContainerID)
+		if err != nil {
+			return err
+		}
+		if container.Status != from {
+			return faulterr.NewBadRequestError(fmt.Sprintf("container %s is %s, it must be %s", container.Code, containerState(container), strings.ReplaceAll(from, "_", " ")))
+		}
+		if _, err := m.containers.Transition(ctx, tx, container.ID, to, actorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ShipmentMaster) validate(ctx context.Context, r models.ShipmentRequest) *faulterr.FaultErr {
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	if r.OriginAddressID <= 0 {
+		return faulterr.NewBadRequestError("Origin address is required")
+	}
+	if r.DestinationAddressID <= 0 {
+		return faulterr.NewBadRequestError("Destination address is required")
+	}
+	if r.OriginAddressID == r.DestinationAddressID {
+		return faulterr.NewBadRequestError("Origin and destination cannot be the same address")
+	}
+	if r.ETD.Valid && r.ETA.Valid && r.ETA.Time.Before(r.ETD.Time) {
+		return faulterr.NewBadRequestError("ETA cannot be before ETD")
+	}
+	if err := m.verifyAddress(ctx, r.OriginAddressID, r.OrganizationID.Int64); err != nil {
+		return err
+	}
+	return m.verifyAddress(ctx, r.DestinationAddressID, r.OrganizationID.Int64)
+}
+
+// verifyAddress checks a shipment address is an address of the organization or of a distributor
+func (m *ShipmentMaster) verifyAddress(ctx context.Context, addressID int64, orgID int64) *faulterr.FaultErr {
+	address, err := m.dbstore.AddressStore.GetByID(ctx, addressID)
+	if err != nil {
+		return err
+	}
+	if address.UserID.Valid {
+		return faulterr.NewNotFoundError("no address found with given address id")
+	}
+	if address.OrganizationID.Valid {
+		if address.OrganizationID.Int64 != orgID {
+			return faulterr.NewNotFoundError("no address found with given address id")
+		}
+		return nil
+	}
+
+	// Addresses without an owner belong to a distributor, which must be one of the organization
+	distributor, err := m.dbstore.DistributorStore.GetByAddressID(ctx, addressID)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return faulterr.NewNotFoundError("no address found with given address id")
+		}
+		return err
+	}
+	if distributor.OrganizationID != orgID {
+		return faulterr.NewNotFoundError("no address found with given address id")
+	}
+	return nil
+}
+
+func trimShipment(r *models.ShipmentRequest) {
+	r.Carrier = strings.TrimSpace(r.Carrier)
+	r.TransportReference = strings.TrimSpace(r.TransportReference)
+	r.Notes = strings.TrimSpace(r.Notes)
+}
@@ -0,0 +1,121 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type SkuMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewSkuMaster(s *dbstore.DBStore) *SkuMaster {
+	return &SkuMaster{s, NewCodeMaster(s)}
+}
+
+func (m *SkuMaster) Create(ctx context.Context, tx pgx.Tx, r models.SkuRequest, createdByID int64) (*models.Sku, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+
+	gtin, err := m.gtin(ctx, r.GTIN, r.OrganizationID.Int64, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeSku, r.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Sku{
+		UID:            uid,
+		Code:           code,
+		Name:           r.Name,
+		Description:    r.Description,
+		Price:          r.Price,
+		GTIN:           gtin,
+		IsArchived:     false,
+		OrganizationID: r.OrganizationID.Int64,
+		CreatedByID:    createdByID,
+	}
+
+	return m.dbstore.SkuStore.Insert(ctx, tx, obj)
+}
+
+func (m *SkuMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Sku,
+	req models.SkuRequest,
+) (*models.Sku, *faulterr.FaultErr) {
+	// Validate request
+	if err := m.validate(req); err != nil {
+		return nil, err
+	}
+
+	gtin, err := m.gtin(ctx, req.GTIN, obj.OrganizationID, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.Name = req.Name
+	obj.Description = req.Description
+	obj.Price = req.Price
+	obj.GTIN = gtin
+
+	if err := m.dbstore.SkuStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (m *SkuMaster) validate(r models.SkuRequest) *faulterr.FaultErr {
+	if r.Name == "" {
+		return faulterr.NewBadRequestError("SKU Name is required")
+	}
+	if r.Price < 0 {
+		return faulterr.NewBadRequestError("SKU Price cannot be negative")
+	}
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	return nil
+}
+
+// gtin checks the GTIN of a sku and pads it to the GTIN-14 it is stored as.
+// A GTIN identifies a single sku of an organization, skuID is the sku keeping its own GTIN on update
+func (m *SkuMaster) gtin(ctx context.Context, gtin null.String, orgID int64, skuID int64) (null.String, *faulterr.FaultErr) {
+	if !gtin.Valid || gtin.String == "" {
+		return null.String{}, nil
+	}
+	if !models.ValidGTIN(gtin.String) {
+		return null.String{}, faulterr.NewBadRequestError(fmt.Sprintf("GTIN %s is not valid", gtin.String))
+	}
+	gtin14 := models.GTIN14(gtin.String)
+
+	current, err := m.dbstore.SkuStore.GetByOrgGTIN(ctx, orgID, gtin14)
+	if err != nil && err.Status != http.StatusNotFound {
+		return null.String{}, err
+	}
+	if current != nil && current.ID != skuID {
+		return null.String{}, faulterr.NewBadRequestError(fmt.Sprintf("GTIN %s is already used by SKU %s", gtin.String, current.Code))
+	}
+	return null.StringFrom(gtin14), nil
+}
@@ -0,0 +1,183 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+type TaskMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewTaskMaster(s *dbstore.DBStore) *TaskMaster {
+	return &TaskMaster{s, NewCodeMaster(s)}
+}
+
+func (m *TaskMaster) Create(ctx context.Context, tx pgx.Tx, r models.TaskRequest, createdByID int64) (*models.Task, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+	if err := m.verifyLinks(ctx, r); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeTask, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Task{
+		UID:            uid,
+		Code:           code,
+		Title:          r.Title,
+		Description:    r.Description,
+		Status:         models.TaskOpen,
+		DueDate:        r.DueDate,
+		AssigneeUserID: r.AssigneeUserID,
+		AssigneeRoleID: r.AssigneeRoleID,
+		ContainerID:    r.ContainerID,
+		PalletID:       r.PalletID,
+		OrganizationID: r.OrganizationID.Int64,
+		CreatedByID:    createdByID,
+	}
+
+	return m.dbstore.TaskStore.Insert(ctx, tx, obj)
+}
+
+func (m *TaskMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Task,
+	req models.TaskRequest,
+) (*models.Task, *faulterr.FaultErr) {
+	if obj.Status == models.TaskDone || obj.Status == models.TaskCancelled {
+		return nil, faulterr.NewBadRequestError("closed tasks cannot be updated")
+	}
+	// Validate request
+	if err := m.validate(req); err != nil {
+		return nil, err
+	}
+	if err := m.verifyLinks(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.Title = req.Title
+	obj.Description = req.Description
+	obj.DueDate = req.DueDate
+	obj.AssigneeUserID = req.AssigneeUserID
+	obj.AssigneeRoleID = req.AssigneeRoleID
+	obj.ContainerID = req.ContainerID
+	obj.PalletID = req.PalletID
+
+	if err := m.dbstore.TaskStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// UpdateStatus moves a task through its lifecycle
+func (m *TaskMaster) UpdateStatus(ctx context.Context, tx pgx.Tx, obj *models.Task, status string) (*models.Task, *faulterr.FaultErr) {
+	if !canTransition(models.TaskTransitions, obj.Status, status) {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("task cannot move from %s to %s", obj.Status, status))
+	}
+
+	obj.Status = status
+	if status == models.TaskDone {
+		obj.CompletedAt = null.TimeFrom(time.Now().UTC())
+	}
+
+	if err := m.dbstore.TaskStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// AddComment appends a comment to a task
+func (m *TaskMaster) AddComment(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Task,
+	body string,
+	userID int64,
+) (*models.TaskComment, *faulterr.FaultErr) {
+	if body == "" {
+		return nil, faulterr.NewBadRequestError("Comment is required")
+	}
+
+	comment := models.TaskComment{
+		TaskID: obj.ID,
+		UserID: userID,
+		Body:   body,
+	}
+
+	return m.dbstore.TaskCommentStore.Insert(ctx, tx, comment)
+}
+
+func (m *TaskMaster) validate(r models.TaskRequest) *faulterr.FaultErr {
+	if r.Title == "" {
+		return faulterr.NewBadRequestError("Task Title is required")
+	}
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	return nil
+}
+
+// verifyLinks checks that assignees and linked inventory belong to the organization of the task
+func (m *TaskMaster) verifyLinks(ctx context.Context, r models.TaskRequest) *faulterr.FaultErr {
+	orgID := r.OrganizationID.Int64
+
+	if r.AssigneeUserID.Valid {
+		user, err := m.dbstore.UserStore.GetByID(ctx, r.AssigneeUserID.Int64)
+		if err != nil {
+			return err
+		}
+		if !user.IsMember || user.OrganizationID.Int64 != orgID {
+			return faulterr.NewNotFoundError("no member found with given assignee id")
+		}
+	}
+	if r.AssigneeRoleID.Valid {
+		role, err := m.dbstore.RoleStore.GetByID(ctx, r.AssigneeRoleID.Int64)
+		if err != nil {
+			return err
+		}
+		if role.OrganizationID != orgID {
+			return faulterr.NewNotFoundError("no role found with given role id")
+		}
+	}
+	if r.ContainerID.Valid {
+		container, err := m.dbstore.ContainerStore.GetByID(ctx, r.ContainerID.Int64)
+		if err != nil {
+			return err
+		}
+		if container.OrganizationID.Int64 != orgID {
+			return faulterr.NewNotFoundError("no container found with given container id")
+		}
+	}
+	if r.PalletID.Valid {
+		pallet, err := m.dbstore.PalletStore.GetByID(ctx, r.PalletID.Int64)
+		if err != nil {
+			return err
+		}
+		if pallet.OrganizationID.Int64 != orgID {
+			return faulterr.NewNotFoundError("no pallet found with given pallet id")
+		}
+	}
+	return nil
+}
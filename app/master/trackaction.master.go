@@ -0,0 +1,110 @@
+package master
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type TrackActionMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewTrackActionMaster(s *dbstore.DBStore) *TrackActionMaster {
+	return &TrackActionMaster{s}
+}
+
+// Create appends a track action to the event log of a container or a pallet
+func (m *TrackActionMaster) Create(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.TrackActionRequest,
+	actorID int64,
+) (*models.TrackAction, *faulterr.FaultErr) {
+	if err := m.validate(r); err != nil {
+		return nil, err
+	}
+
+	orgID, err := m.itemOrganizationID(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	occurredAt := time.Now().UTC()
+	if r.OccurredAt.Valid {
+		occurredAt = r.OccurredAt.Time
+	}
+	payload := r.Payload
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+
+	obj := models.TrackAction{
+		UID:            uid,
+		Action:         r.Action,
+		ContainerID:    r.ContainerID,
+		PalletID:       r.PalletID,
+		Location:       r.Location,
+		Payload:        payload,
+		ActorID:        actorID,
+		OrganizationID: orgID,
+		OccurredAt:     occurredAt,
+	}
+
+	return m.dbstore.TrackActionStore.Insert(ctx, tx, obj)
+}
+
+func (m *TrackActionMaster) validate(r models.TrackActionRequest) *faulterr.FaultErr {
+	if !isTrackActionType(r.Action) {
+		return faulterr.NewBadRequestError("Action is not a valid track action")
+	}
+	if r.ContainerID.Valid == r.PalletID.Valid {
+		return faulterr.NewBadRequestError("Either a Container ID or a Pallet ID is required")
+	}
+	if r.OccurredAt.Valid && r.OccurredAt.Time.After(time.Now()) {
+		return faulterr.NewBadRequestError("Occurred at cannot be in the future")
+	}
+	return nil
+}
+
+// itemOrganizationID gets the organization owning the traced item and checks it against the request
+func (m *TrackActionMaster) itemOrganizationID(ctx context.Context, r models.TrackActionRequest) (int64, *faulterr.FaultErr) {
+	if r.ContainerID.Valid {
+		container, err := m.dbstore.ContainerStore.GetByID(ctx, r.ContainerID.Int64)
+		if err != nil {
+			return 0, err
+		}
+		if r.OrganizationID.Valid && container.OrganizationID.Int64 != r.OrganizationID.Int64 {
+			return 0, faulterr.NewNotFoundError("no container found with given container id")
+		}
+		return container.OrganizationID.Int64, nil
+	}
+
+	pallet, err := m.dbstore.PalletStore.GetByID(ctx, r.PalletID.Int64)
+	if err != nil {
+		return 0, err
+	}
+	if r.OrganizationID.Valid && pallet.OrganizationID.Int64 != r.OrganizationID.Int64 {
+		return 0, faulterr.NewNotFoundError("no pallet found with given pallet id")
+	}
+	return pallet.OrganizationID.Int64, nil
+}
+
+func isTrackActionType(action string) bool {
+	for _, t := range models.TrackActionTypes {
+		if t == action {
+			return true
+		}
+	}
+	return false
+}
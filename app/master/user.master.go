@@ -2,6 +2,7 @@ package master
 
 import (
 	"context"
+	"net/http"
 	"orijinplus/app/models"
 	"orijinplus/app/store/dbstore"
 	"orijinplus/utils/encrypt"
@@ -123,10 +124,15 @@ func (m *UserMaster) CreateCustomer(ctx context.Context, tx pgx.Tx, r models.Cus
 
 // CreateProfile creates and saves user parofile in the db
 func (m *UserMaster) CreateProfile(ctx context.Context, tx pgx.Tx, u *models.User) (*models.Profile, *faulterr.FaultErr) {
+	referralCode, err := m.generateReferralCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	p := models.Profile{
 		UserID:       u.ID,
 		WalletPoints: 0,
-		ReferralCode: null.StringFrom(encrypt.GenerateRandomString(10)),
+		ReferralCode: null.StringFrom(referralCode),
 	}
 
 	return m.dbstore.ProfileStore.Insert(ctx, tx, p)
@@ -185,6 +191,22 @@ func (m *UserMaster) verifyUniqueFields(ctx context.Context, u models.User) *fau
 	return nil
 }
 
+// generateReferralCode returns a referral code which is not used by another profile
+func (m *UserMaster) generateReferralCode(ctx context.Context) (string, *faulterr.FaultErr) {
+	for i := 0; i < 5; i++ {
+		code := encrypt.GenerateRandomString(10)
+		_, err := m.dbstore.ProfileStore.GetByReferralCode(ctx, code)
+		if err == nil {
+			continue
+		}
+		if err.Status == http.StatusNotFound {
+			return code, nil
+		}
+		return "", err
+	}
+	return "", faulterr.NewInternalServerError("could not generate a unique referral code")
+}
+
 // Validation
 
 // verifyUniqueFields verifies the uniqueness of user
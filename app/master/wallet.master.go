@@ -0,0 +1,258 @@
+package master
+
+import (
+	"context"
+	"net/http"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// WalletMaster keeps the wallet point ledger and the cached wallet balance of the profiles in step.
+// Every change locks the profile first, so concurrent changes to the same wallet run one after another.
+type WalletMaster struct {
+	dbstore *dbstore.DBStore
+}
+
+func NewWalletMaster(s *dbstore.DBStore) *WalletMaster {
+	return &WalletMaster{s}
+}
+
+// WalletReference points a ledger entry to the record that caused it
+type WalletReference struct {
+	Type string
+	ID   int64
+}
+
+// Earn credits points to a wallet, the points expire after models.WalletPointsValidity
+func (m *WalletMaster) Earn(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int64,
+	points int64,
+	reason string,
+	ref WalletReference,
+) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	if points <= 0 {
+		return nil, faulterr.NewBadRequestError("Points must be greater than zero")
+	}
+	if _, err := m.lock(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	expiresAt := null.TimeFrom(time.Now().UTC().Add(models.WalletPointsValidity))
+	return m.credit(ctx, tx, userID, models.WalletEarn, points, reason, ref, expiresAt, null.Int64{})
+}
+
+// Redeem spends points from a wallet, the credits expiring first are used up first
+func (m *WalletMaster) Redeem(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int64,
+	points int64,
+	reason string,
+	ref WalletReference,
+) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	if points <= 0 {
+		return nil, faulterr.NewBadRequestError("Points must be greater than zero")
+	}
+	if _, err := m.lock(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	// Expired points cannot be spent
+	if _, err := m.expire(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	return m.debit(ctx, tx, userID, models.WalletRedeem, points, reason, ref, null.Int64{})
+}
+
+// Adjust corrects a wallet by hand, positive adjustments do not expire
+func (m *WalletMaster) Adjust(
+	ctx context.Context,
+	tx pgx.Tx,
+	r models.WalletAdjustmentRequest,
+	createdByID int64,
+) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Points == 0 {
+		return nil, faulterr.NewBadRequestError("Points cannot be zero")
+	}
+	if r.Reason == "" {
+		return nil, faulterr.NewBadRequestError("Reason is required")
+	}
+	if _, err := m.lock(ctx, tx, r.UserID); err != nil {
+		return nil, err
+	}
+
+	if r.Points > 0 {
+		return m.credit(ctx, tx, r.UserID, models.WalletAdjust, r.Points, r.Reason, WalletReference{}, null.Time{}, null.Int64From(createdByID))
+	}
+
+	if _, err := m.expire(ctx, tx, r.UserID); err != nil {
+		return nil, err
+	}
+	return m.debit(ctx, tx, r.UserID, models.WalletAdjust, -r.Points, r.Reason, WalletReference{}, null.Int64From(createdByID))
+}
+
+// Expire writes off the points of a wallet whose credits have expired, it returns nil when nothing expired
+func (m *WalletMaster) Expire(ctx context.Context, tx pgx.Tx, userID int64) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	if _, err := m.lock(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+	return m.expire(ctx, tx, userID)
+}
+
+// lock gets the profile of the user and holds it until the transaction ends
+func (m *WalletMaster) lock(ctx context.Context, tx pgx.Tx, userID int64) (*models.Profile, *faulterr.FaultErr) {
+	profile, err := m.dbstore.ProfileStore.LockByUserID(ctx, tx, userID)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, faulterr.NewNotFoundError("no wallet found for the user")
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (m *WalletMaster) expire(ctx context.Context, tx pgx.Tx, userID int64) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	credits, err := m.dbstore.WalletPointEntryStore.ListUnspentByUserID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expired int64
+	for _, credit := range credits {
+		if !credit.ExpiresAt.Valid || credit.ExpiresAt.Time.After(now) {
+			continue
+		}
+		expired += credit.Remaining
+		if err := m.dbstore.WalletPointEntryStore.UpdateRemaining(ctx, tx, credit.ID, 0); err != nil {
+			return nil, err
+		}
+	}
+	if expired == 0 {
+		return nil, nil
+	}
+
+	profile, err := m.dbstore.ProfileStore.SpendWalletPoints(ctx, tx, userID, expired)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.insert(ctx, tx, models.WalletPointEntry{
+		UserID:    userID,
+		EntryType: models.WalletExpire,
+		Points:    -expired,
+		Balance:   profile.WalletPoints,
+		Reason:    "Points expired",
+	})
+}
+
+func (m *WalletMaster) credit(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int64,
+	entryType string,
+	points int64,
+	reason string,
+	ref WalletReference,
+	expiresAt null.Time,
+	createdByID null.Int64,
+) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	profile, err := m.dbstore.ProfileStore.AddWalletPoints(ctx, tx, userID, points)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := models.WalletPointEntry{
+		UserID:      userID,
+		EntryType:   entryType,
+		Points:      points,
+		Balance:     profile.WalletPoints,
+		Remaining:   points,
+		Reason:      reason,
+		ExpiresAt:   expiresAt,
+		CreatedByID: createdByID,
+	}
+	setReference(&obj, ref)
+	return m.insert(ctx, tx, obj)
+}
+
+func (m *WalletMaster) debit(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID int64,
+	entryType string,
+	points int64,
+	reason string,
+	ref WalletReference,
+	createdByID null.Int64,
+) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	// The update only matches while the balance covers the points
+	profile, err := m.dbstore.ProfileStore.SpendWalletPoints(ctx, tx, userID, points)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, faulterr.NewBadRequestError("insufficient wallet points")
+		}
+		return nil, err
+	}
+
+	credits, err := m.dbstore.WalletPointEntryStore.ListUnspentByUserID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	left := points
+	for _, credit := range credits {
+		if left == 0 {
+			break
+		}
+		used := credit.Remaining
+		if used > left {
+			used = left
+		}
+		if err := m.dbstore.WalletPointEntryStore.UpdateRemaining(ctx, tx, credit.ID, credit.Remaining-used); err != nil {
+			return nil, err
+		}
+		left -= used
+	}
+	if left > 0 {
+		return nil, faulterr.NewInternalServerError("wallet balance does not match the wallet point ledger")
+	}
+
+	obj := models.WalletPointEntry{
+		UserID:      userID,
+		EntryType:   entryType,
+		Points:      -points,
+		Balance:     profile.WalletPoints,
+		Reason:      reason,
+		CreatedByID: createdByID,
+	}
+	setReference(&obj, ref)
+	return m.insert(ctx, tx, obj)
+}
+
+func (m *WalletMaster) insert(ctx context.Context, tx pgx.Tx, obj models.WalletPointEntry) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+	obj.UID = uid
+	return m.dbstore.WalletPointEntryStore.Insert(ctx, tx, obj)
+}
+
+func setReference(obj *models.WalletPointEntry, ref WalletReference) {
+	if ref.Type == "" {
+		return
+	}
+	obj.ReferenceType = null.StringFrom(ref.Type)
+	obj.ReferenceID = null.Int64From(ref.ID)
+}
@@ -0,0 +1,182 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/volatiletech/null"
+)
+
+// WarehouseMaster manages the sites of an organization and the locations inside them
+type WarehouseMaster struct {
+	dbstore *dbstore.DBStore
+	codes   *CodeMaster
+}
+
+func NewWarehouseMaster(s *dbstore.DBStore) *WarehouseMaster {
+	return &WarehouseMaster{s, NewCodeMaster(s)}
+}
+
+func (m *WarehouseMaster) Create(ctx context.Context, tx pgx.Tx, r models.WarehouseRequest, createdByID int64) (*models.Warehouse, *faulterr.FaultErr) {
+	r.Name = strings.TrimSpace(r.Name)
+	if err := m.validate(ctx, r); err != nil {
+		return nil, err
+	}
+
+	// Get the next code
+	code, err := m.codes.Next(ctx, tx, models.CodeWarehouse, null.Int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Warehouse{
+		UID:            uid,
+		Code:           code,
+		Name:           r.Name,
+		AddressID:      r.AddressID,
+		OrganizationID: r.OrganizationID.Int64,
+		CreatedByID:    createdByID,
+	}
+
+	return m.dbstore.WarehouseStore.Insert(ctx, tx, obj)
+}
+
+func (m *WarehouseMaster) Update(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Warehouse,
+	req models.WarehouseRequest,
+) (*models.Warehouse, *faulterr.FaultErr) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.OrganizationID = null.Int64From(obj.OrganizationID)
+	if err := m.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	obj.Name = req.Name
+	obj.AddressID = req.AddressID
+
+	if err := m.dbstore.WarehouseStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// CreateLocation adds a location to a warehouse, nested in a parent location of the next larger kind
+func (m *WarehouseMaster) CreateLocation(ctx context.Context, tx pgx.Tx, r models.LocationRequest) (*models.Location, *faulterr.FaultErr) {
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+
+	obj := models.Location{
+		UID:         uid,
+		Code:        strings.TrimSpace(r.Code),
+		Name:        strings.TrimSpace(r.Name),
+		Kind:        r.Kind,
+		WarehouseID: r.WarehouseID,
+		ParentID:    r.ParentID,
+	}
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	warehouse, err := m.dbstore.WarehouseStore.GetByID(ctx, obj.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if warehouse.IsArchived {
+		return nil, faulterr.NewBadRequestError("Locations cannot be added to an archived warehouse")
+	}
+
+	parentKind := ""
+	if obj.ParentID.Valid {
+		parent, err := m.dbstore.LocationStore.GetByID(ctx, obj.ParentID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		if parent.WarehouseID != obj.WarehouseID {
+			return nil, faulterr.NewNotFoundError("no location found with given parent id")
+		}
+		parentKind = parent.Kind
+	}
+	if !models.ValidLocationParent(obj.Kind, parentKind) {
+		if parentKind == "" {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("A %s must be inside a %s", obj.Kind, models.LocationParentKinds[obj.Kind]))
+		}
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("A %s cannot be inside a %s", obj.Kind, parentKind))
+	}
+
+	return m.dbstore.LocationStore.Insert(ctx, tx, obj)
+}
+
+// UpdateLocation renames a location, its place in the warehouse cannot change
+func (m *WarehouseMaster) UpdateLocation(
+	ctx context.Context,
+	tx pgx.Tx,
+	obj *models.Location,
+	req models.LocationRequest,
+) (*models.Location, *faulterr.FaultErr) {
+	// Update fields
+	obj.Code = strings.TrimSpace(req.Code)
+	obj.Name = strings.TrimSpace(req.Name)
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := m.dbstore.LocationStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// DeleteLocation removes a location which was never used and has no nested locations
+func (m *WarehouseMaster) DeleteLocation(ctx context.Context, tx pgx.Tx, obj *models.Location) *faulterr.FaultErr {
+	children, err := m.dbstore.LocationStore.ListByParentID(ctx, obj.ID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return faulterr.NewBadRequestError("Location has nested locations")
+	}
+	used, err := m.dbstore.LocationMoveStore.ExistsByLocationID(ctx, obj.ID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return faulterr.NewBadRequestError("Location has been used and cannot be deleted")
+	}
+
+	return m.dbstore.LocationStore.Delete(ctx, tx, obj.ID)
+}
+
+func (m *WarehouseMaster) validate(ctx context.Context, r models.WarehouseRequest) *faulterr.FaultErr {
+	if !r.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("Organization ID is required")
+	}
+	if r.Name == "" {
+		return faulterr.NewBadRequestError("Name is required")
+	}
+	if r.AddressID.Valid {
+		address, err := m.dbstore.AddressStore.GetByID(ctx, r.AddressID.Int64)
+		if err != nil {
+			return err
+		}
+		if !address.OrganizationID.Valid || address.OrganizationID.Int64 != r.OrganizationID.Int64 {
+			return faulterr.NewNotFoundError("no address found with given address id")
+		}
+	}
+	return nil
+}
@@ -1,5 +1,15 @@
 package models
 
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
 type SkuCategory struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
@@ -17,8 +27,9 @@ type SkuRetailLink struct {
 }
 
 type File struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	ID   null.Int64 `json:"id"`
+	Name string     `json:"name"`
+	URL  string     `json:"url"`
 }
 
 type Subtask struct {
@@ -32,3 +43,198 @@ type UserSubtask struct {
 	Status     string `json:"status"`
 	IsComplete bool   `json:"isComplete"`
 }
+
+type ReferralStats struct {
+	Referrals      int64 `json:"referrals"`
+	ReferrerPoints int64 `json:"referrerPoints"`
+	RefereePoints  int64 `json:"refereePoints"`
+}
+
+type WalletBalance struct {
+	Points         int64     `json:"points"`
+	LedgerPoints   int64     `json:"ledgerPoints"`
+	ExpiringPoints int64     `json:"expiringPoints"`
+	NextExpiresAt  null.Time `json:"nextExpiresAt"`
+}
+
+type Label struct {
+	Code        string `json:"code"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	GS1AI       string `json:"gs1AI"`
+	GS1Key      string `json:"gs1Key"`
+}
+
+// GS1Text is the human readable element string printed under GS1-128 barcodes, e.g. (00) 106141411234567897
+func (l *Label) GS1Text() string {
+	if l.GS1AI == "" {
+		return ""
+	}
+	return "(" + l.GS1AI + ") " + l.GS1Key
+}
+
+// LabelTemplate lays out labels on a sheet, sizes are in millimetres
+type LabelTemplate struct {
+	Name        string  `json:"name"`
+	PageWidth   float64 `json:"pageWidth"`
+	PageHeight  float64 `json:"pageHeight"`
+	LabelWidth  float64 `json:"labelWidth"`
+	LabelHeight float64 `json:"labelHeight"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	MarginLeft  float64 `json:"marginLeft"`
+	MarginTop   float64 `json:"marginTop"`
+	GapX        float64 `json:"gapX"`
+	GapY        float64 `json:"gapY"`
+	ShowQR      bool    `json:"showQR"`
+	ShowBarcode bool    `json:"showBarcode"`
+	ShowText    bool    `json:"showText"`
+}
+
+// Manifest lists the contents of a shipment, container by container
+type Manifest struct {
+	Shipment    Shipment            `json:"shipment"`
+	Origin      Address             `json:"origin"`
+	Destination Address             `json:"destination"`
+	Containers  []ManifestContainer `json:"containers"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+}
+
+type ManifestContainer struct {
+	Code        string           `json:"code"`
+	Description string           `json:"description"`
+	Status      string           `json:"status"`
+	Pallets     []ManifestPallet `json:"pallets"`
+}
+
+type ManifestPallet struct {
+	Code        string `json:"code"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+}
+
+// Provenance is the public view of a scanned item, what the organization keeps private is left out
+type Provenance struct {
+	Kind         string                  `json:"kind"`
+	UID          string                  `json:"uid"`
+	Code         string                  `json:"code"`
+	SSCC         string                  `json:"sscc,omitempty"`
+	GTIN         string                  `json:"gtin,omitempty"`
+	Name         string                  `json:"name,omitempty"`
+	Description  string                  `json:"description,omitempty"`
+	Status       string                  `json:"status,omitempty"`
+	Organization *ProvenanceOrganization `json:"organization,omitempty"`
+	Anchoring    string                  `json:"anchoring,omitempty"`
+	Events       []ProvenanceEvent       `json:"events"`
+}
+
+type ProvenanceOrganization struct {
+	Name    string `json:"name"`
+	Website string `json:"website,omitempty"`
+}
+
+type ProvenanceEvent struct {
+	Action       string                 `json:"action"`
+	OccurredAt   time.Time              `json:"occurredAt"`
+	Container    string                 `json:"container,omitempty"`
+	Location     string                 `json:"location,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	AnchorTxHash string                 `json:"anchorTxHash,omitempty"`
+	AnchoredAt   *time.Time             `json:"anchoredAt,omitempty"`
+}
+
+// EPCISCaptureResult sums up a captured EPCIS document, events already captured are skipped
+type EPCISCaptureResult struct {
+	Captured     int `json:"captured"`
+	Duplicates   int `json:"duplicates"`
+	TrackActions int `json:"trackActions"`
+}
+
+// Attribute is a custom attribute of a pallet or container
+type Attribute struct {
+	Key     string       `json:"key"`
+	Type    string       `json:"type"`
+	Value   string       `json:"value"`
+	Number  null.Float64 `json:"number"`
+	Boolean null.Bool    `json:"boolean"`
+}
+
+// AttributeList gets the custom attributes of a pallet or container sorted by key,
+// typed by their stored JSON value as dates and enum values are stored as strings
+func AttributeList(attrs map[string]interface{}) []Attribute {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]Attribute, 0, len(keys))
+	for _, key := range keys {
+		attr := Attribute{Key: key, Type: AttributeString}
+		switch v := attrs[key].(type) {
+		case float64:
+			attr.Type = AttributeNumber
+			attr.Value = strconv.FormatFloat(v, 'f', -1, 64)
+			attr.Number = null.Float64From(v)
+		case bool:
+			attr.Type = AttributeBoolean
+			attr.Value = strconv.FormatBool(v)
+			attr.Boolean = null.BoolFrom(v)
+		case string:
+			attr.Value = v
+		default:
+			attr.Value = fmt.Sprint(v)
+		}
+		list = append(list, attr)
+	}
+	return list
+}
+
+// AttributeFilter matches the pallets or containers having a value for a custom attribute
+type AttributeFilter struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RecallProgress counts the items of a recall and how many of them were resolved
+type RecallProgress struct {
+	Total    int                  `json:"total"`
+	Resolved int                  `json:"resolved"`
+	Percent  float64              `json:"percent"`
+	Items    []RecallItemProgress `json:"items"`
+}
+
+// RecallProgressOf counts the items of a recall and how many of them were resolved, by item type
+func RecallProgressOf(items []RecallItem) RecallProgress {
+	progress := RecallProgress{Items: make([]RecallItemProgress, len(RecallItemTypes))}
+	index := make(map[string]int, len(RecallItemTypes))
+	for i, itemType := range RecallItemTypes {
+		progress.Items[i].ItemType = itemType
+		index[itemType] = i
+	}
+
+	for _, item := range items {
+		i, ok := index[item.ItemType]
+		if !ok {
+			continue
+		}
+		progress.Items[i].Total++
+		progress.Total++
+		if item.ResolvedAt.Valid {
+			progress.Items[i].Resolved++
+			progress.Resolved++
+		}
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = math.Round(float64(progress.Resolved)*10000/float64(progress.Total)) / 100
+	}
+	return progress
+}
+
+type RecallItemProgress struct {
+	ItemType string `json:"itemType"`
+	Total    int    `json:"total"`
+	Resolved int    `json:"resolved"`
+}
@@ -1,5 +1,376 @@
 package models
 
+import (
+	"time"
+)
+
 const (
 	OrgAdmin string = "Organization Admin"
 )
+
+// BulkCreateLimit is the most pallets or containers that can be created in one request
+const BulkCreateLimit = 1000
+
+// Code entities
+const (
+	CodeOrganization   string = "organization"
+	CodeRole           string = "role"
+	CodeContainer      string = "container"
+	CodePallet         string = "pallet"
+	CodeSku            string = "sku"
+	CodeOrder          string = "order"
+	CodeContract       string = "contract"
+	CodeDistributor    string = "distributor"
+	CodeTask           string = "task"
+	CodePurchaseRecord string = "purchase_record"
+	CodeConsumerOrder  string = "consumer_order"
+	CodeShipment       string = "shipment"
+	CodeWarehouse      string = "warehouse"
+	CodeRecall         string = "recall"
+	CodeLot            string = "lot"
+)
+
+// DefaultCodePrefixes are the prefixes of the codes of entities without a custom code format
+var DefaultCodePrefixes = map[string]string{
+	CodeOrganization:   "ORG",
+	CodeRole:           "ROLE",
+	CodeContainer:      "CNT",
+	CodePallet:         "PLT",
+	CodeSku:            "SKU",
+	CodeOrder:          "ORD",
+	CodeContract:       "CON",
+	CodeDistributor:    "DST",
+	CodeTask:           "TSK",
+	CodePurchaseRecord: "PUR",
+	CodeConsumerOrder:  "COR",
+	CodeShipment:       "SHP",
+	CodeWarehouse:      "WHS",
+	CodeRecall:         "RCL",
+	CodeLot:            "LOT",
+}
+
+// CustomCodeEntities are the entities organizations can set their own code format for
+var CustomCodeEntities = []string{CodeContainer, CodePallet, CodeRole, CodeSku}
+
+// DefaultCodePadding is the number of digits codes are padded to
+const DefaultCodePadding = 5
+
+// Custom attribute types
+const (
+	AttributeString  string = "string"
+	AttributeNumber  string = "number"
+	AttributeBoolean string = "boolean"
+	AttributeDate    string = "date"
+	AttributeEnum    string = "enum"
+)
+
+// AttributeDateLayout is the layout date attributes are stored in
+const AttributeDateLayout = "2006-01-02"
+
+// AttributeEntities are the entities organizations can define custom attributes for
+var AttributeEntities = []string{CodeContainer, CodePallet}
+
+// Order statuses
+const (
+	OrderDraft     string = "draft"
+	OrderConfirmed string = "confirmed"
+	OrderPacked    string = "packed"
+	OrderShipped   string = "shipped"
+	OrderDelivered string = "delivered"
+	OrderCancelled string = "cancelled"
+)
+
+// OrderTransitions lists the statuses an order can move to from a given status
+var OrderTransitions = map[string][]string{
+	OrderDraft:     {OrderConfirmed, OrderCancelled},
+	OrderConfirmed: {OrderPacked, OrderCancelled},
+	OrderPacked:    {OrderShipped, OrderCancelled},
+	OrderShipped:   {OrderDelivered},
+	OrderDelivered: {},
+	OrderCancelled: {},
+}
+
+// Consumer order statuses
+const (
+	ConsumerOrderPending   string = "pending"
+	ConsumerOrderConfirmed string = "confirmed"
+	ConsumerOrderShipped   string = "shipped"
+	ConsumerOrderDelivered string = "delivered"
+	ConsumerOrderCancelled string = "cancelled"
+)
+
+// ConsumerOrderTransitions lists the statuses a consumer order can move to from a given status
+var ConsumerOrderTransitions = map[string][]string{
+	ConsumerOrderPending:   {ConsumerOrderConfirmed, ConsumerOrderCancelled},
+	ConsumerOrderConfirmed: {ConsumerOrderShipped, ConsumerOrderCancelled},
+	ConsumerOrderShipped:   {ConsumerOrderDelivered},
+	ConsumerOrderDelivered: {},
+	ConsumerOrderCancelled: {},
+}
+
+// Task statuses
+const (
+	TaskOpen       string = "open"
+	TaskInProgress string = "in_progress"
+	TaskBlocked    string = "blocked"
+	TaskDone       string = "done"
+	TaskCancelled  string = "cancelled"
+)
+
+// TaskTransitions lists the statuses a task can move to from a given status
+var TaskTransitions = map[string][]string{
+	TaskOpen:       {TaskInProgress, TaskCancelled},
+	TaskInProgress: {TaskOpen, TaskBlocked, TaskDone, TaskCancelled},
+	TaskBlocked:    {TaskInProgress, TaskCancelled},
+	TaskDone:       {},
+	TaskCancelled:  {},
+}
+
+// Container statuses
+const (
+	ContainerOpen      string = "open"
+	ContainerPacking   string = "packing"
+	ContainerSealed    string = "sealed"
+	ContainerInTransit string = "in_transit"
+	ContainerArrived   string = "arrived"
+	ContainerUnpacked  string = "unpacked"
+)
+
+// ContainerTransitions lists the statuses a container can move to from a given status,
+// unpacked containers are opened again to be reused
+var ContainerTransitions = map[string][]string{
+	ContainerOpen:      {ContainerPacking},
+	ContainerPacking:   {ContainerOpen, ContainerSealed},
+	ContainerSealed:    {ContainerPacking, ContainerInTransit},
+	ContainerInTransit: {ContainerArrived},
+	ContainerArrived:   {ContainerUnpacked},
+	ContainerUnpacked:  {ContainerOpen},
+}
+
+// Shipment statuses
+const (
+	ShipmentPlanned   string = "planned"
+	ShipmentDeparted  string = "departed"
+	ShipmentArrived   string = "arrived"
+	ShipmentCancelled string = "cancelled"
+)
+
+// ShipmentTransitions lists the statuses a shipment can move to from a given status
+var ShipmentTransitions = map[string][]string{
+	ShipmentPlanned:   {ShipmentDeparted, ShipmentCancelled},
+	ShipmentDeparted:  {ShipmentArrived},
+	ShipmentArrived:   {},
+	ShipmentCancelled: {},
+}
+
+// Location kinds, from the largest to the smallest
+const (
+	LocationZone  string = "zone"
+	LocationAisle string = "aisle"
+	LocationRack  string = "rack"
+	LocationBin   string = "bin"
+)
+
+// LocationParentKinds gives the kind of location each kind of location sits in,
+// zones sit directly in the warehouse
+var LocationParentKinds = map[string]string{
+	LocationZone:  "",
+	LocationAisle: LocationZone,
+	LocationRack:  LocationAisle,
+	LocationBin:   LocationRack,
+}
+
+// Blockchain anchoring statuses of a provenance timeline
+const (
+	AnchoringNone     string = "none"
+	AnchoringPending  string = "pending"
+	AnchoringPartial  string = "partial"
+	AnchoringAnchored string = "anchored"
+)
+
+// Label kinds
+const (
+	LabelPallet    string = "pallet"
+	LabelContainer string = "container"
+	LabelSku       string = "sku"
+)
+
+// LabelReadPermissions are the permissions needed to print labels of each kind
+var LabelReadPermissions = map[string]string{
+	LabelPallet:    ReadPallet,
+	LabelContainer: ReadContainer,
+	LabelSku:       ReadSKU,
+}
+
+// FileOwnerPermissions are the permissions needed to attach files to each kind of owner
+var FileOwnerPermissions = map[string]string{
+	CodeOrganization: UpdateOrganization,
+	CodeContainer:    UpdateContainer,
+	CodePallet:       UpdatePallet,
+}
+
+// FileDownloadExpiry is how long the download links of attached files are valid for
+const FileDownloadExpiry = 15 * time.Minute
+
+// Recall scopes, a recall covers everything received of a SKU, the pallets of a lot or the pallets,
+// containers and products with the given UIDs
+const (
+	RecallScopeSku  string = "sku"
+	RecallScopeLot  string = "lot"
+	RecallScopeUIDs string = "uids"
+)
+
+// Recall statuses
+const (
+	RecallOpen   string = "open"
+	RecallClosed string = "closed"
+)
+
+// RecallItemTypes are the kinds of items a recall resolves to, in the order progress is reported
+var RecallItemTypes = []string{CodeContainer, CodePallet, CodeOrder, CodeConsumerOrder, CodePurchaseRecord}
+
+// Notification kinds
+const (
+	NotificationRecall     string = "recall"
+	NotificationLotExpired string = "lot_expired"
+)
+
+// LotExpiryInterval is how often the expiry job flags the lots which expired
+const LotExpiryInterval = time.Hour
+
+// Label formats
+const (
+	LabelPNG string = "png"
+	LabelSVG string = "svg"
+	LabelPDF string = "pdf"
+	LabelCSV string = "csv"
+)
+
+// DefaultLabelTemplate is the template label sheets are printed with when none is given
+const DefaultLabelTemplate = "a4-3x7"
+
+// LabelTemplates are the built-in label sheet layouts, the A4 ones match common adhesive label sheets
+var LabelTemplates = map[string]LabelTemplate{
+	"a4-3x7": {
+		Name: "a4-3x7", PageWidth: 210, PageHeight: 297, LabelWidth: 63.5, LabelHeight: 38.1, Columns: 3, Rows: 7,
+		MarginLeft: 7.2, MarginTop: 15.15, GapX: 2.5, ShowQR: true, ShowBarcode: true, ShowText: true,
+	},
+	"a4-2x7": {
+		Name: "a4-2x7", PageWidth: 210, PageHeight: 297, LabelWidth: 99.1, LabelHeight: 38.1, Columns: 2, Rows: 7,
+		MarginLeft: 4.65, MarginTop: 15.15, GapX: 2.5, ShowQR: true, ShowBarcode: true, ShowText: true,
+	},
+	"a4-qr-4x10": {
+		Name: "a4-qr-4x10", PageWidth: 210, PageHeight: 297, LabelWidth: 45.7, LabelHeight: 25.4, Columns: 4, Rows: 10,
+		MarginLeft: 9.7, MarginTop: 21.5, GapX: 2.5, ShowQR: true, ShowText: true,
+	},
+	"thermal-100x150": {
+		Name: "thermal-100x150", PageWidth: 100, PageHeight: 150, LabelWidth: 100, LabelHeight: 150, Columns: 1, Rows: 1,
+		ShowQR: true, ShowBarcode: true, ShowText: true,
+	},
+	"thermal-100x50": {
+		Name: "thermal-100x50", PageWidth: 100, PageHeight: 50, LabelWidth: 100, LabelHeight: 50, Columns: 1, Rows: 1,
+		ShowQR: true, ShowBarcode: true, ShowText: true,
+	},
+}
+
+// GS1 application identifiers, used in GS1-128 barcodes and GS1 Digital Link urls
+const (
+	AISSCC string = "00"
+	AIGTIN string = "01"
+)
+
+// GS1DigitalLinkBase is the GS1 resolver the Digital Links of SSCCs are written with in EPCIS events
+const GS1DigitalLinkBase = "https://id.gs1.org"
+
+// SSCCCounter is the counter entity SSCC serials are handed out from, per company prefix
+const SSCCCounter = "sscc"
+
+// Manifest formats
+const (
+	ManifestPDF string = "pdf"
+	ManifestCSV string = "csv"
+)
+
+// Track action types
+const (
+	TrackPacked    string = "packed"
+	TrackLoaded    string = "loaded"
+	TrackShipped   string = "shipped"
+	TrackReceived  string = "received"
+	TrackInspected string = "inspected"
+)
+
+// TrackActionTypes lists the actions that can be recorded on a traced item
+var TrackActionTypes = []string{
+	TrackPacked,
+	TrackLoaded,
+	TrackShipped,
+	TrackReceived,
+	TrackInspected,
+}
+
+// EPCIS document formats
+const (
+	EPCISJSON string = "jsonld"
+	EPCISXML  string = "xml"
+)
+
+// EPCISCaptureLimit is the most events that can be captured in one document
+const EPCISCaptureLimit = 1000
+
+// EPCIS business steps of events which are not track actions
+const (
+	BizStepCommissioning string = "commissioning"
+	BizStepLoading       string = "loading"
+	BizStepUnloading     string = "unloading"
+)
+
+// EPCISBizSteps maps track actions to the CBV business steps they are exported as
+var EPCISBizSteps = map[string]string{
+	TrackPacked:    "packing",
+	TrackLoaded:    BizStepLoading,
+	TrackShipped:   "shipping",
+	TrackReceived:  "receiving",
+	TrackInspected: "inspecting",
+}
+
+// EPCISTrackActions maps the CBV business steps of captured events to the track actions they are recorded as,
+// events of other business steps are kept but not added to timelines
+var EPCISTrackActions = map[string]string{
+	"packing":      TrackPacked,
+	BizStepLoading: TrackLoaded,
+	"shipping":     TrackShipped,
+	"departing":    TrackShipped,
+	"receiving":    TrackReceived,
+	"arriving":     TrackReceived,
+	"accepting":    TrackReceived,
+	"inspecting":   TrackInspected,
+}
+
+// Wallet point entry types
+const (
+	WalletEarn   string = "earn"
+	WalletRedeem string = "redeem"
+	WalletExpire string = "expire"
+	WalletAdjust string = "adjust"
+)
+
+// Wallet point references
+const (
+	WalletRefPurchaseRecord string = "purchase_record"
+	WalletRefReferral       string = "referral"
+	WalletRefConsumerOrder  string = "consumer_order"
+)
+
+// WalletPointsValidity is how long earned points can be spent before they expire
+const WalletPointsValidity = 365 * 24 * time.Hour
+
+// WalletPointsExpiryNotice is how far ahead points are reported as expiring
+const WalletPointsExpiryNotice = 30 * 24 * time.Hour
+
+// Contract statuses
+const (
+	ContractPending string = "pending"
+	ContractActive  string = "active"
+	ContractExpired string = "expired"
+)
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -8,32 +11,166 @@ import (
 )
 
 type Address struct {
-	ID        int64       `json:"id"`
-	UserID    int64       `json:"userID"`
-	Tag       string      `json:"tag"`
-	Line1     string      `json:"line1"`
-	Line2     string      `json:"line2"`
-	Line3     null.String `json:"line3"`
-	City      string      `json:"city"`
-	State     string      `json:"state"`
-	Country   string      `json:"country"`
-	Pincode   string      `json:"pincode"`
-	CreatedAt time.Time   `json:"createdAt"`
-	UpdatedAt time.Time   `json:"updatedAt"`
+	ID             int64       `json:"id"`
+	UserID         null.Int64  `json:"userID"`
+	Tag            string      `json:"tag"`
+	Line1          string      `json:"line1"`
+	Line2          string      `json:"line2"`
+	Line3          null.String `json:"line3"`
+	City           string      `json:"city"`
+	State          string      `json:"state"`
+	Country        string      `json:"country"`
+	Pincode        string      `json:"pincode"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+	OrganizationID null.Int64  `json:"organizationID"`
+	IsDefault      bool        `json:"isDefault"`
+}
+
+type ConsumerOrder struct {
+	ID             int64     `json:"id"`
+	UID            uuid.UUID `json:"uid"`
+	Code           string    `json:"code"`
+	Status         string    `json:"status"`
+	CustomerID     int64     `json:"customerID"`
+	AddressID      int64     `json:"addressID"`
+	Total          int64     `json:"total"`
+	WalletPoints   int64     `json:"walletPoints"`
+	OrganizationID int64     `json:"organizationID"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type ConsumerOrderItem struct {
+	ID              int64     `json:"id"`
+	ConsumerOrderID int64     `json:"consumerOrderID"`
+	SkuID           int64     `json:"skuID"`
+	Quantity        int64     `json:"quantity"`
+	UnitPrice       int64     `json:"unitPrice"`
+	CreatedAt       time.Time `json:"createdAt"`
 }
 
 type Container struct {
+	ID             int64                  `json:"id"`
+	UID            uuid.UUID              `json:"uid"`
+	Code           string                 `json:"code"`
+	Description    string                 `json:"description"`
+	IsArchived     bool                   `json:"isArchived"`
+	OrganizationID null.Int64             `json:"organizationID"`
+	CreatedByID    int64                  `json:"createdByID"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
+	Status         string                 `json:"status"`
+	LocationID     null.Int64             `json:"locationID"`
+	SSCC           null.String            `json:"sscc"`
+	Attributes     map[string]interface{} `json:"attributes"`
+}
+
+// CanLoad reports whether pallets can be moved into the container
+func (c *Container) CanLoad() bool {
+	return !c.IsArchived && (c.Status == ContainerOpen || c.Status == ContainerPacking)
+}
+
+// CanUnload reports whether pallets can be taken out of the container
+func (c *Container) CanUnload() bool {
+	return c.Status == ContainerOpen || c.Status == ContainerPacking || c.Status == ContainerArrived
+}
+
+type Contract struct {
+	ID                   int64      `json:"id"`
+	UID                  uuid.UUID  `json:"uid"`
+	Code                 string     `json:"code"`
+	Title                string     `json:"title"`
+	Description          string     `json:"description"`
+	BuyerOrganizationID  int64      `json:"buyerOrganizationID"`
+	StartDate            time.Time  `json:"startDate"`
+	EndDate              time.Time  `json:"endDate"`
+	PricingTerms         string     `json:"pricingTerms"`
+	VolumeTerms          string     `json:"volumeTerms"`
+	SupplierApprovedByID null.Int64 `json:"supplierApprovedByID"`
+	SupplierApprovedAt   null.Time  `json:"supplierApprovedAt"`
+	BuyerApprovedByID    null.Int64 `json:"buyerApprovedByID"`
+	BuyerApprovedAt      null.Time  `json:"buyerApprovedAt"`
+	IsArchived           bool       `json:"isArchived"`
+	OrganizationID       int64      `json:"organizationID"`
+	CreatedByID          int64      `json:"createdByID"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	UpdatedAt            time.Time  `json:"updatedAt"`
+}
+
+// StatusAt derives the status of a contract from its approvals and date range
+func (c *Contract) StatusAt(now time.Time) string {
+	if !c.SupplierApprovedAt.Valid || !c.BuyerApprovedAt.Valid {
+		return ContractPending
+	}
+	if now.After(c.EndDate) {
+		return ContractExpired
+	}
+	if now.Before(c.StartDate) {
+		return ContractPending
+	}
+	return ContractActive
+}
+
+type ContractDocument struct {
+	ID           int64     `json:"id"`
+	ContractID   int64     `json:"contractID"`
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	UploadedByID int64     `json:"uploadedByID"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type Distributor struct {
 	ID             int64      `json:"id"`
 	UID            uuid.UUID  `json:"uid"`
 	Code           string     `json:"code"`
-	Description    string     `json:"description"`
+	Name           string     `json:"name"`
+	ContactName    string     `json:"contactName"`
+	Email          string     `json:"email"`
+	Phone          string     `json:"phone"`
+	Regions        []string   `json:"regions"`
+	AddressID      null.Int64 `json:"addressID"`
 	IsArchived     bool       `json:"isArchived"`
-	OrganizationID null.Int64 `json:"organizationID"`
+	OrganizationID int64      `json:"organizationID"`
 	CreatedByID    int64      `json:"createdByID"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	UpdatedAt      time.Time  `json:"updatedAt"`
 }
 
+type Order struct {
+	ID                  int64      `json:"id"`
+	UID                 uuid.UUID  `json:"uid"`
+	Code                string     `json:"code"`
+	Status              string     `json:"status"`
+	Notes               string     `json:"notes"`
+	BuyerOrganizationID int64      `json:"buyerOrganizationID"`
+	IsArchived          bool       `json:"isArchived"`
+	OrganizationID      int64      `json:"organizationID"`
+	CreatedByID         int64      `json:"createdByID"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+	ContractID          null.Int64 `json:"contractID"`
+	DistributorID       null.Int64 `json:"distributorID"`
+}
+
+type OrderAllocation struct {
+	ID            int64     `json:"id"`
+	OrderID       int64     `json:"orderID"`
+	PalletID      int64     `json:"palletID"`
+	AllocatedByID int64     `json:"allocatedByID"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type OrderItem struct {
+	ID        int64     `json:"id"`
+	OrderID   int64     `json:"orderID"`
+	SkuID     int64     `json:"skuID"`
+	Quantity  int64     `json:"quantity"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 type Organization struct {
 	ID         int64       `json:"id"`
 	Code       string      `json:"code"`
@@ -45,16 +182,20 @@ type Organization struct {
 }
 
 type Pallet struct {
-	ID             int64      `json:"id"`
-	UID            uuid.UUID  `json:"uid"`
-	Code           string     `json:"code"`
-	Description    string     `json:"description"`
-	ContainerID    null.Int64 `json:"containerID"`
-	IsArchived     bool       `json:"isArchived"`
-	OrganizationID null.Int64 `json:"organizationID"`
-	CreatedByID    int64      `json:"createdByID"`
-	CreatedAt      time.Time  `json:"createdAt"`
-	UpdatedAt      time.Time  `json:"updatedAt"`
+	ID             int64                  `json:"id"`
+	UID            uuid.UUID              `json:"uid"`
+	Code           string                 `json:"code"`
+	Description    string                 `json:"description"`
+	ContainerID    null.Int64             `json:"containerID"`
+	IsArchived     bool                   `json:"isArchived"`
+	OrganizationID null.Int64             `json:"organizationID"`
+	CreatedByID    int64                  `json:"createdByID"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
+	LocationID     null.Int64             `json:"locationID"`
+	SSCC           null.String            `json:"sscc"`
+	Attributes     map[string]interface{} `json:"attributes"`
+	LotID          null.Int64             `json:"lotID"`
 }
 
 type Permission struct {
@@ -73,6 +214,61 @@ type Profile struct {
 	UpdatedAt    time.Time   `json:"updatedAt"`
 }
 
+type PurchaseRecord struct {
+	ID             int64       `json:"id"`
+	UID            uuid.UUID   `json:"uid"`
+	Code           string      `json:"code"`
+	ProductUID     uuid.UUID   `json:"productUID"`
+	BuyerEmail     null.String `json:"buyerEmail"`
+	BuyerPhone     null.String `json:"buyerPhone"`
+	PurchaseToken  string      `json:"purchaseToken"`
+	Retailer       string      `json:"retailer"`
+	Points         int64       `json:"points"`
+	RedeemedByID   null.Int64  `json:"redeemedByID"`
+	RedeemedAt     null.Time   `json:"redeemedAt"`
+	OrganizationID int64       `json:"organizationID"`
+	CreatedByID    int64       `json:"createdByID"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+type Referral struct {
+	ID             int64      `json:"id"`
+	ReferrerID     int64      `json:"referrerID"`
+	RefereeID      int64      `json:"refereeID"`
+	ReferralCode   string     `json:"referralCode"`
+	ReferralRuleID null.Int64 `json:"referralRuleID"`
+	ReferrerPoints int64      `json:"referrerPoints"`
+	RefereePoints  int64      `json:"refereePoints"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type ReferralRule struct {
+	ID             int64     `json:"id"`
+	ReferrerPoints int64     `json:"referrerPoints"`
+	RefereePoints  int64     `json:"refereePoints"`
+	IsActive       bool      `json:"isActive"`
+	CreatedByID    int64     `json:"createdByID"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type WalletPointEntry struct {
+	ID            int64       `json:"id"`
+	UID           uuid.UUID   `json:"uid"`
+	UserID        int64       `json:"userID"`
+	EntryType     string      `json:"entryType"`
+	Points        int64       `json:"points"`
+	Balance       int64       `json:"balance"`
+	Remaining     int64       `json:"remaining"`
+	Reason        string      `json:"reason"`
+	ReferenceType null.String `json:"referenceType"`
+	ReferenceID   null.Int64  `json:"referenceID"`
+	ExpiresAt     null.Time   `json:"expiresAt"`
+	CreatedByID   null.Int64  `json:"createdByID"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
+
 type Role struct {
 	ID             int64     `json:"id"`
 	Code           string    `json:"code"`
@@ -85,6 +281,64 @@ type Role struct {
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
+type Sku struct {
+	ID             int64       `json:"id"`
+	UID            uuid.UUID   `json:"uid"`
+	Code           string      `json:"code"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	IsArchived     bool        `json:"isArchived"`
+	OrganizationID int64       `json:"organizationID"`
+	CreatedByID    int64       `json:"createdByID"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+	Price          int64       `json:"price"`
+	GTIN           null.String `json:"gtin"`
+}
+
+type Task struct {
+	ID             int64      `json:"id"`
+	UID            uuid.UUID  `json:"uid"`
+	Code           string     `json:"code"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	DueDate        null.Time  `json:"dueDate"`
+	AssigneeUserID null.Int64 `json:"assigneeUserID"`
+	AssigneeRoleID null.Int64 `json:"assigneeRoleID"`
+	ContainerID    null.Int64 `json:"containerID"`
+	PalletID       null.Int64 `json:"palletID"`
+	CompletedAt    null.Time  `json:"completedAt"`
+	OrganizationID int64      `json:"organizationID"`
+	CreatedByID    int64      `json:"createdByID"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type TaskComment struct {
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"taskID"`
+	UserID    int64     `json:"userID"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type TrackAction struct {
+	ID             int64                  `json:"id"`
+	UID            uuid.UUID              `json:"uid"`
+	Action         string                 `json:"action"`
+	ContainerID    null.Int64             `json:"containerID"`
+	PalletID       null.Int64             `json:"palletID"`
+	Location       string                 `json:"location"`
+	Payload        map[string]interface{} `json:"payload"`
+	ActorID        int64                  `json:"actorID"`
+	OrganizationID int64                  `json:"organizationID"`
+	OccurredAt     time.Time              `json:"occurredAt"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	AnchorTxHash   null.String            `json:"anchorTxHash"`
+	AnchoredAt     null.Time              `json:"anchoredAt"`
+}
+
 type User struct {
 	ID             int64      `json:"id"`
 	FirstName      string     `json:"firstName"`
@@ -100,3 +354,315 @@ type User struct {
 	CreatedAt      time.Time  `json:"createdAt"`
 	UpdatedAt      time.Time  `json:"updatedAt"`
 }
+
+type CodeFormat struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organizationID"`
+	Entity         string    `json:"entity"`
+	Prefix         string    `json:"prefix"`
+	Padding        int       `json:"padding"`
+	ResetYearly    bool      `json:"resetYearly"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// DefaultCode writes the code of an entity without a custom code format, e.g. PLT00042
+func DefaultCode(entity string, n int64) string {
+	return fmt.Sprintf("%s%0*d", DefaultCodePrefixes[entity], DefaultCodePadding, n)
+}
+
+// Period gets the counter period of the code format, the year when it resets yearly and 0 otherwise
+func (f *CodeFormat) Period(now time.Time) int {
+	if f.ResetYearly {
+		return now.Year()
+	}
+	return 0
+}
+
+// Format writes a code of the format, e.g. ACME-00042 or ACME-2024-00042 when it resets yearly.
+// The dash keeps custom codes apart from default codes and from the codes of other prefixes
+func (f *CodeFormat) Format(n int64, period int) string {
+	if period > 0 {
+		return fmt.Sprintf("%s-%d-%0*d", f.Prefix, period, f.Padding, n)
+	}
+	return fmt.Sprintf("%s-%0*d", f.Prefix, f.Padding, n)
+}
+
+type AttributeDefinition struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organizationID"`
+	Entity         string    `json:"entity"`
+	Key            string    `json:"key"`
+	Label          string    `json:"label"`
+	Type           string    `json:"type"`
+	Required       bool      `json:"required"`
+	EnumValues     []string  `json:"enumValues"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type GS1Settings struct {
+	OrganizationID int64     `json:"organizationID"`
+	CompanyPrefix  string    `json:"companyPrefix"`
+	ExtensionDigit int       `json:"extensionDigit"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// SSCCPrefix is the extension digit and company prefix leading every SSCC of the organization
+func (g *GS1Settings) SSCCPrefix() string {
+	return fmt.Sprintf("%d%s", g.ExtensionDigit, g.CompanyPrefix)
+}
+
+// SSCC writes the SSCC-18 of a serial, the extension digit, company prefix and zero padded serial
+// followed by the check digit. It fails when the serial does not fit the digits left by the prefix
+func (g *GS1Settings) SSCC(serial int64) (string, bool) {
+	prefix := g.SSCCPrefix()
+	digits := 17 - len(prefix)
+	reference := fmt.Sprintf("%0*d", digits, serial)
+	if serial < 0 || len(reference) > digits {
+		return "", false
+	}
+	body := prefix + reference
+	return fmt.Sprintf("%s%d", body, GS1CheckDigit(body)), true
+}
+
+// EPC identifies a pallet or container in EPCIS events, by the GS1 Digital Link of its SSCC when it has one
+func EPC(uid uuid.UUID, sscc null.String) string {
+	if sscc.Valid {
+		return GS1DigitalLinkBase + "/" + AISSCC + "/" + sscc.String
+	}
+	return "urn:uuid:" + uid.String()
+}
+
+// ParseEPC reads the SSCC or the uid identifying a pallet or container in EPCIS events, from an
+// SSCC EPC URN such as urn:epc:id:sscc:0614141.1234567890, a GS1 Digital Link or a uuid URN
+func ParseEPC(epc string) (string, uuid.UUID, bool) {
+	epc = strings.TrimSpace(epc)
+	switch {
+	case strings.HasPrefix(epc, "urn:uuid:"):
+		uid, err := uuid.FromString(strings.TrimPrefix(epc, "urn:uuid:"))
+		return "", uid, err == nil
+	case strings.HasPrefix(epc, "urn:epc:id:sscc:"):
+		// The extension digit leads the serial reference, the check digit is left out
+		parts := strings.Split(strings.TrimPrefix(epc, "urn:epc:id:sscc:"), ".")
+		if len(parts) != 2 || len(parts[1]) == 0 || len(parts[0])+len(parts[1]) != 17 {
+			return "", uuid.Nil, false
+		}
+		body := parts[1][:1] + parts[0] + parts[1][1:]
+		sscc := fmt.Sprintf("%s%d", body, GS1CheckDigit(body))
+		return sscc, uuid.Nil, ValidSSCC(sscc)
+	}
+
+	u, err := url.Parse(epc)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", uuid.Nil, false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == AISSCC && ValidSSCC(segments[i+1]) {
+			return segments[i+1], uuid.Nil, true
+		}
+	}
+	return "", uuid.Nil, false
+}
+
+type ProvenanceSettings struct {
+	OrganizationID   int64     `json:"organizationID"`
+	IsPublic         bool      `json:"isPublic"`
+	ShowOrganization bool      `json:"showOrganization"`
+	ShowDescription  bool      `json:"showDescription"`
+	ShowLocations    bool      `json:"showLocations"`
+	ShowEventDetails bool      `json:"showEventDetails"`
+	ShowAnchoring    bool      `json:"showAnchoring"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// DefaultProvenanceSettings are used by organizations which have not set what they make public,
+// their items stay private until they opt in
+func DefaultProvenanceSettings(orgID int64) ProvenanceSettings {
+	return ProvenanceSettings{
+		OrganizationID:   orgID,
+		IsPublic:         false,
+		ShowOrganization: true,
+		ShowDescription:  true,
+		ShowAnchoring:    true,
+	}
+}
+
+type PalletAssignment struct {
+	ID           int64      `json:"id"`
+	PalletID     int64      `json:"palletID"`
+	ContainerID  int64      `json:"containerID"`
+	LoadedByID   int64      `json:"loadedByID"`
+	LoadedAt     time.Time  `json:"loadedAt"`
+	UnloadedByID null.Int64 `json:"unloadedByID"`
+	UnloadedAt   null.Time  `json:"unloadedAt"`
+}
+
+type ContainerTransition struct {
+	ID          int64     `json:"id"`
+	ContainerID int64     `json:"containerID"`
+	FromStatus  string    `json:"fromStatus"`
+	ToStatus    string    `json:"toStatus"`
+	ActorID     int64     `json:"actorID"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+type Shipment struct {
+	ID                   int64     `json:"id"`
+	UID                  uuid.UUID `json:"uid"`
+	Code                 string    `json:"code"`
+	Status               string    `json:"status"`
+	OriginAddressID      int64     `json:"originAddressID"`
+	DestinationAddressID int64     `json:"destinationAddressID"`
+	Carrier              string    `json:"carrier"`
+	TransportReference   string    `json:"transportReference"`
+	ETD                  null.Time `json:"etd"`
+	ETA                  null.Time `json:"eta"`
+	DepartedAt           null.Time `json:"departedAt"`
+	ArrivedAt            null.Time `json:"arrivedAt"`
+	Notes                string    `json:"notes"`
+	OrganizationID       int64     `json:"organizationID"`
+	CreatedByID          int64     `json:"createdByID"`
+	CreatedAt            time.Time `json:"createdAt"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+}
+
+type ShipmentContainer struct {
+	ID          int64     `json:"id"`
+	ShipmentID  int64     `json:"shipmentID"`
+	ContainerID int64     `json:"containerID"`
+	AddedByID   int64     `json:"addedByID"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Warehouse struct {
+	ID             int64      `json:"id"`
+	UID            uuid.UUID  `json:"uid"`
+	Code           string     `json:"code"`
+	Name           string     `json:"name"`
+	AddressID      null.Int64 `json:"addressID"`
+	IsArchived     bool       `json:"isArchived"`
+	OrganizationID int64      `json:"organizationID"`
+	CreatedByID    int64      `json:"createdByID"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type Location struct {
+	ID          int64      `json:"id"`
+	UID         uuid.UUID  `json:"uid"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind"`
+	WarehouseID int64      `json:"warehouseID"`
+	ParentID    null.Int64 `json:"parentID"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type LocationMove struct {
+	ID             int64      `json:"id"`
+	PalletID       null.Int64 `json:"palletID"`
+	ContainerID    null.Int64 `json:"containerID"`
+	FromLocationID null.Int64 `json:"fromLocationID"`
+	ToLocationID   null.Int64 `json:"toLocationID"`
+	ActorID        int64      `json:"actorID"`
+	MovedAt        time.Time  `json:"movedAt"`
+}
+
+type EPCISEvent struct {
+	ID             int64                  `json:"id"`
+	EventID        string                 `json:"eventID"`
+	EventType      string                 `json:"eventType"`
+	EventTime      time.Time              `json:"eventTime"`
+	Document       map[string]interface{} `json:"document"`
+	OrganizationID null.Int64             `json:"organizationID"`
+	CapturedByID   int64                  `json:"capturedByID"`
+	CreatedAt      time.Time              `json:"createdAt"`
+}
+
+type StoredFile struct {
+	ID             int64       `json:"id"`
+	Name           string      `json:"name"`
+	OriginalName   string      `json:"originalName"`
+	URL            string      `json:"url"`
+	MimeType       string      `json:"mimeType"`
+	Size           int64       `json:"size"`
+	Checksum       string      `json:"checksum"`
+	OwnerType      null.String `json:"ownerType"`
+	OwnerID        null.Int64  `json:"ownerID"`
+	OrganizationID null.Int64  `json:"organizationID"`
+	UploadedByID   int64       `json:"uploadedByID"`
+	AttachedAt     null.Time   `json:"attachedAt"`
+	CreatedAt      time.Time   `json:"createdAt"`
+}
+
+type Recall struct {
+	ID             int64      `json:"id"`
+	UID            uuid.UUID  `json:"uid"`
+	Code           string     `json:"code"`
+	Title          string     `json:"title"`
+	Reason         string     `json:"reason"`
+	Scope          string     `json:"scope"`
+	SkuID          null.Int64 `json:"skuID"`
+	UIDs           []string   `json:"uids"`
+	Status         string     `json:"status"`
+	ClosedAt       null.Time  `json:"closedAt"`
+	OrganizationID int64      `json:"organizationID"`
+	CreatedByID    int64      `json:"createdByID"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	LotID          null.Int64 `json:"lotID"`
+}
+
+type RecallItem struct {
+	ID             int64      `json:"id"`
+	RecallID       int64      `json:"recallID"`
+	ItemType       string     `json:"itemType"`
+	ItemID         int64      `json:"itemID"`
+	OrganizationID null.Int64 `json:"organizationID"`
+	CustomerID     null.Int64 `json:"customerID"`
+	ResolvedByID   null.Int64 `json:"resolvedByID"`
+	ResolvedAt     null.Time  `json:"resolvedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type Notification struct {
+	ID             int64       `json:"id"`
+	OrganizationID null.Int64  `json:"organizationID"`
+	UserID         null.Int64  `json:"userID"`
+	Kind           string      `json:"kind"`
+	Subject        string      `json:"subject"`
+	Body           string      `json:"body"`
+	EntityType     null.String `json:"entityType"`
+	EntityID       null.Int64  `json:"entityID"`
+	ReadAt         null.Time   `json:"readAt"`
+	CreatedAt      time.Time   `json:"createdAt"`
+}
+
+type Lot struct {
+	ID             int64     `json:"id"`
+	UID            uuid.UUID `json:"uid"`
+	Code           string    `json:"code"`
+	SkuID          int64     `json:"skuID"`
+	ProductionDate time.Time `json:"productionDate"`
+	ExpiryDate     null.Time `json:"expiryDate"`
+	IsExpired      bool      `json:"isExpired"`
+	ExpiredAt      null.Time `json:"expiredAt"`
+	OrganizationID int64     `json:"organizationID"`
+	CreatedByID    int64     `json:"createdByID"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// HasExpired reports whether the lot is past its expiry date, lots without one never expire
+func (l *Lot) HasExpired(now time.Time) bool {
+	return l.ExpiryDate.Valid && !now.Before(l.ExpiryDate.Time)
+}
+
+// ExpiresWithin reports whether the lot has not expired yet but will within the given number of days
+func (l *Lot) ExpiresWithin(now time.Time, days int) bool {
+	return l.ExpiryDate.Valid && !l.HasExpired(now) && !l.ExpiryDate.Time.After(now.AddDate(0, 0, days))
+}
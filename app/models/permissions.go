@@ -68,6 +68,22 @@ const (
 	ReadTrackAction      string = "Read Track Action"
 	UpdateTrackAction    string = "Update Track Action"
 	DeleteTrackAction    string = "Delete Track Action"
+	CreateShipment       string = "Create Shipment"
+	ReadShipment         string = "Read Shipment"
+	UpdateShipment       string = "Update Shipment"
+	DeleteShipment       string = "Delete Shipment"
+	CreateWarehouse      string = "Create Warehouse"
+	ReadWarehouse        string = "Read Warehouse"
+	UpdateWarehouse      string = "Update Warehouse"
+	DeleteWarehouse      string = "Delete Warehouse"
+	CreateRecall         string = "Create Recall"
+	ReadRecall           string = "Read Recall"
+	UpdateRecall         string = "Update Recall"
+	DeleteRecall         string = "Delete Recall"
+	CreateLot            string = "Create Lot"
+	ReadLot              string = "Read Lot"
+	UpdateLot            string = "Update Lot"
+	DeleteLot            string = "Delete Lot"
 )
 
 func ListPermissions() []string {
@@ -139,5 +155,21 @@ func ListPermissions() []string {
 		ReadTrackAction,
 		UpdateTrackAction,
 		DeleteTrackAction,
+		CreateShipment,
+		ReadShipment,
+		UpdateShipment,
+		DeleteShipment,
+		CreateWarehouse,
+		ReadWarehouse,
+		UpdateWarehouse,
+		DeleteWarehouse,
+		CreateRecall,
+		ReadRecall,
+		UpdateRecall,
+		DeleteRecall,
+		CreateLot,
+		ReadLot,
+		UpdateLot,
+		DeleteLot,
 	}
 }
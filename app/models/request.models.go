@@ -1,6 +1,9 @@
 package models
 
 import (
+	"time"
+
+	"github.com/gofrs/uuid"
 	"github.com/volatiletech/null"
 )
 
@@ -83,15 +86,155 @@ type UpdatePasswordRequest struct {
 }
 
 type ContainerRequest struct {
-	Description    string     `json:"description"`
-	IsArchived     bool       `json:"isArchived"`
-	OrganizationID null.Int64 `json:"organizationID"`
+	Description    string                 `json:"description"`
+	IsArchived     bool                   `json:"isArchived"`
+	OrganizationID null.Int64             `json:"organizationID"`
+	Attributes     map[string]interface{} `json:"attributes"`
 }
 
 type PalletRequest struct {
-	Code           string     `json:"code"`
+	Code           string                 `json:"code"`
+	Description    string                 `json:"description"`
+	ContainerID    null.Int64             `json:"containerID"`
+	IsArchived     bool                   `json:"isArchived"`
+	OrganizationID null.Int64             `json:"organizationID"`
+	Attributes     map[string]interface{} `json:"attributes"`
+}
+
+type SkuRequest struct {
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	Price          int64       `json:"price"`
+	GTIN           null.String `json:"gtin"`
+	OrganizationID null.Int64  `json:"organizationID"`
+}
+
+type OrderRequest struct {
+	Notes               string     `json:"notes"`
+	BuyerOrganizationID int64      `json:"buyerOrganizationID"`
+	ContractID          int64      `json:"contractID"`
+	DistributorID       null.Int64 `json:"distributorID"`
+	OrganizationID      null.Int64 `json:"organizationID"`
+}
+
+type OrderItemRequest struct {
+	OrderID  int64 `json:"orderID"`
+	SkuID    int64 `json:"skuID"`
+	Quantity int64 `json:"quantity"`
+}
+
+type ConsumerOrderRequest struct {
+	AddressID    int64                      `json:"addressID"`
+	Items        []ConsumerOrderItemRequest `json:"items"`
+	WalletPoints int64                      `json:"walletPoints"`
+}
+
+type ConsumerOrderItemRequest struct {
+	SkuID    int64 `json:"skuID"`
+	Quantity int64 `json:"quantity"`
+}
+
+type ContractRequest struct {
+	Title               string     `json:"title"`
+	Description         string     `json:"description"`
+	BuyerOrganizationID int64      `json:"buyerOrganizationID"`
+	StartDate           time.Time  `json:"startDate"`
+	EndDate             time.Time  `json:"endDate"`
+	PricingTerms        string     `json:"pricingTerms"`
+	VolumeTerms         string     `json:"volumeTerms"`
+	OrganizationID      null.Int64 `json:"organizationID"`
+}
+
+type DistributorRequest struct {
+	Name           string     `json:"name"`
+	ContactName    string     `json:"contactName"`
+	Email          string     `json:"email"`
+	Phone          string     `json:"phone"`
+	Regions        []string   `json:"regions"`
+	Address        *Address   `json:"address"`
+	OrganizationID null.Int64 `json:"organizationID"`
+}
+
+type TaskRequest struct {
+	Title          string     `json:"title"`
 	Description    string     `json:"description"`
+	DueDate        null.Time  `json:"dueDate"`
+	AssigneeUserID null.Int64 `json:"assigneeUserID"`
+	AssigneeRoleID null.Int64 `json:"assigneeRoleID"`
 	ContainerID    null.Int64 `json:"containerID"`
-	IsArchived     bool       `json:"isArchived"`
+	PalletID       null.Int64 `json:"palletID"`
 	OrganizationID null.Int64 `json:"organizationID"`
 }
+
+type PurchaseRecordRequest struct {
+	ProductUID     uuid.UUID   `json:"productUID"`
+	BuyerEmail     null.String `json:"buyerEmail"`
+	BuyerPhone     null.String `json:"buyerPhone"`
+	Retailer       string      `json:"retailer"`
+	Points         int64       `json:"points"`
+	OrganizationID null.Int64  `json:"organizationID"`
+}
+
+type TrackActionRequest struct {
+	Action         string                 `json:"action"`
+	ContainerID    null.Int64             `json:"containerID"`
+	PalletID       null.Int64             `json:"palletID"`
+	Location       string                 `json:"location"`
+	Payload        map[string]interface{} `json:"payload"`
+	OccurredAt     null.Time              `json:"occurredAt"`
+	OrganizationID null.Int64             `json:"organizationID"`
+}
+
+type ReferralRuleRequest struct {
+	ReferrerPoints int64 `json:"referrerPoints"`
+	RefereePoints  int64 `json:"refereePoints"`
+	IsActive       bool  `json:"isActive"`
+}
+
+type WalletAdjustmentRequest struct {
+	UserID int64  `json:"userID"`
+	Points int64  `json:"points"`
+	Reason string `json:"reason"`
+}
+
+type ShipmentRequest struct {
+	OriginAddressID      int64      `json:"originAddressID"`
+	DestinationAddressID int64      `json:"destinationAddressID"`
+	Carrier              string     `json:"carrier"`
+	TransportReference   string     `json:"transportReference"`
+	ETD                  null.Time  `json:"etd"`
+	ETA                  null.Time  `json:"eta"`
+	Notes                string     `json:"notes"`
+	OrganizationID       null.Int64 `json:"organizationID"`
+}
+
+type WarehouseRequest struct {
+	Name           string     `json:"name"`
+	AddressID      null.Int64 `json:"addressID"`
+	OrganizationID null.Int64 `json:"organizationID"`
+}
+
+type LocationRequest struct {
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind"`
+	WarehouseID int64      `json:"warehouseID"`
+	ParentID    null.Int64 `json:"parentID"`
+}
+
+type RecallRequest struct {
+	Title          string     `json:"title"`
+	Reason         string     `json:"reason"`
+	Scope          string     `json:"scope"`
+	SkuID          null.Int64 `json:"skuID"`
+	LotID          null.Int64 `json:"lotID"`
+	UIDs           []string   `json:"uids"`
+	OrganizationID null.Int64 `json:"organizationID"`
+}
+
+type LotRequest struct {
+	Code           string    `json:"code"`
+	SkuID          int64     `json:"skuID"`
+	ProductionDate time.Time `json:"productionDate"`
+	ExpiryDate     null.Time `json:"expiryDate"`
+}
@@ -1,6 +1,15 @@
 package models
 
-import "orijinplus/utils/faulterr"
+import (
+	"fmt"
+	"math"
+	"orijinplus/utils/faulterr"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Validate Address
 func (r *Address) Validate() *faulterr.FaultErr {
@@ -25,5 +34,367 @@ func (r *Address) Validate() *faulterr.FaultErr {
 	if r.Pincode == "" {
 		return faulterr.NewBadRequestError("Pincode is required")
 	}
+	if !ValidPostcode(r.Country, r.Pincode) {
+		return faulterr.NewBadRequestError("Pincode is not valid for " + r.Country)
+	}
+	return nil
+}
+
+// postcodeFormats holds the postcode format of the countries keyed by their ISO 3166 alpha-2 code
+var postcodeFormats = map[string]*regexp.Regexp{
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"CN": regexp.MustCompile(`^\d{6}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"IN": regexp.MustCompile(`^[1-9]\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"MY": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"NZ": regexp.MustCompile(`^\d{4}$`),
+	"SG": regexp.MustCompile(`^\d{6}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+}
+
+// countryCodes maps the country names accepted in addresses to their ISO 3166 alpha-2 code
+var countryCodes = map[string]string{
+	"AUSTRALIA":                "AU",
+	"CANADA":                   "CA",
+	"CHINA":                    "CN",
+	"GERMANY":                  "DE",
+	"FRANCE":                   "FR",
+	"UNITED KINGDOM":           "GB",
+	"UK":                       "GB",
+	"INDIA":                    "IN",
+	"JAPAN":                    "JP",
+	"MALAYSIA":                 "MY",
+	"NETHERLANDS":              "NL",
+	"NEW ZEALAND":              "NZ",
+	"SINGAPORE":                "SG",
+	"UNITED STATES":            "US",
+	"UNITED STATES OF AMERICA": "US",
+	"USA":                      "US",
+}
+
+// genericPostcode is used for the countries without a known postcode format
+var genericPostcode = regexp.MustCompile(`^[A-Z\d][A-Z\d -]{1,8}[A-Z\d]$`)
+
+// ValidPostcode checks a postcode against the format of the country, given by name or ISO code
+func ValidPostcode(country string, postcode string) bool {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if code, ok := countryCodes[country]; ok {
+		country = code
+	}
+	postcode = strings.ToUpper(strings.TrimSpace(postcode))
+
+	if format, ok := postcodeFormats[country]; ok {
+		return format.MatchString(postcode)
+	}
+	return genericPostcode.MatchString(postcode)
+}
+
+var codePrefixFormat = regexp.MustCompile(`^[A-Z0-9]{1,10}$`)
+
+// Validate CodeFormat
+func (r *CodeFormat) Validate() *faulterr.FaultErr {
+	custom := false
+	for _, entity := range CustomCodeEntities {
+		if r.Entity == entity {
+			custom = true
+		}
+	}
+	if !custom {
+		return faulterr.NewBadRequestError("Code format cannot be set for " + r.Entity)
+	}
+	if !codePrefixFormat.MatchString(r.Prefix) {
+		return faulterr.NewBadRequestError("Prefix must be 1 to 10 uppercase letters or digits")
+	}
+	if r.Padding < 1 || r.Padding > 12 {
+		return faulterr.NewBadRequestError("Padding must be between 1 and 12")
+	}
+	return nil
+}
+
+// Validate Location
+func (r *Location) Validate() *faulterr.FaultErr {
+	if r.Code == "" {
+		return faulterr.NewBadRequestError("Code is required")
+	}
+	if _, ok := LocationParentKinds[r.Kind]; !ok {
+		return faulterr.NewBadRequestError("Kind must be one of zone, aisle, rack or bin")
+	}
+	return nil
+}
+
+// ValidLocationParent reports whether a location of a kind can sit in a location of the parent kind,
+// an empty parent kind is the warehouse itself
+func ValidLocationParent(kind string, parentKind string) bool {
+	expected, ok := LocationParentKinds[kind]
+	return ok && expected == parentKind
+}
+
+// Validate LabelTemplate
+func (r *LabelTemplate) Validate() *faulterr.FaultErr {
+	if r.PageWidth <= 0 || r.PageHeight <= 0 {
+		return faulterr.NewBadRequestError("Page size must be greater than zero")
+	}
+	if r.LabelWidth < 20 || r.LabelHeight < 10 {
+		return faulterr.NewBadRequestError("Labels must be at least 20mm wide and 10mm high")
+	}
+	if r.Columns < 1 || r.Rows < 1 {
+		return faulterr.NewBadRequestError("Columns and rows must be at least 1")
+	}
+	if r.MarginLeft < 0 || r.MarginTop < 0 || r.GapX < 0 || r.GapY < 0 {
+		return faulterr.NewBadRequestError("Margins and gaps cannot be negative")
+	}
+	width := r.MarginLeft + float64(r.Columns)*r.LabelWidth + float64(r.Columns-1)*r.GapX
+	height := r.MarginTop + float64(r.Rows)*r.LabelHeight + float64(r.Rows-1)*r.GapY
+	if width > r.PageWidth+0.01 || height > r.PageHeight+0.01 {
+		return faulterr.NewBadRequestError("Labels do not fit on the page")
+	}
+	if !r.ShowQR && !r.ShowBarcode && !r.ShowText {
+		return faulterr.NewBadRequestError("Labels must show a QR code, a barcode or text")
+	}
+	return nil
+}
+
+var gs1CompanyPrefix = regexp.MustCompile(`^\d{4,12}$`)
+
+// Validate GS1Settings
+func (r *GS1Settings) Validate() *faulterr.FaultErr {
+	if !gs1CompanyPrefix.MatchString(r.CompanyPrefix) {
+		return faulterr.NewBadRequestError("Company prefix must be 4 to 12 digits")
+	}
+	if r.ExtensionDigit < 0 || r.ExtensionDigit > 9 {
+		return faulterr.NewBadRequestError("Extension digit must be between 0 and 9")
+	}
+	return nil
+}
+
+// GS1CheckDigit computes the check digit of a GS1 key without its check digit,
+// digits are weighted 3 and 1 alternately from the right
+func GS1CheckDigit(digits string) int {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// validGS1Key checks a GS1 key is made of digits and ends with its check digit
+func validGS1Key(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	body := key[:len(key)-1]
+	return int(key[len(key)-1]-'0') == GS1CheckDigit(body)
+}
+
+// ValidGTIN checks a GTIN-8, GTIN-12, GTIN-13 or GTIN-14 and its check digit
+func ValidGTIN(gtin string) bool {
+	switch len(gtin) {
+	case 8, 12, 13, 14:
+		return validGS1Key(gtin)
+	}
+	return false
+}
+
+// GTIN14 pads a GTIN with leading zeros to the 14 digits it is stored and encoded with
+func GTIN14(gtin string) string {
+	if len(gtin) >= 14 {
+		return gtin
+	}
+	return strings.Repeat("0", 14-len(gtin)) + gtin
+}
+
+// ValidSSCC checks an SSCC-18 and its check digit
+func ValidSSCC(sscc string) bool {
+	return len(sscc) == 18 && validGS1Key(sscc)
+}
+
+var attributeKeyFormat = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,39}$`)
+
+// attributeTypeHints describe the values of the attribute types in error messages
+var attributeTypeHints = map[string]string{
+	AttributeString:  "text",
+	AttributeNumber:  "a number",
+	AttributeBoolean: "true or false",
+	AttributeDate:    "a date (YYYY-MM-DD)",
+}
+
+// Validate AttributeDefinition
+func (r *AttributeDefinition) Validate() *faulterr.FaultErr {
+	custom := false
+	for _, entity := range AttributeEntities {
+		if r.Entity == entity {
+			custom = true
+		}
+	}
+	if !custom {
+		return faulterr.NewBadRequestError("Attributes cannot be defined for " + r.Entity)
+	}
+	if !attributeKeyFormat.MatchString(r.Key) {
+		return faulterr.NewBadRequestError("Key must start with a letter and have at most 40 letters, digits or underscores")
+	}
+	switch r.Type {
+	case AttributeString, AttributeNumber, AttributeBoolean, AttributeDate:
+		if len(r.EnumValues) > 0 {
+			return faulterr.NewBadRequestError("Only enum attributes have enum values")
+		}
+	case AttributeEnum:
+		if len(r.EnumValues) == 0 {
+			return faulterr.NewBadRequestError("Enum attributes need at least one value")
+		}
+		seen := map[string]bool{}
+		for _, value := range r.EnumValues {
+			if strings.TrimSpace(value) == "" {
+				return faulterr.NewBadRequestError("Enum values cannot be empty")
+			}
+			if seen[value] {
+				return faulterr.NewBadRequestError("Enum value " + value + " is listed twice")
+			}
+			seen[value] = true
+		}
+	default:
+		return faulterr.NewBadRequestError("Type must be one of string, number, boolean, date or enum")
+	}
+	return nil
+}
+
+// Value converts a value to the type of the attribute, strings are parsed as forms and filters send every type as text
+func (r *AttributeDefinition) Value(v interface{}) (interface{}, bool) {
+	s, isString := v.(string)
+	switch r.Type {
+	case AttributeString:
+		return s, isString
+	case AttributeNumber:
+		n, ok := v.(float64)
+		if isString {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			n, ok = parsed, err == nil
+		}
+		return n, ok && !math.IsNaN(n) && !math.IsInf(n, 0)
+	case AttributeBoolean:
+		b, ok := v.(bool)
+		if isString {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(s))
+			b, ok = parsed, err == nil
+		}
+		return b, ok
+	case AttributeDate:
+		date, err := time.Parse(AttributeDateLayout, strings.TrimSpace(s))
+		if !isString || err != nil {
+			return nil, false
+		}
+		return date.Format(AttributeDateLayout), true
+	case AttributeEnum:
+		for _, value := range r.EnumValues {
+			if isString && value == s {
+				return s, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// name is how an attribute is called in error messages
+func (r *AttributeDefinition) name() string {
+	if r.Label != "" {
+		return r.Label
+	}
+	return r.Key
+}
+
+// ValidateAttributes checks the custom attributes of a pallet or container against the attributes its
+// organization defined and converts them to their types. Empty values are left out.
+func ValidateAttributes(defs []AttributeDefinition, attrs map[string]interface{}) (map[string]interface{}, *faulterr.FaultErr) {
+	byKey := make(map[string]AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]interface{}, len(attrs))
+	for _, key := range keys {
+		v := attrs[key]
+		if s, isString := v.(string); v == nil || (isString && strings.TrimSpace(s) == "") {
+			continue
+		}
+		def, ok := byKey[key]
+		if !ok {
+			return nil, faulterr.NewBadRequestError("Unknown attribute " + key)
+		}
+		value, ok := def.Value(v)
+		if !ok {
+			hint := attributeTypeHints[def.Type]
+			if def.Type == AttributeEnum {
+				hint = "one of " + strings.Join(def.EnumValues, ", ")
+			}
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("%s must be %s", def.name(), hint))
+		}
+		result[key] = value
+	}
+
+	for _, def := range defs {
+		if _, ok := result[def.Key]; def.Required && !ok {
+			return nil, faulterr.NewBadRequestError(def.name() + " is required")
+		}
+	}
+	return result, nil
+}
+
+// AttributeFilterValues gets the attribute values filters match, converted to the types of the attributes
+// an organization defined. Values of attributes which are not defined are matched as text.
+func AttributeFilterValues(defs []AttributeDefinition, filters []AttributeFilter) (map[string]interface{}, *faulterr.FaultErr) {
+	byKey := make(map[string]AttributeDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	values := make(map[string]interface{}, len(filters))
+	for _, filter := range filters {
+		def, ok := byKey[filter.Key]
+		if !ok {
+			values[filter.Key] = filter.Value
+			continue
+		}
+		value, ok := def.Value(filter.Value)
+		if !ok {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("Filter value %s is not valid for %s", filter.Value, def.name()))
+		}
+		values[filter.Key] = value
+	}
+	return values, nil
+}
+
+// Validate LotRequest
+func (r *LotRequest) Validate() *faulterr.FaultErr {
+	if r.Code == "" {
+		return faulterr.NewBadRequestError("Lot code is required")
+	}
+	if r.SkuID <= 0 {
+		return faulterr.NewBadRequestError("SKU is required")
+	}
+	if r.ProductionDate.IsZero() {
+		return faulterr.NewBadRequestError("Production date is required")
+	}
+	if r.ExpiryDate.Valid && !r.ExpiryDate.Time.After(r.ProductionDate) {
+		return faulterr.NewBadRequestError("Expiry date must be after production date")
+	}
 	return nil
 }
@@ -0,0 +1,324 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type postcodeResult struct {
+	country  string
+	postcode string
+	expected bool
+}
+
+var postcodeResults = []postcodeResult{
+	{"India", "560001", true},
+	{"IN", "056001", false},
+	{"IN", "56001", false},
+	{"United States", "94105", true},
+	{"US", "94105-1234", true},
+	{"USA", "9410", false},
+	{"UK", "SW1A 1AA", true},
+	{"GB", "sw1a1aa", true},
+	{"GB", "12345", false},
+	{"Canada", "K1A 0B1", true},
+	{"Netherlands", "1012 AB", true},
+	{"Singapore", "018956", true},
+	{"Japan", "100-0001", true},
+	{"Brazil", "01310-100", true},
+	{"Brazil", "-", false},
+}
+
+func TestValidPostcode(t *testing.T) {
+	for _, test := range postcodeResults {
+		result := ValidPostcode(test.country, test.postcode)
+		if result != test.expected {
+			t.Fatalf("ValidPostcode: %s %s is not expected result", test.country, test.postcode)
+		}
+	}
+}
+
+type codeResult struct {
+	format   CodeFormat
+	n        int64
+	period   int
+	expected string
+}
+
+var codeResults = []codeResult{
+	{CodeFormat{Prefix: "ACME", Padding: 5}, 42, 0, "ACME-00042"},
+	{CodeFormat{Prefix: "ACME", Padding: 3, ResetYearly: true}, 7, 2024, "ACME-2024-007"},
+	{CodeFormat{Prefix: "P1", Padding: 2}, 123, 0, "P1-123"},
+}
+
+func TestCodeFormat(t *testing.T) {
+	for _, test := range codeResults {
+		result := test.format.Format(test.n, test.period)
+		if result != test.expected {
+			t.Fatalf("CodeFormat: %s is not expected result %s", result, test.expected)
+		}
+	}
+	if code := DefaultCode(CodePallet, 42); code != "PLT00042" {
+		t.Fatalf("DefaultCode: %s is not expected result", code)
+	}
+}
+
+type locationParentResult struct {
+	kind       string
+	parentKind string
+	expected   bool
+}
+
+var locationParentResults = []locationParentResult{
+	{LocationZone, "", true},
+	{LocationAisle, LocationZone, true},
+	{LocationRack, LocationAisle, true},
+	{LocationBin, LocationRack, true},
+	{LocationBin, "", false},
+	{LocationRack, LocationZone, false},
+	{LocationZone, LocationBin, false},
+	{"shelf", LocationRack, false},
+}
+
+func TestValidLocationParent(t *testing.T) {
+	for _, test := range locationParentResults {
+		result := ValidLocationParent(test.kind, test.parentKind)
+		if result != test.expected {
+			t.Fatalf("ValidLocationParent: %s in %q is not expected result", test.kind, test.parentKind)
+		}
+	}
+}
+
+func TestLabelTemplates(t *testing.T) {
+	for name, template := range LabelTemplates {
+		if err := template.Validate(); err != nil {
+			t.Fatalf("LabelTemplates: %s is not valid: %s", name, err.Message)
+		}
+		if template.Name != name {
+			t.Fatalf("LabelTemplates: %s is named %s", name, template.Name)
+		}
+	}
+	overflow := LabelTemplates[DefaultLabelTemplate]
+	overflow.Rows++
+	if err := overflow.Validate(); err == nil {
+		t.Fatalf("LabelTemplate: labels overflowing the page are not expected to be valid")
+	}
+}
+
+type gtinResult struct {
+	gtin     string
+	expected bool
+}
+
+var gtinResults = []gtinResult{
+	{"96385074", true},
+	{"036000291452", true},
+	{"4006381333931", true},
+	{"10614141000415", true},
+	{"4006381333932", false},
+	{"400638133393", false},
+	{"40063813339A1", false},
+	{"", false},
+}
+
+func TestValidGTIN(t *testing.T) {
+	for _, test := range gtinResults {
+		result := ValidGTIN(test.gtin)
+		if result != test.expected {
+			t.Fatalf("ValidGTIN: %q is not expected result", test.gtin)
+		}
+	}
+	if gtin := GTIN14("036000291452"); gtin != "00036000291452" || !ValidGTIN(gtin) {
+		t.Fatalf("GTIN14: %s is not expected result", gtin)
+	}
+}
+
+type ssccResult struct {
+	settings GS1Settings
+	serial   int64
+	sscc     string
+	ok       bool
+}
+
+var ssccResults = []ssccResult{
+	{GS1Settings{CompanyPrefix: "0614141", ExtensionDigit: 1}, 123456789, "106141411234567897", true},
+	{GS1Settings{CompanyPrefix: "0614141", ExtensionDigit: 0}, 1, "006141410000000012", true},
+	{GS1Settings{CompanyPrefix: "061414112345", ExtensionDigit: 1}, 9999, "106141411234599997", true},
+	{GS1Settings{CompanyPrefix: "061414112345", ExtensionDigit: 1}, 10000, "", false},
+}
+
+func TestGS1SettingsSSCC(t *testing.T) {
+	for _, test := range ssccResults {
+		sscc, ok := test.settings.SSCC(test.serial)
+		if sscc != test.sscc || ok != test.ok {
+			t.Fatalf("SSCC: serial %d of %s is %q, expected %q", test.serial, test.settings.CompanyPrefix, sscc, test.sscc)
+		}
+		if ok && !ValidSSCC(sscc) {
+			t.Fatalf("ValidSSCC: %s is expected to be valid", sscc)
+		}
+	}
+}
+
+type epcResult struct {
+	epc  string
+	sscc string
+	uid  string
+	ok   bool
+}
+
+var epcResults = []epcResult{
+	{"urn:epc:id:sscc:0614141.1234567890", "106141412345678908", "", true},
+	{"https://id.gs1.org/00/106141411234567897", "106141411234567897", "", true},
+	{"https://example.com/00/106141411234567897?linkType=gs1:pip", "106141411234567897", "", true},
+	{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", "", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true},
+	{"https://id.gs1.org/00/106141411234567890", "", "", false},
+	{"urn:epc:id:sscc:0614141.123456789", "", "", false},
+	{"urn:epc:id:sgtin:0614141.812345.6789", "", "", false},
+	{"urn:uuid:pallet", "", "", false},
+}
+
+func TestParseEPC(t *testing.T) {
+	for _, test := range epcResults {
+		sscc, uid, ok := ParseEPC(test.epc)
+		if ok != test.ok || (ok && (sscc != test.sscc || (test.uid != "" && uid.String() != test.uid))) {
+			t.Fatalf("ParseEPC: %s is not expected result", test.epc)
+		}
+		if ok && sscc != "" {
+			if back, _, _ := ParseEPC(EPC(uuid.Nil, null.StringFrom(sscc))); back != sscc {
+				t.Fatalf("EPC: %s does not read back", sscc)
+			}
+		}
+	}
+}
+
+var attributeDefinitions = []AttributeDefinition{
+	{Key: "temperatureClass", Type: AttributeEnum, EnumValues: []string{"ambient", "chilled", "frozen"}, Required: true},
+	{Key: "customsNumber", Type: AttributeString},
+	{Key: "harvestDate", Label: "Harvest date", Type: AttributeDate},
+	{Key: "weight", Type: AttributeNumber},
+	{Key: "organic", Type: AttributeBoolean},
+}
+
+type attributesResult struct {
+	attrs    map[string]interface{}
+	expected map[string]interface{}
+	ok       bool
+}
+
+var attributesResults = []attributesResult{
+	{
+		map[string]interface{}{"temperatureClass": "frozen", "harvestDate": "2024-03-07", "weight": "12.5", "organic": "true"},
+		map[string]interface{}{"temperatureClass": "frozen", "harvestDate": "2024-03-07", "weight": 12.5, "organic": true},
+		true,
+	},
+	{
+		map[string]interface{}{"temperatureClass": "chilled", "weight": 3.0, "organic": false, "customsNumber": ""},
+		map[string]interface{}{"temperatureClass": "chilled", "weight": 3.0, "organic": false},
+		true,
+	},
+	{map[string]interface{}{"customsNumber": "AU123"}, nil, false},
+	{map[string]interface{}{"temperatureClass": "warm"}, nil, false},
+	{map[string]interface{}{"temperatureClass": "frozen", "harvestDate": "07/03/2024"}, nil, false},
+	{map[string]interface{}{"temperatureClass": "frozen", "weight": "NaN"}, nil, false},
+	{map[string]interface{}{"temperatureClass": "frozen", "organic": "maybe"}, nil, false},
+	{map[string]interface{}{"temperatureClass": "frozen", "colour": "red"}, nil, false},
+}
+
+func TestValidateAttributes(t *testing.T) {
+	for i, test := range attributesResults {
+		result, err := ValidateAttributes(attributeDefinitions, test.attrs)
+		if (err == nil) != test.ok {
+			t.Fatalf("ValidateAttributes: case %d is expected to be valid %t", i, test.ok)
+		}
+		if !test.ok {
+			continue
+		}
+		if len(result) != len(test.expected) {
+			t.Fatalf("ValidateAttributes: case %d has %d attributes, expected %d", i, len(result), len(test.expected))
+		}
+		for key, value := range test.expected {
+			if result[key] != value {
+				t.Fatalf("ValidateAttributes: case %d has %s %v, expected %v", i, key, result[key], value)
+			}
+		}
+	}
+
+	filters := []AttributeFilter{{Key: "weight", Value: "12.50"}, {Key: "colour", Value: "red"}}
+	values, err := AttributeFilterValues(attributeDefinitions, filters)
+	if err != nil || values["weight"] != 12.5 || values["colour"] != "red" {
+		t.Fatalf("AttributeFilterValues: %v is not expected result", values)
+	}
+}
+
+func TestRecallProgressOf(t *testing.T) {
+	resolved := null.TimeFrom(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	items := []RecallItem{
+		{ItemType: CodePallet, ResolvedAt: resolved},
+		{ItemType: CodePallet},
+		{ItemType: CodeContainer, ResolvedAt: resolved},
+		{ItemType: CodeConsumerOrder},
+		{ItemType: "unknown", ResolvedAt: resolved},
+		{ItemType: CodeOrder},
+	}
+
+	progress := RecallProgressOf(items)
+	if progress.Total != 5 || progress.Resolved != 2 || progress.Percent != 40 {
+		t.Fatalf("RecallProgressOf: %d of %d resolved (%v%%) is not expected result", progress.Resolved, progress.Total, progress.Percent)
+	}
+	if len(progress.Items) != len(RecallItemTypes) {
+		t.Fatalf("RecallProgressOf: %d item types, expected %d", len(progress.Items), len(RecallItemTypes))
+	}
+	for _, count := range progress.Items {
+		if count.ItemType == CodePallet && (count.Total != 2 || count.Resolved != 1) {
+			t.Fatalf("RecallProgressOf: %d of %d pallets resolved, expected 1 of 2", count.Resolved, count.Total)
+		}
+		if count.ItemType == CodePurchaseRecord && count.Total != 0 {
+			t.Fatalf("RecallProgressOf: %d purchase records, expected 0", count.Total)
+		}
+	}
+
+	if empty := RecallProgressOf(nil); empty.Total != 0 || empty.Percent != 0 {
+		t.Fatalf("RecallProgressOf: empty recall has %d items", empty.Total)
+	}
+}
+
+type lotExpiryResult struct {
+	expiry   null.Time
+	expired  bool
+	expiring bool
+}
+
+var lotNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+var lotExpiryResults = []lotExpiryResult{
+	{null.Time{}, false, false},
+	{null.TimeFrom(lotNow.AddDate(0, 0, -1)), true, false},
+	{null.TimeFrom(lotNow), true, false},
+	{null.TimeFrom(lotNow.Add(time.Hour)), false, true},
+	{null.TimeFrom(lotNow.AddDate(0, 0, 30)), false, true},
+	{null.TimeFrom(lotNow.AddDate(0, 0, 31)), false, false},
+}
+
+func TestLotExpiry(t *testing.T) {
+	for i, test := range lotExpiryResults {
+		lot := Lot{ExpiryDate: test.expiry}
+		if lot.HasExpired(lotNow) != test.expired {
+			t.Fatalf("HasExpired: case %d is expected to be %t", i, test.expired)
+		}
+		if lot.ExpiresWithin(lotNow, 30) != test.expiring {
+			t.Fatalf("ExpiresWithin: case %d is expected to be %t", i, test.expiring)
+		}
+	}
+
+	lot := LotRequest{Code: "L-001", SkuID: 1, ProductionDate: lotNow, ExpiryDate: null.TimeFrom(lotNow)}
+	if lot.Validate() == nil {
+		t.Fatalf("LotRequest: expiry on the production date is expected to be invalid")
+	}
+	lot.ExpiryDate = null.TimeFrom(lotNow.AddDate(1, 0, 0))
+	if err := lot.Validate(); err != nil {
+		t.Fatalf("LotRequest: %s", err.Message)
+	}
+}
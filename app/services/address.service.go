@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/volatiletech/null"
+)
+
+type AddressService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ AddressServiceInterface = &AddressService{}
+
+type AddressServiceInterface interface {
+	ListMine(ctx context.Context, auther *models.Auther) ([]models.Address, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64, auther *models.Auther) ([]models.Address, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Address, *faulterr.FaultErr)
+	Create(ctx context.Context, address models.Address, auther *models.Auther) (*models.Address, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, address models.Address, auther *models.Auther) (*models.Address, *faulterr.FaultErr)
+	SetDefault(ctx context.Context, id int64, auther *models.Auther) (*models.Address, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewAddressService(s *dbstore.DBStore, m *master.Master) *AddressService {
+	return &AddressService{s, m}
+}
+
+// ListMine gets the addresses of the logged in customer or of the organization of the logged in member
+func (s *AddressService) ListMine(ctx context.Context, auther *models.Auther) ([]models.Address, *faulterr.FaultErr) {
+	if auther.IsCustomer {
+		return s.dbstore.AddressStore.ListByUserID(ctx, auther.ID)
+	}
+	if !auther.OrganizationID.Valid {
+		return []models.Address{}, nil
+	}
+	return s.dbstore.AddressStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+// ListByOrgID gets the addresses of an organization
+func (s *AddressService) ListByOrgID(ctx context.Context, orgID int64, auther *models.Auther) ([]models.Address, *faulterr.FaultErr) {
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != orgID {
+		return nil, faulterr.NewNotFoundError("no organization found")
+	}
+	return s.dbstore.AddressStore.ListByOrgID(ctx, orgID)
+}
+
+func (s *AddressService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Address, *faulterr.FaultErr) {
+	obj, err := s.dbstore.AddressStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canManage(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no address found")
+	}
+	return obj, nil
+}
+
+// Create adds an address to the logged in customer or to the organization of the logged in member,
+// admins add organization addresses to the organization given in the request
+func (s *AddressService) Create(ctx context.Context, a models.Address, auther *models.Auther) (*models.Address, *faulterr.FaultErr) {
+	// Reassign the owner of the address
+	switch {
+	case auther.IsCustomer:
+		a.UserID = null.Int64From(auther.ID)
+		a.OrganizationID = null.Int64{}
+	case auther.IsAdmin:
+		a.UserID = null.Int64{}
+		if !a.OrganizationID.Valid {
+			return nil, faulterr.NewBadRequestError("Organization ID is required")
+		}
+		if _, err := s.dbstore.OrganizationStore.GetByID(ctx, a.OrganizationID.Int64); err != nil {
+			return nil, err
+		}
+	default:
+		a.UserID = null.Int64{}
+		a.OrganizationID = auther.OrganizationID
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.AddressMaster.Create(ctx, tx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *AddressService) Update(ctx context.Context, id int64, a models.Address, auther *models.Auther) (*models.Address, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.AddressMaster.Update(ctx, tx, current, a)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// SetDefault makes an address the default address of its owner
+func (s *AddressService) SetDefault(ctx context.Context, id int64, auther *models.Auther) (*models.Address, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.AddressMaster.SetDefault(ctx, tx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *AddressService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.master.AddressMaster.Delete(ctx, tx, current); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// canManage allows customers to manage their own addresses and members the addresses of their organization,
+// addresses without an owner belong to distributors and are managed through them
+func (s *AddressService) canManage(address *models.Address, auther *models.Auther) bool {
+	if auther.IsAdmin {
+		return address.UserID.Valid || address.OrganizationID.Valid
+	}
+	if auther.IsCustomer {
+		return address.UserID.Valid && address.UserID.Int64 == auther.ID
+	}
+	return address.OrganizationID.Valid && address.OrganizationID.Int64 == auther.OrganizationID.Int64
+}
@@ -7,12 +7,32 @@ import (
 )
 
 type Services struct {
-	AuthService         *AuthService
-	OrganizationService *OrganizationService
-	RoleService         *RoleService
-	UserService         *UserService
-	ContainerService    *ContainerService
-	PalletService       *PalletService
+	AuthService           *AuthService
+	OrganizationService   *OrganizationService
+	RoleService           *RoleService
+	UserService           *UserService
+	ContainerService      *ContainerService
+	PalletService         *PalletService
+	SkuService            *SkuService
+	OrderService          *OrderService
+	ContractService       *ContractService
+	DistributorService    *DistributorService
+	TaskService           *TaskService
+	PurchaseRecordService *PurchaseRecordService
+	ConsumerOrderService  *ConsumerOrderService
+	TrackActionService    *TrackActionService
+	ReferralService       *ReferralService
+	WalletService         *WalletService
+	AddressService        *AddressService
+	ShipmentService       *ShipmentService
+	WarehouseService      *WarehouseService
+	LabelService          *LabelService
+	ProvenanceService     *ProvenanceService
+	EPCISService          *EPCISService
+	FileService           *FileService
+	RecallService         *RecallService
+	NotificationService   *NotificationService
+	LotService            *LotService
 }
 
 func NewService(
@@ -27,5 +47,25 @@ func NewService(
 		NewUserService(dbstore, master),
 		NewContainerService(dbstore, master),
 		NewPalletService(dbstore, master),
+		NewSkuService(dbstore, master),
+		NewOrderService(dbstore, master),
+		NewContractService(dbstore, master),
+		NewDistributorService(dbstore, master),
+		NewTaskService(dbstore, master),
+		NewPurchaseRecordService(dbstore, master),
+		NewConsumerOrderService(dbstore, master),
+		NewTrackActionService(dbstore, master),
+		NewReferralService(dbstore, master),
+		NewWalletService(dbstore, master),
+		NewAddressService(dbstore, master),
+		NewShipmentService(dbstore, master),
+		NewWarehouseService(dbstore, master),
+		NewLabelService(dbstore, master),
+		NewProvenanceService(dbstore, master),
+		NewEPCISService(dbstore, master),
+		NewFileService(dbstore, master),
+		NewRecallService(dbstore, master),
+		NewNotificationService(dbstore, master),
+		NewLotService(dbstore, master),
 	}
 }
@@ -118,17 +118,24 @@ func (s *AuthService) RegisterCustomer(ctx context.Context, r models.CustomerReq
 		return nil, err
 	}
 
-	// if r.ReferralCode != "" {
-	// 	if err := s.master.ReferralMaster.Create(ctx, tx, u, r.ReferralCode); err != nil {
-	// 		return nil, err
-	// 	}
-	// }
-
-	// s.master.PurchaseRecordMaster.RedeemByEmail(ctx, tx, u)
-	// s.master.PurchaseRecordMaster.RedeemByPhone(ctx, tx, u)
-	// if r.PurchaseToken != "" {
-	// 	s.master.PurchaseRecordMaster.RedeemByToken(ctx, tx, u.ID, r.PurchaseToken)
-	// }
+	if r.ReferralCode != "" {
+		if err := s.master.ReferralMaster.Create(ctx, tx, u, r.ReferralCode); err != nil {
+			return nil, err
+		}
+	}
+
+	// Redeem purchases made before registration
+	if _, err := s.master.PurchaseRecordMaster.RedeemByEmail(ctx, tx, u); err != nil {
+		return nil, err
+	}
+	if _, err := s.master.PurchaseRecordMaster.RedeemByPhone(ctx, tx, u); err != nil {
+		return nil, err
+	}
+	if r.PurchaseToken != "" {
+		if _, err := s.master.PurchaseRecordMaster.RedeemByToken(ctx, tx, u.ID, r.PurchaseToken); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
 		return nil, err
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type ConsumerOrderService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ ConsumerOrderServiceInterface = &ConsumerOrderService{}
+
+type ConsumerOrderServiceInterface interface {
+	List(ctx context.Context, status null.String, auther *models.Auther) ([]models.ConsumerOrder, *faulterr.FaultErr)
+	ListMine(ctx context.Context, status null.String, auther *models.Auther) ([]models.ConsumerOrder, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr)
+	ListItems(ctx context.Context, consumerOrderID int64) ([]models.ConsumerOrderItem, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.ConsumerOrderRequest, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr)
+	UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewConsumerOrderService(s *dbstore.DBStore, m *master.Master) *ConsumerOrderService {
+	return &ConsumerOrderService{s, m}
+}
+
+// List gets all consumer orders sold by the auther's organization
+func (s *ConsumerOrderService) List(ctx context.Context, status null.String, auther *models.Auther) ([]models.ConsumerOrder, *faulterr.FaultErr) {
+	var orders []models.ConsumerOrder
+	var err *faulterr.FaultErr
+	if auther.IsAdmin {
+		orders, err = s.dbstore.ConsumerOrderStore.List(ctx)
+	} else {
+		orders, err = s.dbstore.ConsumerOrderStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return filterConsumerOrders(orders, status), nil
+}
+
+// ListMine gets the order history of the logged in customer
+func (s *ConsumerOrderService) ListMine(ctx context.Context, status null.String, auther *models.Auther) ([]models.ConsumerOrder, *faulterr.FaultErr) {
+	orders, err := s.dbstore.ConsumerOrderStore.ListByCustomerID(ctx, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+	return filterConsumerOrders(orders, status), nil
+}
+
+func (s *ConsumerOrderService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ConsumerOrderStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no consumer order found")
+	}
+	return obj, nil
+}
+
+func (s *ConsumerOrderService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ConsumerOrderStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no consumer order found")
+	}
+	return obj, nil
+}
+
+func (s *ConsumerOrderService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ConsumerOrderStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no consumer order found")
+	}
+	return obj, nil
+}
+
+// ListItems gets the line items of a consumer order
+func (s *ConsumerOrderService) ListItems(ctx context.Context, consumerOrderID int64) ([]models.ConsumerOrderItem, *faulterr.FaultErr) {
+	return s.dbstore.ConsumerOrderItemStore.ListByConsumerOrderID(ctx, consumerOrderID)
+}
+
+// Create places a consumer order for the logged in customer
+func (s *ConsumerOrderService) Create(ctx context.Context, r models.ConsumerOrderRequest, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	if !auther.IsCustomer {
+		return nil, faulterr.NewUnauthorizedError("only customers can place consumer orders")
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ConsumerOrderMaster.Create(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// UpdateStatus moves a consumer order to the next status, customers may only cancel their pending orders
+func (s *ConsumerOrderService) UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	// The status is checked on the locked order so concurrent cancellations refund points once
+	current, err := s.dbstore.ConsumerOrderStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if auther.IsCustomer && (status != models.ConsumerOrderCancelled || current.Status != models.ConsumerOrderPending) {
+		return nil, faulterr.NewUnauthorizedError("customers can only cancel pending orders")
+	}
+
+	order, err := s.master.ConsumerOrderMaster.UpdateStatus(ctx, tx, current, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *ConsumerOrderService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.ConsumerOrderStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.ConsumerOrderStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// canView allows the customer who placed a consumer order and the selling organization to see it
+func (s *ConsumerOrderService) canView(order *models.ConsumerOrder, auther *models.Auther) bool {
+	if auther.IsAdmin {
+		return true
+	}
+	if auther.IsCustomer {
+		return auther.ID == order.CustomerID
+	}
+	return auther.OrganizationID.Int64 == order.OrganizationID
+}
+
+func filterConsumerOrders(orders []models.ConsumerOrder, status null.String) []models.ConsumerOrder {
+	if !status.Valid {
+		return orders
+	}
+
+	result := []models.ConsumerOrder{}
+	for _, order := range orders {
+		if order.Status == status.String {
+			result = append(result, order)
+		}
+	}
+	return result
+}
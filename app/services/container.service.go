@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"orijinplus/app/master"
 	"orijinplus/app/models"
 	"orijinplus/app/store/dbstore"
 	"orijinplus/utils/faulterr"
 
 	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
 )
 
 type ContainerService struct {
@@ -19,11 +22,19 @@ var _ ContainerServiceInterface = &ContainerService{}
 
 type ContainerServiceInterface interface {
 	List(ctx context.Context, auther *models.Auther) ([]models.Container, *faulterr.FaultErr)
+	ListByAttributes(ctx context.Context, filters []models.AttributeFilter, auther *models.Auther) ([]models.Container, *faulterr.FaultErr)
 	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
 	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
 	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
+	GetBySSCC(ctx context.Context, sscc string, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
 	Create(ctx context.Context, request models.ContainerRequest, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
+	CreateBulk(ctx context.Context, request models.ContainerRequest, count int, auther *models.Auther) ([]models.Container, *faulterr.FaultErr)
 	Update(ctx context.Context, id int64, request models.ContainerRequest, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
+	Transition(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
+	ListTransitions(ctx context.Context, id int64, auther *models.Auther) ([]models.ContainerTransition, *faulterr.FaultErr)
+	AssignSSCC(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
+	Place(ctx context.Context, id int64, locationID null.Int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr)
+	ListLocationMoves(ctx context.Context, id int64, auther *models.Auther) ([]models.LocationMove, *faulterr.FaultErr)
 	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
 }
 
@@ -39,6 +50,19 @@ func (s *ContainerService) List(ctx context.Context, auther *models.Auther) ([]m
 	return s.dbstore.ContainerStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
 }
 
+// ListByAttributes gets the containers having custom attribute values
+func (s *ContainerService) ListByAttributes(ctx context.Context, filters []models.AttributeFilter, auther *models.Auther) ([]models.Container, *faulterr.FaultErr) {
+	orgID := null.Int64{}
+	if !auther.IsAdmin {
+		orgID = auther.OrganizationID
+	}
+	values, err := s.master.AttributeMaster.FilterValues(ctx, orgID, models.CodeContainer, filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.dbstore.ContainerStore.ListByAttributes(ctx, orgID, values)
+}
+
 func (s *ContainerService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
 	obj, err := s.dbstore.ContainerStore.GetByID(ctx, id)
 	if err != nil {
@@ -72,6 +96,17 @@ func (s *ContainerService) GetByCode(ctx context.Context, code string, auther *m
 	return obj, nil
 }
 
+func (s *ContainerService) GetBySSCC(ctx context.Context, sscc string, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
+	container, err := s.dbstore.ContainerStore.GetBySSCC(ctx, sscc)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != container.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no container found")
+	}
+	return container, nil
+}
+
 // Create gets all skus
 func (s *ContainerService) Create(ctx context.Context, r models.ContainerRequest, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
 	if auther.IsAdmin && !r.OrganizationID.Valid {
@@ -102,12 +137,40 @@ func (s *ContainerService) Create(ctx context.Context, r models.ContainerRequest
 	return obj, nil
 }
 
-func (s *ContainerService) Update(ctx context.Context, id int64, request models.ContainerRequest, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
-	current, err := s.GetByID(ctx, id, auther)
+// CreateBulk creates a number of identical containers in one transaction
+func (s *ContainerService) CreateBulk(ctx context.Context, r models.ContainerRequest, count int, auther *models.Auther) ([]models.Container, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	containers, err := s.master.ContainerMaster.CreateBulk(ctx, tx, r, count, auther.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return containers, nil
+}
+
+func (s *ContainerService) Update(ctx context.Context, id int64, request models.ContainerRequest, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
 	if err != nil {
@@ -115,6 +178,12 @@ func (s *ContainerService) Update(ctx context.Context, id int64, request models.
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
+	// The container is locked so a concurrent status transition is not written back over
+	current, err := s.dbstore.ContainerStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	container, err := s.master.ContainerMaster.Update(ctx, tx, current, request)
 	if err != nil {
 		return nil, err
@@ -127,13 +196,87 @@ func (s *ContainerService) Update(ctx context.Context, id int64, request models.
 	return container, nil
 }
 
+// Transition moves a container to the next status of its lifecycle
+func (s *ContainerService) Transition(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	// Containers on a shipment depart and arrive with it, the lock keeps them from being added meanwhile
+	if status == models.ContainerInTransit || status == models.ContainerArrived {
+		current, err := s.dbstore.ContainerStore.LockByID(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.verifyNotShipped(ctx, current); err != nil {
+			return nil, err
+		}
+	}
+
+	container, err := s.master.ContainerMaster.Transition(ctx, tx, id, status, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
+
+// ListTransitions gets the status changes of a container, latest first
+func (s *ContainerService) ListTransitions(ctx context.Context, id int64, auther *models.Auther) ([]models.ContainerTransition, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.ContainerTransitionStore.ListByContainerID(ctx, id)
+}
+
 func (s *ContainerService) Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
-	container, err := s.GetByID(ctx, id, auther)
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	container, err := s.dbstore.ContainerStore.LockByID(ctx, tx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.verifyNotShipped(ctx, container); err != nil {
+		return nil, err
+	}
 	container.IsArchived = true
 
+	if err := s.dbstore.ContainerStore.Update(ctx, tx, *container); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
+
+func (s *ContainerService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
 	if err != nil {
@@ -141,6 +284,12 @@ func (s *ContainerService) Archive(ctx context.Context, id int64, auther *models
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
+	container, err := s.dbstore.ContainerStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	container.IsArchived = false
+
 	if err := s.dbstore.ContainerStore.Update(ctx, tx, *container); err != nil {
 		return nil, err
 	}
@@ -152,12 +301,12 @@ func (s *ContainerService) Archive(ctx context.Context, id int64, auther *models
 	return container, nil
 }
 
-func (s *ContainerService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
+// AssignSSCC gives a container without an SSCC the next SSCC of its organization
+func (s *ContainerService) AssignSSCC(ctx context.Context, id int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
 	container, err := s.GetByID(ctx, id, auther)
 	if err != nil {
 		return nil, err
 	}
-	container.IsArchived = false
 
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
@@ -166,7 +315,8 @@ func (s *ContainerService) Unarchive(ctx context.Context, id int64, auther *mode
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
-	if err := s.dbstore.ContainerStore.Update(ctx, tx, *container); err != nil {
+	container, err = s.master.ContainerMaster.AssignSSCC(ctx, tx, container)
+	if err != nil {
 		return nil, err
 	}
 
@@ -177,6 +327,39 @@ func (s *ContainerService) Unarchive(ctx context.Context, id int64, auther *mode
 	return container, nil
 }
 
+// Place puts a container at a warehouse location, a null location takes it off its location
+func (s *ContainerService) Place(ctx context.Context, id int64, locationID null.Int64, auther *models.Auther) (*models.Container, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	container, err := s.master.LocationMaster.PlaceContainer(ctx, tx, id, locationID, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
+
+// ListLocationMoves gets the locations a container has been moved between, latest first
+func (s *ContainerService) ListLocationMoves(ctx context.Context, id int64, auther *models.Auther) ([]models.LocationMove, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.LocationMoveStore.ListByContainerID(ctx, id)
+}
+
 func (s *ContainerService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
 	if !auther.IsAdmin {
 		return faulterr.NewUnauthorizedError("Permission not granted")
@@ -185,6 +368,14 @@ func (s *ContainerService) Delete(ctx context.Context, id int64, auther *models.
 	if err != nil {
 		return err
 	}
+	// Containers which were used are archived instead so their history is kept
+	hasHistory, err := s.dbstore.ContainerStore.HasHistory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasHistory {
+		return faulterr.NewBadRequestError("container has history and cannot be deleted, archive it instead")
+	}
 
 	// Start db transaction
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
@@ -193,6 +384,9 @@ func (s *ContainerService) Delete(ctx context.Context, id int64, auther *models.
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
+	if err := s.dbstore.LocationMoveStore.DeleteByContainerID(ctx, tx, id); err != nil {
+		return err
+	}
 	if err := s.dbstore.ContainerStore.Delete(ctx, tx, id); err != nil {
 		return err
 	}
@@ -202,3 +396,17 @@ func (s *ContainerService) Delete(ctx context.Context, id int64, auther *models.
 
 	return nil
 }
+
+// Helpers
+
+// verifyNotShipped refuses changes to a container on a shipment which has not arrived yet
+func (s *ContainerService) verifyNotShipped(ctx context.Context, container *models.Container) *faulterr.FaultErr {
+	entry, err := s.dbstore.ShipmentContainerStore.GetOpenByContainerID(ctx, container.ID)
+	if err != nil && err.Status != http.StatusNotFound {
+		return err
+	}
+	if entry != nil {
+		return faulterr.NewBadRequestError(fmt.Sprintf("container %s is on a shipment, it departs and arrives with the shipment", container.Code))
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type ContractService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ ContractServiceInterface = &ContractService{}
+
+type ContractServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Contract, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	ListDocuments(ctx context.Context, contractID int64) ([]models.ContractDocument, *faulterr.FaultErr)
+	ListOrders(ctx context.Context, contractID int64) ([]models.Order, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.ContractRequest, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.ContractRequest, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	Approve(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	AddDocument(ctx context.Context, id int64, file models.File, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	RemoveDocument(ctx context.Context, documentID int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewContractService(s *dbstore.DBStore, m *master.Master) *ContractService {
+	return &ContractService{s, m}
+}
+
+// List gets all contracts the auther's organization is a party of
+func (s *ContractService) List(ctx context.Context, auther *models.Auther) ([]models.Contract, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.ContractStore.List(ctx)
+	}
+	return s.dbstore.ContractStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+func (s *ContractService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ContractStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no contract found")
+	}
+	return obj, nil
+}
+
+func (s *ContractService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ContractStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no contract found")
+	}
+	return obj, nil
+}
+
+func (s *ContractService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ContractStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no contract found")
+	}
+	return obj, nil
+}
+
+// ListDocuments gets the documents attached to a contract
+func (s *ContractService) ListDocuments(ctx context.Context, contractID int64) ([]models.ContractDocument, *faulterr.FaultErr) {
+	return s.dbstore.ContractDocumentStore.ListByContractID(ctx, contractID)
+}
+
+// ListOrders gets the orders placed against a contract
+func (s *ContractService) ListOrders(ctx context.Context, contractID int64) ([]models.Order, *faulterr.FaultErr) {
+	return s.dbstore.OrderStore.ListByContractID(ctx, contractID)
+}
+
+// Create saves a contract object in db
+func (s *ContractService) Create(ctx context.Context, r models.ContractRequest, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+	createdByID := auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ContractMaster.Create(ctx, tx, r, createdByID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *ContractService) Update(ctx context.Context, id int64, request models.ContractRequest, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	// The contract is locked so edits and approvals of the other side are not written back over
+	current, err := s.dbstore.ContractStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	request.OrganizationID = null.Int64From(current.OrganizationID)
+
+	contract, err := s.master.ContractMaster.Update(ctx, tx, current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+// Approve records the approval of the auther's organization
+func (s *ContractService) Approve(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	// Approvals are recorded on the locked contract so the approval of the other side is kept
+	current, err := s.dbstore.ContractStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := s.master.ContractMaster.Approve(ctx, tx, current, auther.OrganizationID.Int64, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+// AddDocument attaches an uploaded file to a contract of the organization, both sides approve it again
+func (s *ContractService) AddDocument(ctx context.Context, id int64, file models.File, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	contract, err := s.dbstore.ContractStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.master.ContractMaster.AddDocument(ctx, tx, contract, file, auther.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+// RemoveDocument detaches a document from a contract of the organization, both sides approve it again
+func (s *ContractService) RemoveDocument(ctx context.Context, documentID int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	document, err := s.dbstore.ContractDocumentStore.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getOwned(ctx, document.ContractID, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	contract, err := s.dbstore.ContractStore.LockByID(ctx, tx, document.ContractID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.master.ContractMaster.RemoveDocument(ctx, tx, contract, document.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (s *ContractService) Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	contract, err := s.dbstore.ContractStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	contract.IsArchived = true
+
+	if err := s.dbstore.ContractStore.Update(ctx, tx, *contract); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (s *ContractService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	contract, err := s.dbstore.ContractStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	contract.IsArchived = false
+
+	if err := s.dbstore.ContractStore.Update(ctx, tx, *contract); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (s *ContractService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.ContractStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.ContractStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Helpers
+
+// canView allows both the supplier and the buyer to see a contract
+func (s *ContractService) canView(contract *models.Contract, auther *models.Auther) bool {
+	if auther.IsAdmin {
+		return true
+	}
+	orgID := auther.OrganizationID.Int64
+	return orgID == contract.OrganizationID || orgID == contract.BuyerOrganizationID
+}
+
+// getOwned gets a contract drafted by the auther's organization, which may therefore change its terms
+func (s *ContractService) getOwned(ctx context.Context, id int64, auther *models.Auther) (*models.Contract, *faulterr.FaultErr) {
+	contract, err := s.dbstore.ContractStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != contract.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no contract found")
+	}
+	return contract, nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type DistributorService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ DistributorServiceInterface = &DistributorService{}
+
+type DistributorServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Distributor, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	GetByPalletID(ctx context.Context, palletID int64) (*models.Distributor, *faulterr.FaultErr)
+	ListPallets(ctx context.Context, distributorID int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListOrders(ctx context.Context, distributorID int64) ([]models.Order, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.DistributorRequest, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.DistributorRequest, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewDistributorService(s *dbstore.DBStore, m *master.Master) *DistributorService {
+	return &DistributorService{s, m}
+}
+
+// List gets all distributors
+func (s *DistributorService) List(ctx context.Context, auther *models.Auther) ([]models.Distributor, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.DistributorStore.List(ctx)
+	}
+	return s.dbstore.DistributorStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+func (s *DistributorService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	obj, err := s.dbstore.DistributorStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no distributor found")
+	}
+	return obj, nil
+}
+
+func (s *DistributorService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	obj, err := s.dbstore.DistributorStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no distributor found")
+	}
+	return obj, nil
+}
+
+func (s *DistributorService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	obj, err := s.dbstore.DistributorStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no distributor found")
+	}
+	return obj, nil
+}
+
+// GetByPalletID gets the distributor a pallet went through, if any
+func (s *DistributorService) GetByPalletID(ctx context.Context, palletID int64) (*models.Distributor, *faulterr.FaultErr) {
+	return s.dbstore.DistributorStore.GetByPalletID(ctx, palletID)
+}
+
+// ListPallets gets all pallets that went through a distributor
+func (s *DistributorService) ListPallets(ctx context.Context, distributorID int64) ([]models.Pallet, *faulterr.FaultErr) {
+	return s.dbstore.PalletStore.ListByDistributorID(ctx, distributorID)
+}
+
+// ListOrders gets all orders routed through a distributor
+func (s *DistributorService) ListOrders(ctx context.Context, distributorID int64) ([]models.Order, *faulterr.FaultErr) {
+	return s.dbstore.OrderStore.ListByDistributorID(ctx, distributorID)
+}
+
+// Create saves a distributor object in db
+func (s *DistributorService) Create(ctx context.Context, r models.DistributorRequest, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+	createdByID := auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.DistributorMaster.Create(ctx, tx, r, createdByID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *DistributorService) Update(ctx context.Context, id int64, request models.DistributorRequest, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	request.OrganizationID = null.Int64From(current.OrganizationID)
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	distributor, err := s.master.DistributorMaster.Update(ctx, tx, current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return distributor, nil
+}
+
+func (s *DistributorService) Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	distributor, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	distributor.IsArchived = true
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.DistributorStore.Update(ctx, tx, *distributor); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return distributor, nil
+}
+
+func (s *DistributorService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Distributor, *faulterr.FaultErr) {
+	distributor, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	distributor.IsArchived = false
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.DistributorStore.Update(ctx, tx, *distributor); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return distributor, nil
+}
+
+func (s *DistributorService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.DistributorStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.DistributorStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/epcis"
+	"orijinplus/utils/faulterr"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type EPCISService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ EPCISServiceInterface = &EPCISService{}
+
+type EPCISServiceInterface interface {
+	ExportOrganization(ctx context.Context, orgID int64, auther *models.Auther) (*epcis.Document, *faulterr.FaultErr)
+	ExportItem(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*epcis.Document, *faulterr.FaultErr)
+	Capture(ctx context.Context, doc *epcis.Document, auther *models.Auther) (*models.EPCISCaptureResult, *faulterr.FaultErr)
+}
+
+func NewEPCISService(s *dbstore.DBStore, m *master.Master) *EPCISService {
+	return &EPCISService{s, m}
+}
+
+// epcisItems caches the epcs of the pallets and containers events refer to
+type epcisItems struct {
+	dbstore    *dbstore.DBStore
+	pallets    map[int64]string
+	containers map[int64]string
+}
+
+// ExportOrganization gets the events of all pallets and containers of an organization
+func (s *EPCISService) ExportOrganization(ctx context.Context, orgID int64, auther *models.Auther) (*epcis.Document, *faulterr.FaultErr) {
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != orgID {
+		return nil, faulterr.NewNotFoundError("no organization found")
+	}
+
+	pallets, err := s.dbstore.PalletStore.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := s.dbstore.ContainerStore.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := s.dbstore.TrackActionStore.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.dbstore.PalletAssignmentStore.ListByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := s.items()
+	events := []epcis.Event{}
+	for _, p := range pallets {
+		items.pallets[p.ID] = models.EPC(p.UID, p.SSCC)
+		events = append(events, commissioningEvent(items.pallets[p.ID], models.LabelPallet, p.UID, p.CreatedAt))
+	}
+	for _, c := range containers {
+		items.containers[c.ID] = models.EPC(c.UID, c.SSCC)
+		events = append(events, commissioningEvent(items.containers[c.ID], models.LabelContainer, c.UID, c.CreatedAt))
+	}
+	return items.document(ctx, events, actions, assignments)
+}
+
+// ExportItem gets the events of a pallet or a container, with the loading and unloading of pallets
+func (s *EPCISService) ExportItem(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*epcis.Document, *faulterr.FaultErr) {
+	items := s.items()
+
+	pallet, err := s.dbstore.PalletStore.GetByUID(ctx, uid)
+	if err == nil {
+		if !auther.IsAdmin && auther.OrganizationID.Int64 != pallet.OrganizationID.Int64 {
+			return nil, faulterr.NewNotFoundError("no item found")
+		}
+		actions, err := s.dbstore.TrackActionStore.ListByPalletID(ctx, pallet.ID)
+		if err != nil {
+			return nil, err
+		}
+		assignments, err := s.dbstore.PalletAssignmentStore.ListByPalletID(ctx, pallet.ID)
+		if err != nil {
+			return nil, err
+		}
+		items.pallets[pallet.ID] = models.EPC(pallet.UID, pallet.SSCC)
+		events := []epcis.Event{commissioningEvent(items.pallets[pallet.ID], models.LabelPallet, pallet.UID, pallet.CreatedAt)}
+		return items.document(ctx, events, actions, assignments)
+	}
+	if err.Status != http.StatusNotFound {
+		return nil, err
+	}
+
+	container, err := s.dbstore.ContainerStore.GetByUID(ctx, uid)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, faulterr.NewNotFoundError("no item found")
+		}
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != container.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no item found")
+	}
+	actions, err := s.dbstore.TrackActionStore.ListByContainerID(ctx, container.ID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.dbstore.PalletAssignmentStore.ListByContainerID(ctx, container.ID)
+	if err != nil {
+		return nil, err
+	}
+	items.containers[container.ID] = models.EPC(container.UID, container.SSCC)
+	events := []epcis.Event{commissioningEvent(items.containers[container.ID], models.LabelContainer, container.UID, container.CreatedAt)}
+	return items.document(ctx, events, actions, assignments)
+}
+
+// Capture stores the events of a partner document, events with a business step known as a track action
+// are also added to the timelines of the pallets and containers of the organization they refer to.
+// A document is captured as a whole, or not at all when one of its events is not valid
+func (s *EPCISService) Capture(ctx context.Context, doc *epcis.Document, auther *models.Auther) (*models.EPCISCaptureResult, *faulterr.FaultErr) {
+	if len(doc.Events) == 0 {
+		return nil, faulterr.NewBadRequestError("At least one event is required")
+	}
+	if len(doc.Events) > models.EPCISCaptureLimit {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("At most %d events can be captured at once", models.EPCISCaptureLimit))
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	result := &models.EPCISCaptureResult{}
+	seen := map[string]bool{}
+	for i, event := range doc.Events {
+		if event.Type == "" || event.EventTime.IsZero() {
+			return nil, faulterr.NewBadRequestError(fmt.Sprintf("Event %d needs a type and an event time", i+1))
+		}
+
+		eventID := event.EventID
+		if eventID == "" {
+			id, hashErr := epcis.HashID(event)
+			if hashErr != nil {
+				return nil, faulterr.NewBadRequestError(hashErr.Error())
+			}
+			eventID = id
+		}
+		if seen[eventID] {
+			result.Duplicates++
+			continue
+		}
+		seen[eventID] = true
+
+		document, docErr := eventDocument(event)
+		if docErr != nil {
+			return nil, faulterr.NewBadRequestError(docErr.Error())
+		}
+		obj := models.EPCISEvent{
+			EventID:        eventID,
+			EventType:      event.Type,
+			EventTime:      event.EventTime.UTC(),
+			Document:       document,
+			OrganizationID: auther.OrganizationID,
+			CapturedByID:   auther.ID,
+		}
+		captured, err := s.dbstore.EPCISEventStore.Insert(ctx, tx, obj)
+		if err != nil {
+			return nil, err
+		}
+		if captured == nil {
+			result.Duplicates++
+			continue
+		}
+		result.Captured++
+
+		action, epcs := capturedTrackAction(event)
+		if action == "" {
+			continue
+		}
+		for _, epc := range epcs {
+			palletID, containerID, err := s.resolve(ctx, epc, auther)
+			if err != nil {
+				return nil, err
+			}
+			if !palletID.Valid && !containerID.Valid {
+				continue
+			}
+
+			request := models.TrackActionRequest{
+				Action:      action,
+				ContainerID: containerID,
+				PalletID:    palletID,
+				Location:    eventLocation(event),
+				Payload:     map[string]interface{}{"epcisEventID": eventID, "bizStep": event.BizStep},
+				OccurredAt:  null.TimeFrom(event.EventTime.UTC()),
+			}
+			if !auther.IsAdmin {
+				request.OrganizationID = auther.OrganizationID
+			}
+			if _, err := s.master.TrackActionMaster.Create(ctx, tx, request, auther.ID); err != nil {
+				return nil, err
+			}
+			result.TrackActions++
+		}
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resolve finds the pallet or container of the organization an epc refers to, neither when it is not one of them
+func (s *EPCISService) resolve(ctx context.Context, epc string, auther *models.Auther) (null.Int64, null.Int64, *faulterr.FaultErr) {
+	sscc, uid, ok := models.ParseEPC(epc)
+	if !ok {
+		return null.Int64{}, null.Int64{}, nil
+	}
+
+	var (
+		pallet *models.Pallet
+		err    *faulterr.FaultErr
+	)
+	if sscc != "" {
+		pallet, err = s.dbstore.PalletStore.GetBySSCC(ctx, sscc)
+	} else {
+		pallet, err = s.dbstore.PalletStore.GetByUID(ctx, uid)
+	}
+	if err == nil {
+		if !auther.IsAdmin && auther.OrganizationID.Int64 != pallet.OrganizationID.Int64 {
+			return null.Int64{}, null.Int64{}, nil
+		}
+		return null.Int64From(pallet.ID), null.Int64{}, nil
+	}
+	if err.Status != http.StatusNotFound {
+		return null.Int64{}, null.Int64{}, err
+	}
+
+	var container *models.Container
+	if sscc != "" {
+		container, err = s.dbstore.ContainerStore.GetBySSCC(ctx, sscc)
+	} else {
+		container, err = s.dbstore.ContainerStore.GetByUID(ctx, uid)
+	}
+	if err == nil {
+		if !auther.IsAdmin && auther.OrganizationID.Int64 != container.OrganizationID.Int64 {
+			return null.Int64{}, null.Int64{}, nil
+		}
+		return null.Int64{}, null.Int64From(container.ID), nil
+	}
+	if err.Status != http.StatusNotFound {
+		return null.Int64{}, null.Int64{}, err
+	}
+	return null.Int64{}, null.Int64{}, nil
+}
+
+func (s *EPCISService) items() *epcisItems {
+	return &epcisItems{s.dbstore, map[int64]string{}, map[int64]string{}}
+}
+
+// document adds the events of track actions and pallet assignments to commissioning events, in time order
+func (i *epcisItems) document(
+	ctx context.Context,
+	events []epcis.Event,
+	actions []models.TrackAction,
+	assignments []models.PalletAssignment,
+) (*epcis.Document, *faulterr.FaultErr) {
+	for _, a := range actions {
+		var (
+			epc string
+			err *faulterr.FaultErr
+		)
+		if a.PalletID.Valid {
+			epc, err = i.pallet(ctx, a.PalletID.Int64)
+		} else {
+			epc, err = i.container(ctx, a.ContainerID.Int64)
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, epcis.Event{
+			Type:           epcis.ObjectEvent,
+			EventID:        "urn:uuid:" + a.UID.String(),
+			EventTime:      a.OccurredAt.UTC(),
+			TimeZoneOffset: "+00:00",
+			Action:         epcis.ActionObserve,
+			BizStep:        models.EPCISBizSteps[a.Action],
+			EPCList:        []string{epc},
+			Location:       a.Location,
+		})
+	}
+
+	for _, a := range assignments {
+		pallet, err := i.pallet(ctx, a.PalletID)
+		if err != nil {
+			return nil, err
+		}
+		container, err := i.container(ctx, a.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, aggregationEvent(a.ID, "loaded", epcis.ActionAdd, models.BizStepLoading, container, pallet, a.LoadedAt))
+		if a.UnloadedAt.Valid {
+			events = append(events, aggregationEvent(a.ID, "unloaded", epcis.ActionDelete, models.BizStepUnloading, container, pallet, a.UnloadedAt.Time))
+		}
+	}
+
+	sort.SliceStable(events, func(x, y int) bool { return events[x].EventTime.Before(events[y].EventTime) })
+	return &epcis.Document{CreationDate: time.Now().UTC(), Events: events}, nil
+}
+
+func (i *epcisItems) pallet(ctx context.Context, id int64) (string, *faulterr.FaultErr) {
+	if epc, ok := i.pallets[id]; ok {
+		return epc, nil
+	}
+	pallet, err := i.dbstore.PalletStore.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	i.pallets[id] = models.EPC(pallet.UID, pallet.SSCC)
+	return i.pallets[id], nil
+}
+
+func (i *epcisItems) container(ctx context.Context, id int64) (string, *faulterr.FaultErr) {
+	if epc, ok := i.containers[id]; ok {
+		return epc, nil
+	}
+	container, err := i.dbstore.ContainerStore.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	i.containers[id] = models.EPC(container.UID, container.SSCC)
+	return i.containers[id], nil
+}
+
+// commissioningEvent is the creation of a pallet or container
+func commissioningEvent(epc string, kind string, uid uuid.UUID, createdAt time.Time) epcis.Event {
+	return epcis.Event{
+		Type:           epcis.ObjectEvent,
+		EventID:        derivedEventID(fmt.Sprintf("%ss/%s/commissioned", kind, uid)),
+		EventTime:      createdAt.UTC(),
+		TimeZoneOffset: "+00:00",
+		Action:         epcis.ActionAdd,
+		BizStep:        models.BizStepCommissioning,
+		EPCList:        []string{epc},
+	}
+}
+
+// aggregationEvent is the packing of a pallet into a container, or its unpacking
+func aggregationEvent(assignmentID int64, what string, action string, bizStep string, container string, pallet string, at time.Time) epcis.Event {
+	return epcis.Event{
+		Type:           epcis.AggregationEvent,
+		EventID:        derivedEventID(fmt.Sprintf("pallet-assignments/%d/%s", assignmentID, what)),
+		EventTime:      at.UTC(),
+		TimeZoneOffset: "+00:00",
+		Action:         action,
+		BizStep:        bizStep,
+		ParentID:       container,
+		ChildEPCs:      []string{pallet},
+	}
+}
+
+// derivedEventID gives events which are not stored as such the same id on every export
+func derivedEventID(path string) string {
+	return "urn:uuid:" + uuid.NewV5(uuid.NamespaceURL, epcis.Namespace+path).String()
+}
+
+// capturedTrackAction gets the track action a captured event is recorded as, and the epcs it is recorded on.
+// Aggregations are recorded on the parent and its children, as loading when they have no business step
+func capturedTrackAction(e epcis.Event) (string, []string) {
+	if e.Action == epcis.ActionDelete {
+		return "", nil
+	}
+	switch e.Type {
+	case epcis.ObjectEvent:
+		return models.EPCISTrackActions[e.BizStep], e.EPCList
+	case epcis.AggregationEvent:
+		step := e.BizStep
+		if step == "" && e.Action == epcis.ActionAdd {
+			step = models.BizStepLoading
+		}
+		epcs := e.ChildEPCs
+		if e.ParentID != "" {
+			epcs = append([]string{e.ParentID}, epcs...)
+		}
+		return models.EPCISTrackActions[step], epcs
+	}
+	return "", nil
+}
+
+// eventLocation gets where a captured event happened, its business location or else its read point
+func eventLocation(e epcis.Event) string {
+	switch {
+	case e.Location != "":
+		return e.Location
+	case e.BizLocation != "":
+		return e.BizLocation
+	}
+	return e.ReadPoint
+}
+
+// eventDocument keeps a captured event as EPCIS 2.0 JSON-LD
+func eventDocument(e epcis.Event) (map[string]interface{}, error) {
+	b, err := epcis.EventJSON(e)
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(b, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
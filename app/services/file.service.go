@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+)
+
+type FileService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ FileServiceInterface = &FileService{}
+
+type FileServiceInterface interface {
+	ListByOwner(ctx context.Context, ownerType string, ownerID int64, auther *models.Auther) ([]models.StoredFile, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr)
+	Create(ctx context.Context, obj models.StoredFile, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr)
+	Attach(ctx context.Context, id int64, ownerType string, ownerID int64, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr)
+	Detach(ctx context.Context, id int64, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr)
+}
+
+func NewFileService(s *dbstore.DBStore, m *master.Master) *FileService {
+	return &FileService{s, m}
+}
+
+// ListByOwner gets the files attached to an organization, container or pallet,
+// users outside of its organization do not see them even when they can see their owner
+func (s *FileService) ListByOwner(ctx context.Context, ownerType string, ownerID int64, auther *models.Auther) ([]models.StoredFile, *faulterr.FaultErr) {
+	files, err := s.dbstore.StoredFileStore.ListByOwner(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if auther.IsAdmin {
+		return files, nil
+	}
+
+	visible := []models.StoredFile{}
+	for _, file := range files {
+		if file.OrganizationID.Int64 == auther.OrganizationID.Int64 {
+			visible = append(visible, file)
+		}
+	}
+	return visible, nil
+}
+
+// GetByID gets a file of the organization of the user
+func (s *FileService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr) {
+	file, err := s.dbstore.StoredFileStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != file.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no file found")
+	}
+	return file, nil
+}
+
+// Create records a file uploaded to the filestore, it belongs to the organization of the user uploading it
+func (s *FileService) Create(ctx context.Context, obj models.StoredFile, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr) {
+	obj.OrganizationID = auther.OrganizationID
+	obj.UploadedByID = auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	file, err := s.master.FileMaster.Record(ctx, tx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Attach attaches a file to an organization, container or pallet, moving it off its current owner
+func (s *FileService) Attach(ctx context.Context, id int64, ownerType string, ownerID int64, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr) {
+	file, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	file, err = s.master.FileMaster.Attach(ctx, tx, file, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Detach takes a file off the organization, container or pallet it is attached to
+func (s *FileService) Detach(ctx context.Context, id int64, auther *models.Auther) (*models.StoredFile, *faulterr.FaultErr) {
+	file, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	file, err = s.master.FileMaster.Detach(ctx, tx, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
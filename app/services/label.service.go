@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+// VerifyBaseURL is where scanned label QR codes lead, set from the configuration on start
+var VerifyBaseURL = "https://blockchain.orijinplus.com.au"
+
+type LabelService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ LabelServiceInterface = &LabelService{}
+
+type LabelServiceInterface interface {
+	Labels(ctx context.Context, kind string, ids []int64, auther *models.Auther) ([]models.Label, *faulterr.FaultErr)
+	LabelByUID(ctx context.Context, kind string, uid uuid.UUID, auther *models.Auther) (*models.Label, *faulterr.FaultErr)
+}
+
+func NewLabelService(s *dbstore.DBStore, m *master.Master) *LabelService {
+	return &LabelService{s, m}
+}
+
+// Labels gets the labels of pallets, containers or skus of the organization in the order of the ids
+func (s *LabelService) Labels(ctx context.Context, kind string, ids []int64, auther *models.Auther) ([]models.Label, *faulterr.FaultErr) {
+	if len(ids) == 0 {
+		return nil, faulterr.NewBadRequestError("At least one id is required")
+	}
+	if len(ids) > models.BulkCreateLimit {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("At most %d labels can be made at once", models.BulkCreateLimit))
+	}
+
+	labels := map[int64]models.Label{}
+	orgIDs := map[int64]int64{}
+	switch kind {
+	case models.LabelPallet:
+		objs, err := s.dbstore.PalletStore.GetMany(ctx, ids)
+		if err != nil {
+			return nil, faulterr.NewPostgresError(err, "error when trying to get pallets")
+		}
+		for _, obj := range objs {
+			labels[obj.ID], orgIDs[obj.ID] = PalletLabels([]models.Pallet{*obj})[0], obj.OrganizationID.Int64
+		}
+	case models.LabelContainer:
+		objs, err := s.dbstore.ContainerStore.GetMany(ctx, ids)
+		if err != nil {
+			return nil, faulterr.NewPostgresError(err, "error when trying to get containers")
+		}
+		for _, obj := range objs {
+			labels[obj.ID], orgIDs[obj.ID] = ContainerLabels([]models.Container{*obj})[0], obj.OrganizationID.Int64
+		}
+	case models.LabelSku:
+		objs, err := s.dbstore.SkuStore.GetMany(ctx, ids)
+		if err != nil {
+			return nil, faulterr.NewPostgresError(err, "error when trying to get skus")
+		}
+		for _, obj := range objs {
+			labels[obj.ID], orgIDs[obj.ID] = SkuLabels([]models.Sku{*obj})[0], obj.OrganizationID
+		}
+	default:
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Unknown label kind %s", kind))
+	}
+
+	result := make([]models.Label, 0, len(ids))
+	for _, id := range ids {
+		label, ok := labels[id]
+		if !ok || (!auther.IsAdmin && auther.OrganizationID.Int64 != orgIDs[id]) {
+			return nil, faulterr.NewNotFoundError("no object found")
+		}
+		result = append(result, label)
+	}
+	return result, nil
+}
+
+// LabelByUID gets the label of a pallet, container or sku of the organization
+func (s *LabelService) LabelByUID(ctx context.Context, kind string, uid uuid.UUID, auther *models.Auther) (*models.Label, *faulterr.FaultErr) {
+	var (
+		label models.Label
+		orgID int64
+	)
+	switch kind {
+	case models.LabelPallet:
+		obj, err := s.dbstore.PalletStore.GetByUID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		label, orgID = PalletLabels([]models.Pallet{*obj})[0], obj.OrganizationID.Int64
+	case models.LabelContainer:
+		obj, err := s.dbstore.ContainerStore.GetByUID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		label, orgID = ContainerLabels([]models.Container{*obj})[0], obj.OrganizationID.Int64
+	case models.LabelSku:
+		obj, err := s.dbstore.SkuStore.GetByUID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		label, orgID = SkuLabels([]models.Sku{*obj})[0], obj.OrganizationID
+	default:
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Unknown label kind %s", kind))
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != orgID {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return &label, nil
+}
+
+// VerifyURL is the public page a scanned label leads to, skus are shown as products
+func VerifyURL(kind string, uid string) string {
+	if kind == models.LabelSku {
+		kind = "product"
+	}
+	return fmt.Sprintf("%s/%s/view?%s", strings.TrimRight(VerifyBaseURL, "/"), kind, uid)
+}
+
+// DigitalLinkURL is the GS1 Digital Link of a GS1 key, on the public page scanned labels lead to
+func DigitalLinkURL(ai string, key string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimRight(VerifyBaseURL, "/"), ai, key)
+}
+
+// gs1Label makes a label carry a GS1 key, its barcode becomes GS1-128 and its QR code a GS1 Digital Link
+func gs1Label(label models.Label, ai string, key null.String) models.Label {
+	if key.Valid {
+		label.GS1AI, label.GS1Key = ai, key.String
+		label.URL = DigitalLinkURL(ai, key.String)
+	}
+	return label
+}
+
+// PalletLabels gets the printable labels of pallets
+func PalletLabels(pallets []models.Pallet) []models.Label {
+	labels := make([]models.Label, 0, len(pallets))
+	for _, p := range pallets {
+		label := models.Label{Code: p.Code, UID: p.UID.String(), Description: p.Description, URL: VerifyURL(models.LabelPallet, p.UID.String())}
+		labels = append(labels, gs1Label(label, models.AISSCC, p.SSCC))
+	}
+	return labels
+}
+
+// ContainerLabels gets the printable labels of containers
+func ContainerLabels(containers []models.Container) []models.Label {
+	labels := make([]models.Label, 0, len(containers))
+	for _, c := range containers {
+		label := models.Label{Code: c.Code, UID: c.UID.String(), Description: c.Description, URL: VerifyURL(models.LabelContainer, c.UID.String())}
+		labels = append(labels, gs1Label(label, models.AISSCC, c.SSCC))
+	}
+	return labels
+}
+
+// SkuLabels gets the printable labels of skus
+func SkuLabels(skus []models.Sku) []models.Label {
+	labels := make([]models.Label, 0, len(skus))
+	for _, k := range skus {
+		label := models.Label{Code: k.Code, UID: k.UID.String(), Description: k.Name, URL: VerifyURL(models.LabelSku, k.UID.String())}
+		labels = append(labels, gs1Label(label, models.AIGTIN, k.GTIN))
+	}
+	return labels
+}
+
+// LabelSheetCSV writes labels as a csv sheet which can be merged into label printing software
+func LabelSheetCSV(labels []models.Label) ([]byte, *faulterr.FaultErr) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"code", "uid", "description", "url", "gs1"}); err != nil {
+		return nil, faulterr.NewInternalServerError(err.Error())
+	}
+	for _, l := range labels {
+		if err := w.Write([]string{l.Code, l.UID, l.Description, l.URL, l.GS1Text()}); err != nil {
+			return nil, faulterr.NewInternalServerError(err.Error())
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, faulterr.NewInternalServerError(err.Error())
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"orijinplus/app/models"
+	"orijinplus/utils/barcode"
+	"orijinplus/utils/faulterr"
+	"orijinplus/utils/pdf"
+	"strconv"
+)
+
+// Symbologies printed on labels
+const (
+	SymbolQR      = "qr"
+	SymbolCode128 = "code128"
+)
+
+// labelPadding is the blank border kept inside every label, in millimetres
+const labelPadding = 2
+
+// labelLayout is where the parts of a label go, in millimetres from the top left corner of the label
+type labelLayout struct {
+	qr     *barcode.Symbol
+	qrBox  [4]float64
+	bar    *barcode.Symbol
+	barBox [4]float64
+	texts  []labelText
+}
+
+type labelText struct {
+	x, y float64 // baseline
+	size float64
+	text string
+}
+
+// LabelTemplate gets a built-in template by name, the default one when no name is given
+func LabelTemplate(name string) (models.LabelTemplate, *faulterr.FaultErr) {
+	if name == "" {
+		name = models.DefaultLabelTemplate
+	}
+	t, ok := models.LabelTemplates[name]
+	if !ok {
+		return t, faulterr.NewBadRequestError(fmt.Sprintf("Unknown label template %s", name))
+	}
+	return t, nil
+}
+
+// LabelSymbol renders the QR code or the Code128 barcode of a label as png or svg,
+// the barcode is GS1-128 for labels carrying a GS1 key
+func LabelSymbol(label models.Label, symbology string, format string) ([]byte, *faulterr.FaultErr) {
+	symbol, err := labelSymbol(label, symbology)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case models.LabelPNG:
+		// Code128 modules are thin, so they are drawn wider than QR ones to stay readable
+		scale := 8
+		if symbology == SymbolCode128 {
+			scale = 3
+		}
+		b, pngErr := symbol.PNG(scale)
+		if pngErr != nil {
+			return nil, faulterr.NewInternalServerError(pngErr.Error())
+		}
+		return b, nil
+	case models.LabelSVG:
+		return symbol.SVG(), nil
+	}
+	return nil, faulterr.NewBadRequestError(fmt.Sprintf("Unknown format %s", format))
+}
+
+// LabelSVG renders a single label of a template as svg, sized in millimetres
+func LabelSVG(label models.Label, t models.LabelTemplate) ([]byte, *faulterr.FaultErr) {
+	layout, err := newLabelLayout(label, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s" shape-rendering="crispEdges">`,
+		fnum(t.LabelWidth), fnum(t.LabelHeight), fnum(t.LabelWidth), fnum(t.LabelHeight))
+	fmt.Fprintf(&buf, `<rect width="%s" height="%s" fill="#fff"/>`, fnum(t.LabelWidth), fnum(t.LabelHeight))
+	buf.WriteString(`<path fill="#000" d="`)
+	drawSymbol(layout.qr, layout.qrBox, func(x, y, w, h float64) {
+		fmt.Fprintf(&buf, "M%s %sh%sv%sh-%sz", fnum(x), fnum(y), fnum(w), fnum(h), fnum(w))
+	})
+	drawSymbol(layout.bar, layout.barBox, func(x, y, w, h float64) {
+		fmt.Fprintf(&buf, "M%s %sh%sv%sh-%sz", fnum(x), fnum(y), fnum(w), fnum(h), fnum(w))
+	})
+	buf.WriteString(`"/>`)
+	for _, text := range layout.texts {
+		fmt.Fprintf(&buf, `<text x="%s" y="%s" font-family="monospace" font-size="%s">%s</text>`,
+			fnum(text.x), fnum(text.y), fnum(text.size), html.EscapeString(text.text))
+	}
+	buf.WriteString(`</svg>`)
+	return buf.Bytes(), nil
+}
+
+// LabelSheetPDF lays labels out on the pages of a template, row by row
+func LabelSheetPDF(labels []models.Label, t models.LabelTemplate) ([]byte, *faulterr.FaultErr) {
+	doc := pdf.NewSized(t.PageWidth*pdf.MM, t.PageHeight*pdf.MM)
+	perPage := t.Columns * t.Rows
+	for i, label := range labels {
+		if i%perPage == 0 {
+			doc.PageBreak()
+		}
+		layout, err := newLabelLayout(label, t)
+		if err != nil {
+			return nil, err
+		}
+
+		cell := i % perPage
+		left := t.MarginLeft + float64(cell%t.Columns)*(t.LabelWidth+t.GapX)
+		top := t.MarginTop + float64(cell/t.Columns)*(t.LabelHeight+t.GapY)
+		rect := func(x, y, w, h float64) {
+			doc.Rect((left+x)*pdf.MM, (top+y)*pdf.MM, w*pdf.MM, h*pdf.MM)
+		}
+		drawSymbol(layout.qr, layout.qrBox, rect)
+		drawSymbol(layout.bar, layout.barBox, rect)
+		for _, text := range layout.texts {
+			doc.Text((left+text.x)*pdf.MM, (top+text.y)*pdf.MM, text.size*pdf.MM, text.text)
+		}
+	}
+	return doc.Bytes(), nil
+}
+
+// newLabelLayout puts the QR code on the left of the label, and the code, the barcode, the
+// description and the element string of GS1 keys on top of each other on its right
+func newLabelLayout(label models.Label, t models.LabelTemplate) (*labelLayout, *faulterr.FaultErr) {
+	layout := &labelLayout{}
+	innerW := t.LabelWidth - 2*labelPadding
+	innerH := t.LabelHeight - 2*labelPadding
+	left := float64(labelPadding)
+
+	if t.ShowQR {
+		symbol, err := labelSymbol(label, SymbolQR)
+		if err != nil {
+			return nil, err
+		}
+		side := innerH
+		if t.ShowBarcode || t.ShowText {
+			side = math.Min(side, innerW/2)
+		} else {
+			side = math.Min(side, innerW)
+		}
+		layout.qr = symbol
+		layout.qrBox = [4]float64{left, labelPadding + (innerH-side)/2, side, side}
+		left += side + labelPadding
+	}
+
+	width := t.LabelWidth - labelPadding - left
+	top := float64(labelPadding)
+	bottom := t.LabelHeight - labelPadding
+	codeSize := math.Min(4, innerH/5)
+	if t.ShowText {
+		layout.texts = append(layout.texts, fitText(left, top+codeSize, codeSize, width, label.Code))
+		top += codeSize + 1
+		descSize := codeSize * 0.75
+		if text := label.GS1Text(); text != "" {
+			layout.texts = append(layout.texts, fitText(left, bottom, descSize, width, text))
+			bottom -= descSize + 1
+		}
+		if label.Description != "" {
+			layout.texts = append(layout.texts, fitText(left, bottom, descSize, width, label.Description))
+			bottom -= descSize + 1
+		}
+	}
+	if t.ShowBarcode && bottom > top {
+		symbol, err := labelSymbol(label, SymbolCode128)
+		if err != nil {
+			return nil, err
+		}
+		layout.bar = symbol
+		layout.barBox = [4]float64{left, top, width, bottom - top}
+	}
+	return layout, nil
+}
+
+// fitText cuts a text to the characters fitting in a width
+func fitText(x, y, size, width float64, text string) labelText {
+	runes := []rune(text)
+	if max := int(width / (size * 0.6)); len(runes) > max {
+		if max < 0 {
+			max = 0
+		}
+		runes = runes[:max]
+	}
+	return labelText{x: x, y: y, size: size, text: string(runes)}
+}
+
+// drawSymbol scales the dark modules of a symbol, with its quiet zone, to a box
+func drawSymbol(s *barcode.Symbol, box [4]float64, rect func(x, y, w, h float64)) {
+	if s == nil {
+		return
+	}
+	mx := box[2] / float64(s.TotalWidth())
+	my := box[3] / float64(s.TotalHeight())
+	for _, r := range s.Rects() {
+		rect(box[0]+float64(s.QuietX+r.X)*mx, box[1]+float64(s.QuietY+r.Y)*my, float64(r.W)*mx, float64(r.H)*my)
+	}
+}
+
+// labelSymbol encodes the verification url of a label as a QR code, or its code as a Code128 barcode,
+// its GS1 key as a GS1-128 barcode when it has one
+func labelSymbol(label models.Label, symbology string) (*barcode.Symbol, *faulterr.FaultErr) {
+	var (
+		symbol *barcode.Symbol
+		err    error
+	)
+	switch symbology {
+	case SymbolQR:
+		symbol, err = barcode.QR(label.URL)
+	case SymbolCode128:
+		if label.GS1AI != "" {
+			symbol, err = barcode.GS1128(label.GS1AI, label.GS1Key)
+		} else {
+			symbol, err = barcode.Code128(label.Code)
+		}
+	default:
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("Unknown symbology %s", symbology))
+	}
+	if err != nil {
+		return nil, faulterr.NewBadRequestError(fmt.Sprintf("%s cannot be encoded: %s", label.Code, err.Error()))
+	}
+	return symbol, nil
+}
+
+// fnum writes a number with at most three decimals
+func fnum(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
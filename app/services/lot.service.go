@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+// MaxExpiringDays is the furthest ahead lots expiring can be looked up
+const MaxExpiringDays = 3650
+
+// DefaultFEFOLimit is the number of pallets suggested when no limit is given
+const DefaultFEFOLimit = 20
+
+type LotService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ LotServiceInterface = &LotService{}
+
+type LotServiceInterface interface {
+	ListBySkuID(ctx context.Context, skuID int64, auther *models.Auther) ([]models.Lot, *faulterr.FaultErr)
+	ListExpiring(ctx context.Context, days int, auther *models.Auther) ([]models.Lot, *faulterr.FaultErr)
+	ListFEFO(ctx context.Context, skuID int64, limit int, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr)
+	ListPallets(ctx context.Context, id int64, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Lot, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.LotRequest, auther *models.Auther) (*models.Lot, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.LotRequest, auther *models.Auther) (*models.Lot, *faulterr.FaultErr)
+	AssignPallets(ctx context.Context, id int64, palletIDs []int64, auther *models.Auther) (*models.Lot, *faulterr.FaultErr)
+	UnassignPallet(ctx context.Context, id int64, palletID int64, auther *models.Auther) (*models.Lot, *faulterr.FaultErr)
+	FlagExpired(ctx context.Context) ([]models.Lot, *faulterr.FaultErr)
+}
+
+func NewLotService(s *dbstore.DBStore, m *master.Master) *LotService {
+	return &LotService{s, m}
+}
+
+// ListBySkuID gets the lots of a SKU, oldest first
+func (s *LotService) ListBySkuID(ctx context.Context, skuID int64, auther *models.Auther) ([]models.Lot, *faulterr.FaultErr) {
+	if _, err := s.sku(ctx, skuID, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.LotStore.ListBySkuID(ctx, skuID)
+}
+
+// ListExpiring gets the lots which expire within the given number of days, soonest first
+func (s *LotService) ListExpiring(ctx context.Context, days int, auther *models.Auther) ([]models.Lot, *faulterr.FaultErr) {
+	if days < 0 || days > MaxExpiringDays {
+		return nil, faulterr.NewBadRequestError("days must be between 0 and 3650")
+	}
+
+	orgID := auther.OrganizationID
+	if auther.IsAdmin {
+		orgID = null.Int64{}
+	}
+	now := time.Now()
+	return s.dbstore.LotStore.ListExpiring(ctx, orgID, now, now.AddDate(0, 0, days))
+}
+
+// ListFEFO suggests the pallets of a SKU to pick first, those of the lots expiring soonest
+func (s *LotService) ListFEFO(ctx context.Context, skuID int64, limit int, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr) {
+	sku, err := s.sku(ctx, skuID, auther)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > models.BulkCreateLimit {
+		limit = DefaultFEFOLimit
+	}
+	return s.dbstore.PalletStore.ListFEFO(ctx, sku.ID, sku.OrganizationID, limit)
+}
+
+// ListPallets gets the pallets produced in a lot
+func (s *LotService) ListPallets(ctx context.Context, id int64, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.PalletStore.ListByLotID(ctx, id)
+}
+
+func (s *LotService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Lot, *faulterr.FaultErr) {
+	obj, err := s.dbstore.LotStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return obj, nil
+}
+
+func (s *LotService) Create(ctx context.Context, r models.LotRequest, auther *models.Auther) (*models.Lot, *faulterr.FaultErr) {
+	if r.SkuID <= 0 {
+		return nil, faulterr.NewBadRequestError("SKU is required")
+	}
+	// The lot belongs to the organization of its SKU
+	sku, err := s.sku(ctx, r.SkuID, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.LotMaster.Create(ctx, tx, r, sku.OrganizationID, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *LotService) Update(ctx context.Context, id int64, r models.LotRequest, auther *models.Auther) (*models.Lot, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.LotMaster.Update(ctx, tx, id, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// AssignPallets puts pallets in a lot
+func (s *LotService) AssignPallets(ctx context.Context, id int64, palletIDs []int64, auther *models.Auther) (*models.Lot, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.LotMaster.AssignPallets(ctx, tx, id, palletIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// UnassignPallet takes a pallet out of a lot
+func (s *LotService) UnassignPallet(ctx context.Context, id int64, palletID int64, auther *models.Auther) (*models.Lot, *faulterr.FaultErr) {
+	obj, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.master.LotMaster.UnassignPallet(ctx, tx, id, palletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// FlagExpired flags the lots which reached their expiry date, it is run by the expiry job
+func (s *LotService) FlagExpired(ctx context.Context) ([]models.Lot, *faulterr.FaultErr) {
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	lots, err := s.master.LotMaster.FlagExpired(ctx, tx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+// sku gets a SKU the user can see
+func (s *LotService) sku(ctx context.Context, skuID int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	sku, err := s.dbstore.SkuStore.GetByID(ctx, skuID)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != sku.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no sku found with given sku id")
+	}
+	return sku, nil
+}
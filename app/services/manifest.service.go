@@ -0,0 +1,91 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"orijinplus/utils/pdf"
+	"strconv"
+	"strings"
+)
+
+// ManifestCSV writes a manifest with one row per pallet, empty containers get a row without pallet
+func ManifestCSV(m *models.Manifest) ([]byte, *faulterr.FaultErr) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"shipment", "container", "container status", "pallet", "pallet uid", "pallet description"}}
+	for _, c := range m.Containers {
+		if len(c.Pallets) == 0 {
+			rows = append(rows, []string{m.Shipment.Code, c.Code, c.Status, "", "", ""})
+		}
+		for _, p := range c.Pallets {
+			rows = append(rows, []string{m.Shipment.Code, c.Code, c.Status, p.Code, p.UID, p.Description})
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, faulterr.NewInternalServerError(err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+// ManifestPDF writes a printable manifest with the route of the shipment followed by its containers
+func ManifestPDF(m *models.Manifest) []byte {
+	doc := pdf.New()
+	s := m.Shipment
+
+	doc.Line("SHIPPING MANIFEST " + s.Code)
+	doc.Line(strings.Repeat("=", pdf.CharsPerLine))
+	doc.Line("Status:     " + s.Status)
+	doc.Line("Carrier:    " + s.Carrier)
+	doc.Line("Reference:  " + s.TransportReference)
+	doc.Line("From:       " + formatAddress(m.Origin))
+	doc.Line("To:         " + formatAddress(m.Destination))
+	if s.ETD.Valid {
+		doc.Line("ETD:        " + s.ETD.Time.Format("2006-01-02 15:04"))
+	}
+	if s.ETA.Valid {
+		doc.Line("ETA:        " + s.ETA.Time.Format("2006-01-02 15:04"))
+	}
+	if s.DepartedAt.Valid {
+		doc.Line("Departed:   " + s.DepartedAt.Time.Format("2006-01-02 15:04"))
+	}
+	if s.ArrivedAt.Valid {
+		doc.Line("Arrived:    " + s.ArrivedAt.Time.Format("2006-01-02 15:04"))
+	}
+	if s.Notes != "" {
+		doc.Line("Notes:      " + s.Notes)
+	}
+
+	pallets := 0
+	for _, c := range m.Containers {
+		pallets += len(c.Pallets)
+	}
+	doc.Line("Containers: " + strconv.Itoa(len(m.Containers)) + ", pallets: " + strconv.Itoa(pallets))
+	doc.Line("Generated:  " + m.GeneratedAt.Format("2006-01-02 15:04 MST"))
+
+	for _, c := range m.Containers {
+		doc.Line("")
+		doc.Line(fmt.Sprintf("Container %s (%s) %s", c.Code, c.Status, c.Description))
+		doc.Line(strings.Repeat("-", pdf.CharsPerLine))
+		for _, p := range c.Pallets {
+			doc.Line(fmt.Sprintf("  %-12s %-36s %s", p.Code, p.UID, p.Description))
+		}
+		if len(c.Pallets) == 0 {
+			doc.Line("  no pallets")
+		}
+	}
+
+	return doc.Bytes()
+}
+
+func formatAddress(a models.Address) string {
+	parts := []string{}
+	for _, part := range []string{a.Line1, a.Line2, a.City, a.State, a.Pincode, a.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
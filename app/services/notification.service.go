@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+type NotificationService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ NotificationServiceInterface = &NotificationService{}
+
+type NotificationServiceInterface interface {
+	ListMine(ctx context.Context, auther *models.Auther) ([]models.Notification, *faulterr.FaultErr)
+	MarkRead(ctx context.Context, id int64, auther *models.Auther) (*models.Notification, *faulterr.FaultErr)
+}
+
+func NewNotificationService(s *dbstore.DBStore, m *master.Master) *NotificationService {
+	return &NotificationService{s, m}
+}
+
+// ListMine gets the notifications of the user and of the organization the user is a member of, newest first
+func (s *NotificationService) ListMine(ctx context.Context, auther *models.Auther) ([]models.Notification, *faulterr.FaultErr) {
+	return s.dbstore.NotificationStore.ListByRecipient(ctx, auther.ID, auther.OrganizationID)
+}
+
+// MarkRead marks a notification as read, for every member when it was sent to an organization
+func (s *NotificationService) MarkRead(ctx context.Context, id int64, auther *models.Auther) (*models.Notification, *faulterr.FaultErr) {
+	obj, err := s.dbstore.NotificationStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mine := obj.UserID.Valid && obj.UserID.Int64 == auther.ID
+	ours := obj.OrganizationID.Valid && auther.OrganizationID.Valid && obj.OrganizationID.Int64 == auther.OrganizationID.Int64
+	if !mine && !ours {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	if obj.ReadAt.Valid {
+		return obj, nil
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj.ReadAt = null.TimeFrom(time.Now())
+	if err := s.dbstore.NotificationStore.Update(ctx, tx, *obj); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type OrderService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ OrderServiceInterface = &OrderService{}
+
+type OrderServiceInterface interface {
+	List(ctx context.Context, status null.String, auther *models.Auther) ([]models.Order, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	ListItems(ctx context.Context, orderID int64) ([]models.OrderItem, *faulterr.FaultErr)
+	ListAllocations(ctx context.Context, orderID int64) ([]models.OrderAllocation, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.OrderRequest, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.OrderRequest, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	AddItem(ctx context.Context, request models.OrderItemRequest, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	UpdateItem(ctx context.Context, itemID int64, quantity int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	RemoveItem(ctx context.Context, itemID int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	Allocate(ctx context.Context, id int64, palletID int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	Deallocate(ctx context.Context, id int64, palletID int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewOrderService(s *dbstore.DBStore, m *master.Master) *OrderService {
+	return &OrderService{s, m}
+}
+
+// List gets all orders sold or bought by the auther's organization
+func (s *OrderService) List(ctx context.Context, status null.String, auther *models.Auther) ([]models.Order, *faulterr.FaultErr) {
+	var orders []models.Order
+	var err *faulterr.FaultErr
+	if auther.IsAdmin {
+		orders, err = s.dbstore.OrderStore.List(ctx)
+	} else {
+		orders, err = s.dbstore.OrderStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !status.Valid {
+		return orders, nil
+	}
+
+	result := []models.Order{}
+	for _, order := range orders {
+		if order.Status == status.String {
+			result = append(result, order)
+		}
+	}
+	return result, nil
+}
+
+func (s *OrderService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	obj, err := s.dbstore.OrderStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no order found")
+	}
+	return obj, nil
+}
+
+func (s *OrderService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	obj, err := s.dbstore.OrderStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no order found")
+	}
+	return obj, nil
+}
+
+func (s *OrderService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	obj, err := s.dbstore.OrderStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no order found")
+	}
+	return obj, nil
+}
+
+// ListItems gets the line items of an order
+func (s *OrderService) ListItems(ctx context.Context, orderID int64) ([]models.OrderItem, *faulterr.FaultErr) {
+	return s.dbstore.OrderItemStore.ListByOrderID(ctx, orderID)
+}
+
+// ListAllocations gets the pallets allocated to an order
+func (s *OrderService) ListAllocations(ctx context.Context, orderID int64) ([]models.OrderAllocation, *faulterr.FaultErr) {
+	return s.dbstore.OrderAllocationStore.ListByOrderID(ctx, orderID)
+}
+
+// Create saves an order object in db
+func (s *OrderService) Create(ctx context.Context, r models.OrderRequest, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+	createdByID := auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.OrderMaster.Create(ctx, tx, r, createdByID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *OrderService) Update(ctx context.Context, id int64, request models.OrderRequest, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	current, err := s.dbstore.OrderStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	request.OrganizationID = null.Int64From(current.OrganizationID)
+
+	order, err := s.master.OrderMaster.Update(ctx, tx, current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// UpdateStatus moves an order to the next status of its lifecycle
+func (s *OrderService) UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	// The status is checked on the locked order so allocations cannot be added to a cancelled order
+	current, err := s.dbstore.OrderStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.master.OrderMaster.UpdateStatus(ctx, tx, current, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// AddItem adds a sku line item to an order
+func (s *OrderService) AddItem(ctx context.Context, request models.OrderItemRequest, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, request.OrderID, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	order, err := s.dbstore.OrderStore.LockByID(ctx, tx, request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.master.OrderMaster.AddItem(ctx, tx, order, request); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// UpdateItem changes the quantity of an order line item
+func (s *OrderService) UpdateItem(ctx context.Context, itemID int64, quantity int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	item, err := s.dbstore.OrderItemStore.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getOwned(ctx, item.OrderID, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	order, err := s.dbstore.OrderStore.LockByID(ctx, tx, item.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.master.OrderMaster.UpdateItem(ctx, tx, order, item, quantity); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// RemoveItem deletes an order line item
+func (s *OrderService) RemoveItem(ctx context.Context, itemID int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	item, err := s.dbstore.OrderItemStore.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getOwned(ctx, item.OrderID, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	order, err := s.dbstore.OrderStore.LockByID(ctx, tx, item.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.master.OrderMaster.RemoveItem(ctx, tx, order, item); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Allocate assigns a serialised pallet to an order
+func (s *OrderService) Allocate(ctx context.Context, id int64, palletID int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	pallet, err := s.dbstore.PalletStore.GetByID(ctx, palletID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	order, err := s.dbstore.OrderStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.master.OrderMaster.Allocate(ctx, tx, order, pallet, auther.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Deallocate releases a pallet from an order
+func (s *OrderService) Deallocate(ctx context.Context, id int64, palletID int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	order, err := s.dbstore.OrderStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.master.OrderMaster.Deallocate(ctx, tx, order, palletID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *OrderService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.OrderStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.OrderStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Helpers
+
+// canView allows both the selling and the buying organization to see an order
+func (s *OrderService) canView(order *models.Order, auther *models.Auther) bool {
+	if auther.IsAdmin {
+		return true
+	}
+	orgID := auther.OrganizationID.Int64
+	return orgID == order.OrganizationID || orgID == order.BuyerOrganizationID
+}
+
+// getOwned gets an order that the auther's organization sells and may therefore change
+func (s *OrderService) getOwned(ctx context.Context, id int64, auther *models.Auther) (*models.Order, *faulterr.FaultErr) {
+	order, err := s.dbstore.OrderStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != order.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no order found")
+	}
+	return order, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"net/http"
 	"orijinplus/app/master"
 	"orijinplus/app/models"
 	"orijinplus/app/store/dbstore"
@@ -23,6 +24,14 @@ type OrganizationServiceInterface interface {
 	Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Organization, *faulterr.FaultErr)
 	Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Organization, *faulterr.FaultErr)
 	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+	ListCodeFormats(ctx context.Context, id int64, auther *models.Auther) ([]models.CodeFormat, *faulterr.FaultErr)
+	SetCodeFormat(ctx context.Context, request models.CodeFormat, auther *models.Auther) (*models.CodeFormat, *faulterr.FaultErr)
+	DeleteCodeFormat(ctx context.Context, id int64, entity string, auther *models.Auther) *faulterr.FaultErr
+	GetGS1Settings(ctx context.Context, id int64, auther *models.Auther) (*models.GS1Settings, *faulterr.FaultErr)
+	SetGS1Settings(ctx context.Context, request models.GS1Settings, auther *models.Auther) (*models.GS1Settings, *faulterr.FaultErr)
+	ListAttributeDefinitions(ctx context.Context, id int64, auther *models.Auther) ([]models.AttributeDefinition, *faulterr.FaultErr)
+	SetAttributeDefinition(ctx context.Context, request models.AttributeDefinition, auther *models.Auther) (*models.AttributeDefinition, *faulterr.FaultErr)
+	DeleteAttributeDefinition(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
 }
 
 func NewOrganizationService(s *dbstore.DBStore, m *master.Master) *OrganizationService {
@@ -155,3 +164,161 @@ func (s *OrganizationService) Delete(ctx context.Context, id int64, auther *mode
 
 	return nil
 }
+
+// ListCodeFormats gets the code formats an organization has set
+func (s *OrganizationService) ListCodeFormats(ctx context.Context, id int64, auther *models.Auther) ([]models.CodeFormat, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.CodeFormatStore.ListByOrgID(ctx, id)
+}
+
+// SetCodeFormat sets the format of the codes handed out to new records of an organization
+func (s *OrganizationService) SetCodeFormat(ctx context.Context, request models.CodeFormat, auther *models.Auther) (*models.CodeFormat, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, request.OrganizationID, auther); err != nil {
+		return nil, err
+	}
+
+	// Begin transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.CodeMaster.SetFormat(ctx, tx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// DeleteCodeFormat makes an organization use the default codes for an entity again
+func (s *OrganizationService) DeleteCodeFormat(ctx context.Context, id int64, entity string, auther *models.Auther) *faulterr.FaultErr {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return err
+	}
+
+	// Begin transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.master.CodeMaster.DeleteFormat(ctx, tx, id, entity); err != nil {
+		return err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetGS1Settings gets the GS1 company prefix of an organization, nil when it has not set one
+func (s *OrganizationService) GetGS1Settings(ctx context.Context, id int64, auther *models.Auther) (*models.GS1Settings, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	settings, err := s.dbstore.GS1SettingsStore.GetByOrgID(ctx, id)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetGS1Settings sets the GS1 company prefix the SSCCs of new pallets and containers of an organization are built from
+func (s *OrganizationService) SetGS1Settings(ctx context.Context, request models.GS1Settings, auther *models.Auther) (*models.GS1Settings, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, request.OrganizationID, auther); err != nil {
+		return nil, err
+	}
+
+	// Begin transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.CodeMaster.SetGS1Settings(ctx, tx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ListAttributeDefinitions gets the custom attributes an organization defined for its pallets and containers
+func (s *OrganizationService) ListAttributeDefinitions(ctx context.Context, id int64, auther *models.Auther) ([]models.AttributeDefinition, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.AttributeDefinitionStore.ListByOrgID(ctx, id)
+}
+
+// SetAttributeDefinition defines a custom attribute of the pallets or containers of an organization
+func (s *OrganizationService) SetAttributeDefinition(ctx context.Context, request models.AttributeDefinition, auther *models.Auther) (*models.AttributeDefinition, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, request.OrganizationID, auther); err != nil {
+		return nil, err
+	}
+
+	// Begin transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.AttributeMaster.SetDefinition(ctx, tx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// DeleteAttributeDefinition removes a custom attribute of an organization
+func (s *OrganizationService) DeleteAttributeDefinition(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	def, err := s.dbstore.AttributeDefinitionStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.GetByID(ctx, def.OrganizationID, auther); err != nil {
+		return err
+	}
+
+	// Begin transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.master.AttributeMaster.DeleteDefinition(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"orijinplus/utils/faulterr"
 
 	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
 )
 
 type PalletService struct {
@@ -19,11 +20,21 @@ var _ PalletServiceInterface = &PalletService{}
 
 type PalletServiceInterface interface {
 	List(ctx context.Context, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr)
+	ListByContainerID(ctx context.Context, containerID int64, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr)
+	ListByAttributes(ctx context.Context, containerID null.Int64, filters []models.AttributeFilter, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr)
 	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
 	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
 	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	GetBySSCC(ctx context.Context, sscc string, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
 	Create(ctx context.Context, request models.PalletRequest, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	CreateBulk(ctx context.Context, request models.PalletRequest, count int, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr)
 	Update(ctx context.Context, id int64, request models.PalletRequest, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	Move(ctx context.Context, id int64, containerID int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	Unload(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	ListHistory(ctx context.Context, id int64, auther *models.Auther) ([]models.PalletAssignment, *faulterr.FaultErr)
+	AssignSSCC(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	Place(ctx context.Context, id int64, locationID null.Int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr)
+	ListLocationMoves(ctx context.Context, id int64, auther *models.Auther) ([]models.LocationMove, *faulterr.FaultErr)
 	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
 }
 
@@ -39,6 +50,50 @@ func (s *PalletService) List(ctx context.Context, auther *models.Auther) ([]mode
 	return s.dbstore.PalletStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
 }
 
+// ListByContainerID gets all pallets inside a container
+func (s *PalletService) ListByContainerID(ctx context.Context, containerID int64, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr) {
+	container, err := s.dbstore.ContainerStore.GetByID(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != container.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no container found")
+	}
+	return s.dbstore.PalletStore.ListByContainerID(ctx, containerID)
+}
+
+// ListByAttributes gets the pallets having custom attribute values, inside a container when containerID is set
+func (s *PalletService) ListByAttributes(
+	ctx context.Context,
+	containerID null.Int64,
+	filters []models.AttributeFilter,
+	auther *models.Auther,
+) ([]models.Pallet, *faulterr.FaultErr) {
+	orgID := null.Int64{}
+	if !auther.IsAdmin {
+		orgID = auther.OrganizationID
+	}
+
+	// Filter values are read by the attributes the organization of the container defined
+	defsOrgID := orgID
+	if containerID.Valid {
+		container, err := s.dbstore.ContainerStore.GetByID(ctx, containerID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		if !auther.IsAdmin && auther.OrganizationID.Int64 != container.OrganizationID.Int64 {
+			return nil, faulterr.NewNotFoundError("no container found")
+		}
+		defsOrgID = container.OrganizationID
+	}
+
+	values, err := s.master.AttributeMaster.FilterValues(ctx, defsOrgID, models.CodePallet, filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.dbstore.PalletStore.ListByAttributes(ctx, orgID, containerID, values)
+}
+
 func (s *PalletService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
 	pallet, err := s.dbstore.PalletStore.GetByID(ctx, id)
 	if err != nil {
@@ -72,27 +127,23 @@ func (s *PalletService) GetByCode(ctx context.Context, code string, auther *mode
 	return pallet, nil
 }
 
-// Create gets all skus
-func (s *PalletService) Create(ctx context.Context, req models.PalletRequest, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
-	if auther.IsAdmin && !req.OrganizationID.Valid {
-		return nil, faulterr.NewBadRequestError("organization id is required")
+func (s *PalletService) GetBySSCC(ctx context.Context, sscc string, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	pallet, err := s.dbstore.PalletStore.GetBySSCC(ctx, sscc)
+	if err != nil {
+		return nil, err
 	}
-	// Reassign organization ID to the request
-	if !auther.IsAdmin {
-		req.OrganizationID = auther.OrganizationID
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != pallet.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no pallet found")
 	}
-	createdByID := auther.ID
+	return pallet, nil
+}
 
-	// Verify container and verify organization
-	if req.ContainerID.Valid {
-		container, err := s.dbstore.ContainerStore.GetByID(ctx, req.ContainerID.Int64)
-		if err != nil {
-			return nil, err
-		}
-		if !auther.IsAdmin && container.OrganizationID != auther.OrganizationID {
-			return nil, faulterr.NewNotFoundError("no container found with given container id")
-		}
+// Create gets all skus
+func (s *PalletService) Create(ctx context.Context, req models.PalletRequest, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	if err := s.prepareCreate(ctx, &req, auther); err != nil {
+		return nil, err
 	}
+	createdByID := auther.ID
 
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
@@ -113,11 +164,35 @@ func (s *PalletService) Create(ctx context.Context, req models.PalletRequest, au
 	return pallet, nil
 }
 
-func (s *PalletService) Update(ctx context.Context, id int64, request models.PalletRequest, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
-	current, err := s.GetByID(ctx, id, auther)
+// CreateBulk creates a number of identical pallets in one transaction
+func (s *PalletService) CreateBulk(ctx context.Context, req models.PalletRequest, count int, auther *models.Auther) ([]models.Pallet, *faulterr.FaultErr) {
+	if err := s.prepareCreate(ctx, &req, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	pallets, err := s.master.PalletMaster.CreateBulk(ctx, tx, req, count, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return pallets, nil
+}
+
+func (s *PalletService) Update(ctx context.Context, id int64, request models.PalletRequest, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
 
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
@@ -126,6 +201,12 @@ func (s *PalletService) Update(ctx context.Context, id int64, request models.Pal
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
+	// The pallet is locked so a concurrent move or unload is not written back over
+	current, err := s.dbstore.PalletStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	pallet, err := s.master.PalletMaster.Update(ctx, tx, current, request)
 	if err != nil {
 		return nil, err
@@ -138,13 +219,105 @@ func (s *PalletService) Update(ctx context.Context, id int64, request models.Pal
 	return pallet, nil
 }
 
+// Move loads a pallet into another container of its organization
+func (s *PalletService) Move(ctx context.Context, id int64, containerID int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	container, err := s.dbstore.ContainerStore.GetByID(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && container.OrganizationID != auther.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no container found with given container id")
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	pallet, err := s.master.PalletMaster.Move(ctx, tx, id, container.ID, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return pallet, nil
+}
+
+// Unload takes a pallet out of its container
+func (s *PalletService) Unload(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	pallet, err := s.master.PalletMaster.Unload(ctx, tx, id, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return pallet, nil
+}
+
+// ListHistory gets the containers a pallet has been in, latest first
+func (s *PalletService) ListHistory(ctx context.Context, id int64, auther *models.Auther) ([]models.PalletAssignment, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.PalletAssignmentStore.ListByPalletID(ctx, id)
+}
+
 func (s *PalletService) Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
-	pallet, err := s.GetByID(ctx, id, auther)
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	pallet, err := s.dbstore.PalletStore.LockByID(ctx, tx, id)
 	if err != nil {
 		return nil, err
 	}
 	pallet.IsArchived = true
 
+	if err := s.dbstore.PalletStore.Update(ctx, tx, *pallet); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return pallet, nil
+}
+
+func (s *PalletService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
 	if err != nil {
@@ -152,6 +325,12 @@ func (s *PalletService) Archive(ctx context.Context, id int64, auther *models.Au
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
+	pallet, err := s.dbstore.PalletStore.LockByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	pallet.IsArchived = false
+
 	if err := s.dbstore.PalletStore.Update(ctx, tx, *pallet); err != nil {
 		return nil, err
 	}
@@ -163,12 +342,12 @@ func (s *PalletService) Archive(ctx context.Context, id int64, auther *models.Au
 	return pallet, nil
 }
 
-func (s *PalletService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+// AssignSSCC gives a pallet without an SSCC the next SSCC of its organization
+func (s *PalletService) AssignSSCC(ctx context.Context, id int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
 	pallet, err := s.GetByID(ctx, id, auther)
 	if err != nil {
 		return nil, err
 	}
-	pallet.IsArchived = false
 
 	// Start transactions
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
@@ -177,7 +356,8 @@ func (s *PalletService) Unarchive(ctx context.Context, id int64, auther *models.
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
-	if err := s.dbstore.PalletStore.Update(ctx, tx, *pallet); err != nil {
+	pallet, err = s.master.PalletMaster.AssignSSCC(ctx, tx, pallet)
+	if err != nil {
 		return nil, err
 	}
 
@@ -188,6 +368,39 @@ func (s *PalletService) Unarchive(ctx context.Context, id int64, auther *models.
 	return pallet, nil
 }
 
+// Place puts a pallet at a warehouse location, a null location takes it off its location
+func (s *PalletService) Place(ctx context.Context, id int64, locationID null.Int64, auther *models.Auther) (*models.Pallet, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	pallet, err := s.master.LocationMaster.PlacePallet(ctx, tx, id, locationID, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return pallet, nil
+}
+
+// ListLocationMoves gets the locations a pallet has been moved between, latest first
+func (s *PalletService) ListLocationMoves(ctx context.Context, id int64, auther *models.Auther) ([]models.LocationMove, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.LocationMoveStore.ListByPalletID(ctx, id)
+}
+
 func (s *PalletService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
 	if !auther.IsAdmin {
 		return faulterr.NewUnauthorizedError("Permission not granted")
@@ -204,6 +417,12 @@ func (s *PalletService) Delete(ctx context.Context, id int64, auther *models.Aut
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
+	if err := s.dbstore.PalletAssignmentStore.DeleteByPalletID(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.LocationMoveStore.DeleteByPalletID(ctx, tx, id); err != nil {
+		return err
+	}
 	if err := s.dbstore.PalletStore.Delete(ctx, tx, id); err != nil {
 		return err
 	}
@@ -213,3 +432,26 @@ func (s *PalletService) Delete(ctx context.Context, id int64, auther *models.Aut
 
 	return nil
 }
+
+// prepareCreate sets the organization of a new pallet and verifies its container
+func (s *PalletService) prepareCreate(ctx context.Context, req *models.PalletRequest, auther *models.Auther) *faulterr.FaultErr {
+	if auther.IsAdmin && !req.OrganizationID.Valid {
+		return faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		req.OrganizationID = auther.OrganizationID
+	}
+
+	// Verify container and verify organization
+	if req.ContainerID.Valid {
+		container, err := s.dbstore.ContainerStore.GetByID(ctx, req.ContainerID.Int64)
+		if err != nil {
+			return err
+		}
+		if !auther.IsAdmin && container.OrganizationID != auther.OrganizationID {
+			return faulterr.NewNotFoundError("no container found with given container id")
+		}
+	}
+	return nil
+}
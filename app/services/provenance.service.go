@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+type ProvenanceService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ ProvenanceServiceInterface = &ProvenanceService{}
+
+type ProvenanceServiceInterface interface {
+	Lookup(ctx context.Context, uid uuid.UUID) (*models.Provenance, *faulterr.FaultErr)
+	LookupGS1(ctx context.Context, ai string, key string) (*models.Provenance, *faulterr.FaultErr)
+	GetSettings(ctx context.Context, orgID int64, auther *models.Auther) (*models.ProvenanceSettings, *faulterr.FaultErr)
+	SetSettings(ctx context.Context, request models.ProvenanceSettings, auther *models.Auther) (*models.ProvenanceSettings, *faulterr.FaultErr)
+}
+
+func NewProvenanceService(s *dbstore.DBStore, m *master.Master) *ProvenanceService {
+	return &ProvenanceService{s, m}
+}
+
+// Lookup resolves a scanned uid of a pallet, container or product to its public view.
+// It needs no user, so items of organizations which are not public are reported as not found
+func (s *ProvenanceService) Lookup(ctx context.Context, uid uuid.UUID) (*models.Provenance, *faulterr.FaultErr) {
+	view, orgID, description, err := s.find(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, view, orgID, description)
+}
+
+// LookupGS1 resolves the GS1 key of a scanned GS1 Digital Link, the SSCC of a pallet or container
+// or the GTIN of a product, to its public view
+func (s *ProvenanceService) LookupGS1(ctx context.Context, ai string, key string) (*models.Provenance, *faulterr.FaultErr) {
+	view, orgID, description, err := s.findGS1(ctx, ai, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, view, orgID, description)
+}
+
+// publish strips from a view what its organization does not make public
+func (s *ProvenanceService) publish(ctx context.Context, view *models.Provenance, orgID int64, description string) (*models.Provenance, *faulterr.FaultErr) {
+	if orgID == 0 {
+		return nil, faulterr.NewNotFoundError("no item found")
+	}
+	settings, err := s.settings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.IsPublic {
+		return nil, faulterr.NewNotFoundError("no item found")
+	}
+
+	if settings.ShowOrganization {
+		org, err := s.dbstore.OrganizationStore.GetByID(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		view.Organization = &models.ProvenanceOrganization{Name: org.Name, Website: org.Website.String}
+	}
+	if settings.ShowDescription {
+		view.Description = description
+	}
+
+	if settings.ShowAnchoring {
+		view.Anchoring = anchoringStatus(view.Events)
+	}
+	for i := range view.Events {
+		event := &view.Events[i]
+		if !settings.ShowLocations {
+			event.Location = ""
+		}
+		if !settings.ShowEventDetails {
+			event.Details = nil
+		}
+		if !settings.ShowAnchoring {
+			event.AnchorTxHash, event.AnchoredAt = "", nil
+		}
+	}
+
+	return view, nil
+}
+
+// GetSettings gets what an organization makes public, the defaults when it has not set them
+func (s *ProvenanceService) GetSettings(ctx context.Context, orgID int64, auther *models.Auther) (*models.ProvenanceSettings, *faulterr.FaultErr) {
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != orgID {
+		return nil, faulterr.NewNotFoundError("object not found")
+	}
+	if _, err := s.dbstore.OrganizationStore.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.settings(ctx, orgID)
+}
+
+// SetSettings sets what an organization makes public
+func (s *ProvenanceService) SetSettings(ctx context.Context, r models.ProvenanceSettings, auther *models.Auther) (*models.ProvenanceSettings, *faulterr.FaultErr) {
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != r.OrganizationID {
+		return nil, faulterr.NewNotFoundError("object not found")
+	}
+	if _, err := s.dbstore.OrganizationStore.GetByID(ctx, r.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.dbstore.ProvenanceSettingsStore.Upsert(ctx, tx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *ProvenanceService) settings(ctx context.Context, orgID int64) (*models.ProvenanceSettings, *faulterr.FaultErr) {
+	settings, err := s.dbstore.ProvenanceSettingsStore.GetByOrgID(ctx, orgID)
+	if err != nil {
+		if err.Status == http.StatusNotFound {
+			defaults := models.DefaultProvenanceSettings(orgID)
+			return &defaults, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// find looks a uid up in pallets, containers and then skus, and gets the full timeline of what it finds
+// along with its organization and description, which depend on the settings of the organization
+func (s *ProvenanceService) find(ctx context.Context, uid uuid.UUID) (*models.Provenance, int64, string, *faulterr.FaultErr) {
+	pallet, err := s.dbstore.PalletStore.GetByUID(ctx, uid)
+	if err == nil {
+		return s.palletView(ctx, pallet)
+	}
+	if err.Status != http.StatusNotFound {
+		return nil, 0, "", err
+	}
+
+	container, err := s.dbstore.ContainerStore.GetByUID(ctx, uid)
+	if err == nil {
+		return s.containerView(ctx, container)
+	}
+	if err.Status != http.StatusNotFound {
+		return nil, 0, "", err
+	}
+
+	sku, err := s.dbstore.SkuStore.GetByUID(ctx, uid)
+	if err == nil {
+		return skuView(sku)
+	}
+	if err.Status != http.StatusNotFound {
+		return nil, 0, "", err
+	}
+	return nil, 0, "", faulterr.NewNotFoundError("no item found")
+}
+
+// findGS1 looks an SSCC up in pallets and then containers, or a GTIN up in skus
+func (s *ProvenanceService) findGS1(ctx context.Context, ai string, key string) (*models.Provenance, int64, string, *faulterr.FaultErr) {
+	switch ai {
+	case models.AISSCC:
+		if !models.ValidSSCC(key) {
+			return nil, 0, "", faulterr.NewBadRequestError("SSCC is not valid")
+		}
+		pallet, err := s.dbstore.PalletStore.GetBySSCC(ctx, key)
+		if err == nil {
+			return s.palletView(ctx, pallet)
+		}
+		if err.Status != http.StatusNotFound {
+			return nil, 0, "", err
+		}
+
+		container, err := s.dbstore.ContainerStore.GetBySSCC(ctx, key)
+		if err == nil {
+			return s.containerView(ctx, container)
+		}
+		if err.Status != http.StatusNotFound {
+			return nil, 0, "", err
+		}
+	case models.AIGTIN:
+		if !models.ValidGTIN(key) {
+			return nil, 0, "", faulterr.NewBadRequestError("GTIN is not valid")
+		}
+		sku, err := s.dbstore.SkuStore.GetByGTIN(ctx, models.GTIN14(key))
+		if err == nil {
+			return skuView(sku)
+		}
+		if err.Status != http.StatusNotFound {
+			return nil, 0, "", err
+		}
+	default:
+		return nil, 0, "", faulterr.NewBadRequestError("Unknown GS1 application identifier " + ai)
+	}
+	return nil, 0, "", faulterr.NewNotFoundError("no item found")
+}
+
+func (s *ProvenanceService) palletView(ctx context.Context, pallet *models.Pallet) (*models.Provenance, int64, string, *faulterr.FaultErr) {
+	events, err := s.palletEvents(ctx, pallet.ID)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	view := &models.Provenance{Kind: models.LabelPallet, UID: pallet.UID.String(), Code: pallet.Code, SSCC: pallet.SSCC.String, Events: events}
+	return view, pallet.OrganizationID.Int64, pallet.Description, nil
+}
+
+func (s *ProvenanceService) containerView(ctx context.Context, container *models.Container) (*models.Provenance, int64, string, *faulterr.FaultErr) {
+	actions, err := s.dbstore.TrackActionStore.ListByContainerID(ctx, container.ID)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	view := &models.Provenance{Kind: models.LabelContainer, UID: container.UID.String(), Code: container.Code, SSCC: container.SSCC.String, Status: container.Status, Events: provenanceEvents(actions, "")}
+	return view, container.OrganizationID.Int64, container.Description, nil
+}
+
+// skuView shows a sku as a product, which is what consumers know it as. Nothing is tracked on skus
+func skuView(sku *models.Sku) (*models.Provenance, int64, string, *faulterr.FaultErr) {
+	view := &models.Provenance{Kind: "product", UID: sku.UID.String(), Code: sku.Code, GTIN: sku.GTIN.String, Name: sku.Name, Events: []models.ProvenanceEvent{}}
+	return view, sku.OrganizationID, sku.Description, nil
+}
+
+// palletEvents gets the actions tracked on a pallet, and on each container it was in while it was loaded
+func (s *ProvenanceService) palletEvents(ctx context.Context, palletID int64) ([]models.ProvenanceEvent, *faulterr.FaultErr) {
+	own, err := s.dbstore.TrackActionStore.ListByPalletID(ctx, palletID)
+	if err != nil {
+		return nil, err
+	}
+	events := provenanceEvents(own, "")
+
+	assignments, err := s.dbstore.PalletAssignmentStore.ListByPalletID(ctx, palletID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignments {
+		container, err := s.dbstore.ContainerStore.GetByID(ctx, a.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		list, err := s.dbstore.TrackActionStore.ListByContainerID(ctx, a.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		during := []models.TrackAction{}
+		for _, action := range list {
+			if action.OccurredAt.Before(a.LoadedAt) || (a.UnloadedAt.Valid && !action.OccurredAt.Before(a.UnloadedAt.Time)) {
+				continue
+			}
+			during = append(during, action)
+		}
+		events = append(events, provenanceEvents(during, container.Code)...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].OccurredAt.Before(events[j].OccurredAt) })
+	return events, nil
+}
+
+// provenanceEvents turns track actions into timeline events, container is the code of the container
+// the actions were tracked on when they are shown on the timeline of a pallet
+func provenanceEvents(actions []models.TrackAction, container string) []models.ProvenanceEvent {
+	events := make([]models.ProvenanceEvent, 0, len(actions))
+	for _, a := range actions {
+		events = append(events, models.ProvenanceEvent{
+			Action:       a.Action,
+			OccurredAt:   a.OccurredAt,
+			Container:    container,
+			Location:     a.Location,
+			Details:      a.Payload,
+			AnchorTxHash: a.AnchorTxHash.String,
+			AnchoredAt:   a.AnchoredAt.Ptr(),
+		})
+	}
+	return events
+}
+
+// anchoringStatus sums up how much of a timeline is anchored on chain
+func anchoringStatus(events []models.ProvenanceEvent) string {
+	if len(events) == 0 {
+		return models.AnchoringNone
+	}
+	anchored := 0
+	for _, e := range events {
+		if e.AnchorTxHash != "" {
+			anchored++
+		}
+	}
+	switch anchored {
+	case 0:
+		return models.AnchoringPending
+	case len(events):
+		return models.AnchoringAnchored
+	}
+	return models.AnchoringPartial
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type PurchaseRecordService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ PurchaseRecordServiceInterface = &PurchaseRecordService{}
+
+type PurchaseRecordServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	ListMine(ctx context.Context, auther *models.Auther) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.PurchaseRecordRequest, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.PurchaseRecordRequest, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr)
+	Redeem(ctx context.Context, token string, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewPurchaseRecordService(s *dbstore.DBStore, m *master.Master) *PurchaseRecordService {
+	return &PurchaseRecordService{s, m}
+}
+
+// List gets all purchase records
+func (s *PurchaseRecordService) List(ctx context.Context, auther *models.Auther) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.PurchaseRecordStore.List(ctx)
+	}
+	return s.dbstore.PurchaseRecordStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+// ListMine gets all purchase records redeemed by the logged in customer
+func (s *PurchaseRecordService) ListMine(ctx context.Context, auther *models.Auther) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	return s.dbstore.PurchaseRecordStore.ListByRedeemedByID(ctx, auther.ID)
+}
+
+func (s *PurchaseRecordService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	obj, err := s.dbstore.PurchaseRecordStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no purchase record found")
+	}
+	return obj, nil
+}
+
+func (s *PurchaseRecordService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	obj, err := s.dbstore.PurchaseRecordStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no purchase record found")
+	}
+	return obj, nil
+}
+
+func (s *PurchaseRecordService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	obj, err := s.dbstore.PurchaseRecordStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no purchase record found")
+	}
+	return obj, nil
+}
+
+// Create saves a purchase record object in db
+func (s *PurchaseRecordService) Create(ctx context.Context, r models.PurchaseRecordRequest, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+	createdByID := auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.PurchaseRecordMaster.Create(ctx, tx, r, createdByID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *PurchaseRecordService) Update(ctx context.Context, id int64, request models.PurchaseRecordRequest, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	request.OrganizationID = null.Int64From(current.OrganizationID)
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	record, err := s.master.PurchaseRecordMaster.Update(ctx, tx, current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Redeem claims a purchase record with its purchase token for the logged in customer
+func (s *PurchaseRecordService) Redeem(ctx context.Context, token string, auther *models.Auther) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	if !auther.IsCustomer {
+		return nil, faulterr.NewUnauthorizedError("only customers can redeem purchases")
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	record, err := s.master.PurchaseRecordMaster.RedeemByToken(ctx, tx, auther.ID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *PurchaseRecordService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.PurchaseRecordStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.PurchaseRecordStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/volatiletech/null"
+)
+
+type RecallService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ RecallServiceInterface = &RecallService{}
+
+type RecallServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Recall, *faulterr.FaultErr)
+	ListOpenByItem(ctx context.Context, itemType string, itemID int64) ([]models.Recall, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Recall, *faulterr.FaultErr)
+	ListItems(ctx context.Context, id int64, auther *models.Auther) ([]models.RecallItem, *faulterr.FaultErr)
+	Progress(ctx context.Context, id int64, auther *models.Auther) (*models.RecallProgress, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.RecallRequest, auther *models.Auther) (*models.Recall, *faulterr.FaultErr)
+	ResolveItem(ctx context.Context, id int64, itemID int64, auther *models.Auther) (*models.RecallItem, *faulterr.FaultErr)
+	Close(ctx context.Context, id int64, auther *models.Auther) (*models.Recall, *faulterr.FaultErr)
+}
+
+func NewRecallService(s *dbstore.DBStore, m *master.Master) *RecallService {
+	return &RecallService{s, m}
+}
+
+// List gets all recalls
+func (s *RecallService) List(ctx context.Context, auther *models.Auther) ([]models.Recall, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.RecallStore.List(ctx)
+	}
+	return s.dbstore.RecallStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+// ListOpenByItem gets the open recalls a pallet or container is marked recalled by,
+// the caller has already checked the item can be seen
+func (s *RecallService) ListOpenByItem(ctx context.Context, itemType string, itemID int64) ([]models.Recall, *faulterr.FaultErr) {
+	items, err := s.dbstore.RecallItemStore.ListOpenByItem(ctx, itemType, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	recalls := make([]models.Recall, 0, len(items))
+	for _, item := range items {
+		recall, err := s.dbstore.RecallStore.GetByID(ctx, item.RecallID)
+		if err != nil {
+			return nil, err
+		}
+		recalls = append(recalls, *recall)
+	}
+	return recalls, nil
+}
+
+// GetByID gets a recall of the organization, or a recall organizations and customers were notified of
+// because they received some of its items
+func (s *RecallService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Recall, *faulterr.FaultErr) {
+	obj, err := s.dbstore.RecallStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.isOwner(obj, auther) {
+		return obj, nil
+	}
+
+	items, err := s.recipientItems(ctx, obj.ID, auther)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return obj, nil
+}
+
+// ListItems gets the pallets, containers, orders and products a recall affects,
+// organizations and customers it was sent to only get the items they received
+func (s *RecallService) ListItems(ctx context.Context, id int64, auther *models.Auther) ([]models.RecallItem, *faulterr.FaultErr) {
+	obj, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	if s.isOwner(obj, auther) {
+		return s.dbstore.RecallItemStore.ListByRecallID(ctx, id)
+	}
+	return s.recipientItems(ctx, id, auther)
+}
+
+// Progress counts the items of a recall which were resolved
+func (s *RecallService) Progress(ctx context.Context, id int64, auther *models.Auther) (*models.RecallProgress, *faulterr.FaultErr) {
+	items, err := s.ListItems(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	progress := models.RecallProgressOf(items)
+	return &progress, nil
+}
+
+func (s *RecallService) Create(ctx context.Context, r models.RecallRequest, auther *models.Auther) (*models.Recall, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.RecallMaster.Create(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ResolveItem marks an item of a recall as returned or destroyed
+func (s *RecallService) ResolveItem(ctx context.Context, id int64, itemID int64, auther *models.Auther) (*models.RecallItem, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.RecallMaster.ResolveItem(ctx, tx, id, itemID, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// Close ends a recall
+func (s *RecallService) Close(ctx context.Context, id int64, auther *models.Auther) (*models.Recall, *faulterr.FaultErr) {
+	if _, err := s.getOwned(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.RecallMaster.Close(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// getOwned gets a recall of the organization, only it can resolve its items and close it
+func (s *RecallService) getOwned(ctx context.Context, id int64, auther *models.Auther) (*models.Recall, *faulterr.FaultErr) {
+	obj, err := s.dbstore.RecallStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isOwner(obj, auther) {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return obj, nil
+}
+
+func (s *RecallService) isOwner(obj *models.Recall, auther *models.Auther) bool {
+	return auther.IsAdmin || (!auther.IsCustomer && auther.OrganizationID.Int64 == obj.OrganizationID)
+}
+
+// recipientItems gets the items of a recall which went to the organization of the user, or to the customer
+func (s *RecallService) recipientItems(ctx context.Context, id int64, auther *models.Auther) ([]models.RecallItem, *faulterr.FaultErr) {
+	if auther.IsCustomer {
+		return s.dbstore.RecallItemStore.ListByRecipient(ctx, id, null.Int64{}, null.Int64From(auther.ID))
+	}
+	return s.dbstore.RecallItemStore.ListByRecipient(ctx, id, auther.OrganizationID, null.Int64{})
+}
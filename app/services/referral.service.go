@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/volatiletech/null"
+)
+
+type ReferralService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ ReferralServiceInterface = &ReferralService{}
+
+type ReferralServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Referral, *faulterr.FaultErr)
+	ListMine(ctx context.Context, auther *models.Auther) ([]models.Referral, *faulterr.FaultErr)
+	GetStats(ctx context.Context, referrerID null.Int64, auther *models.Auther) (*models.ReferralStats, *faulterr.FaultErr)
+	ListRules(ctx context.Context, auther *models.Auther) ([]models.ReferralRule, *faulterr.FaultErr)
+	GetRuleByID(ctx context.Context, id int64, auther *models.Auther) (*models.ReferralRule, *faulterr.FaultErr)
+	CreateRule(ctx context.Context, request models.ReferralRuleRequest, auther *models.Auther) (*models.ReferralRule, *faulterr.FaultErr)
+	UpdateRule(ctx context.Context, id int64, request models.ReferralRuleRequest, auther *models.Auther) (*models.ReferralRule, *faulterr.FaultErr)
+}
+
+func NewReferralService(s *dbstore.DBStore, m *master.Master) *ReferralService {
+	return &ReferralService{s, m}
+}
+
+// List gets all referrals
+func (s *ReferralService) List(ctx context.Context, auther *models.Auther) ([]models.Referral, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	return s.dbstore.ReferralStore.List(ctx)
+}
+
+// ListMine gets the customers referred by the logged in customer
+func (s *ReferralService) ListMine(ctx context.Context, auther *models.Auther) ([]models.Referral, *faulterr.FaultErr) {
+	return s.dbstore.ReferralStore.ListByReferrerID(ctx, auther.ID)
+}
+
+// GetStats gets referral statistics, customers only see their own and admins may see anyone's or the overall total
+func (s *ReferralService) GetStats(ctx context.Context, referrerID null.Int64, auther *models.Auther) (*models.ReferralStats, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		referrerID = null.Int64From(auther.ID)
+	}
+	return s.dbstore.ReferralStore.GetStats(ctx, referrerID)
+}
+
+// ListRules gets all referral reward rules
+func (s *ReferralService) ListRules(ctx context.Context, auther *models.Auther) ([]models.ReferralRule, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	return s.dbstore.ReferralRuleStore.List(ctx)
+}
+
+func (s *ReferralService) GetRuleByID(ctx context.Context, id int64, auther *models.Auther) (*models.ReferralRule, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	return s.dbstore.ReferralRuleStore.GetByID(ctx, id)
+}
+
+func (s *ReferralService) CreateRule(ctx context.Context, r models.ReferralRuleRequest, auther *models.Auther) (*models.ReferralRule, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	rule, err := s.master.ReferralMaster.CreateRule(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *ReferralService) UpdateRule(ctx context.Context, id int64, r models.ReferralRuleRequest, auther *models.Auther) (*models.ReferralRule, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	current, err := s.dbstore.ReferralRuleStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	rule, err := s.master.ReferralMaster.UpdateRule(ctx, tx, current, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+)
+
+type ShipmentService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ ShipmentServiceInterface = &ShipmentService{}
+
+type ShipmentServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Shipment, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.ShipmentRequest, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.ShipmentRequest, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr)
+	AddContainer(ctx context.Context, id int64, containerID int64, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr)
+	RemoveContainer(ctx context.Context, id int64, containerID int64, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr)
+	UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr)
+	ListContainers(ctx context.Context, id int64, auther *models.Auther) ([]models.Container, *faulterr.FaultErr)
+	Manifest(ctx context.Context, id int64, auther *models.Auther) (*models.Manifest, *faulterr.FaultErr)
+}
+
+func NewShipmentService(s *dbstore.DBStore, m *master.Master) *ShipmentService {
+	return &ShipmentService{s, m}
+}
+
+// List gets all shipments
+func (s *ShipmentService) List(ctx context.Context, auther *models.Auther) ([]models.Shipment, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.ShipmentStore.List(ctx)
+	}
+	return s.dbstore.ShipmentStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+func (s *ShipmentService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr) {
+	obj, err := s.dbstore.ShipmentStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return obj, nil
+}
+
+func (s *ShipmentService) Create(ctx context.Context, r models.ShipmentRequest, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ShipmentMaster.Create(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *ShipmentService) Update(ctx context.Context, id int64, r models.ShipmentRequest, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ShipmentMaster.Update(ctx, tx, id, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// AddContainer puts a container on a planned shipment
+func (s *ShipmentService) AddContainer(ctx context.Context, id int64, containerID int64, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ShipmentMaster.AddContainer(ctx, tx, id, containerID, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// RemoveContainer takes a container off a planned shipment
+func (s *ShipmentService) RemoveContainer(ctx context.Context, id int64, containerID int64, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ShipmentMaster.RemoveContainer(ctx, tx, id, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// UpdateStatus departs, arrives or cancels a shipment
+func (s *ShipmentService) UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Shipment, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.ShipmentMaster.UpdateStatus(ctx, tx, id, status, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ListContainers gets the containers on a shipment in the order they were added
+func (s *ShipmentService) ListContainers(ctx context.Context, id int64, auther *models.Auther) ([]models.Container, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	entries, err := s.dbstore.ShipmentContainerStore.ListByShipmentID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]models.Container, 0, len(entries))
+	for _, entry := range entries {
+		container, err := s.dbstore.ContainerStore.GetByID(ctx, entry.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, *container)
+	}
+	return containers, nil
+}
+
+// Manifest gathers the containers and pallets of a shipment for printing
+func (s *ShipmentService) Manifest(ctx context.Context, id int64, auther *models.Auther) (*models.Manifest, *faulterr.FaultErr) {
+	shipment, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	origin, err := s.dbstore.AddressStore.GetByID(ctx, shipment.OriginAddressID)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.dbstore.AddressStore.GetByID(ctx, shipment.DestinationAddressID)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := s.ListContainers(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &models.Manifest{
+		Shipment:    *shipment,
+		Origin:      *origin,
+		Destination: *destination,
+		Containers:  make([]models.ManifestContainer, 0, len(containers)),
+		GeneratedAt: time.Now().UTC(),
+	}
+	for _, c := range containers {
+		pallets, err := s.dbstore.PalletStore.ListByContainerID(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		entry := models.ManifestContainer{
+			Code:        c.Code,
+			Description: c.Description,
+			Status:      c.Status,
+			Pallets:     make([]models.ManifestPallet, 0, len(pallets)),
+		}
+		for _, p := range pallets {
+			entry.Pallets = append(entry.Pallets, models.ManifestPallet{Code: p.Code, UID: p.UID.String(), Description: p.Description})
+		}
+		manifest.Containers = append(manifest.Containers, entry)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type SkuService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ SkuServiceInterface = &SkuService{}
+
+type SkuServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Sku, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.SkuRequest, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.SkuRequest, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewSkuService(s *dbstore.DBStore, m *master.Master) *SkuService {
+	return &SkuService{s, m}
+}
+
+// List gets all skus
+func (s *SkuService) List(ctx context.Context, auther *models.Auther) ([]models.Sku, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.SkuStore.List(ctx)
+	}
+	return s.dbstore.SkuStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+func (s *SkuService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	obj, err := s.dbstore.SkuStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no sku found")
+	}
+	return obj, nil
+}
+
+func (s *SkuService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	obj, err := s.dbstore.SkuStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no sku found")
+	}
+	return obj, nil
+}
+
+func (s *SkuService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	obj, err := s.dbstore.SkuStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no sku found")
+	}
+	return obj, nil
+}
+
+// Create saves a sku object in db
+func (s *SkuService) Create(ctx context.Context, r models.SkuRequest, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+	createdByID := auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.SkuMaster.Create(ctx, tx, r, createdByID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *SkuService) Update(ctx context.Context, id int64, request models.SkuRequest, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	request.OrganizationID = null.Int64From(current.OrganizationID)
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	sku, err := s.master.SkuMaster.Update(ctx, tx, current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return sku, nil
+}
+
+func (s *SkuService) Archive(ctx context.Context, id int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	sku, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	sku.IsArchived = true
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.SkuStore.Update(ctx, tx, *sku); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return sku, nil
+}
+
+func (s *SkuService) Unarchive(ctx context.Context, id int64, auther *models.Auther) (*models.Sku, *faulterr.FaultErr) {
+	sku, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	sku.IsArchived = false
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.SkuStore.Update(ctx, tx, *sku); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return sku, nil
+}
+
+func (s *SkuService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.SkuStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.SkuStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/volatiletech/null"
+)
+
+type TaskService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ TaskServiceInterface = &TaskService{}
+
+type TaskServiceInterface interface {
+	List(ctx context.Context, status null.String, auther *models.Auther) ([]models.Task, *faulterr.FaultErr)
+	ListMine(ctx context.Context, status null.String, auther *models.Auther) ([]models.Task, *faulterr.FaultErr)
+	ListComments(ctx context.Context, taskID int64) ([]models.TaskComment, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.TaskRequest, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.TaskRequest, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	AddComment(ctx context.Context, id int64, body string, auther *models.Auther) (*models.Task, *faulterr.FaultErr)
+	Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+}
+
+func NewTaskService(s *dbstore.DBStore, m *master.Master) *TaskService {
+	return &TaskService{s, m}
+}
+
+// List gets all tasks of the auther's organization
+func (s *TaskService) List(ctx context.Context, status null.String, auther *models.Auther) ([]models.Task, *faulterr.FaultErr) {
+	var tasks []models.Task
+	var err *faulterr.FaultErr
+	if auther.IsAdmin {
+		tasks, err = s.dbstore.TaskStore.List(ctx)
+	} else {
+		tasks, err = s.dbstore.TaskStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return filterTasks(tasks, status), nil
+}
+
+// ListMine gets the work queue of the auther: tasks assigned to them or to their role
+func (s *TaskService) ListMine(ctx context.Context, status null.String, auther *models.Auther) ([]models.Task, *faulterr.FaultErr) {
+	tasks, err := s.dbstore.TaskStore.ListByAssignee(ctx, auther.ID, auther.RoleID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	return filterTasks(tasks, status), nil
+}
+
+// ListComments gets the comments of a task
+func (s *TaskService) ListComments(ctx context.Context, taskID int64) ([]models.TaskComment, *faulterr.FaultErr) {
+	return s.dbstore.TaskCommentStore.ListByTaskID(ctx, taskID)
+}
+
+func (s *TaskService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	obj, err := s.dbstore.TaskStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no task found")
+	}
+	return obj, nil
+}
+
+func (s *TaskService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	obj, err := s.dbstore.TaskStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no task found")
+	}
+	return obj, nil
+}
+
+func (s *TaskService) GetByCode(ctx context.Context, code string, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	obj, err := s.dbstore.TaskStore.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no task found")
+	}
+	return obj, nil
+}
+
+// Create saves a task object in db
+func (s *TaskService) Create(ctx context.Context, r models.TaskRequest, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+	createdByID := auther.ID
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.TaskMaster.Create(ctx, tx, r, createdByID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *TaskService) Update(ctx context.Context, id int64, request models.TaskRequest, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	request.OrganizationID = null.Int64From(current.OrganizationID)
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	task, err := s.master.TaskMaster.Update(ctx, tx, current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// UpdateStatus moves a task to the next status of its lifecycle
+func (s *TaskService) UpdateStatus(ctx context.Context, id int64, status string, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	task, err := s.master.TaskMaster.UpdateStatus(ctx, tx, current, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// AddComment adds a comment of the auther to a task
+func (s *TaskService) AddComment(ctx context.Context, id int64, body string, auther *models.Auther) (*models.Task, *faulterr.FaultErr) {
+	task, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if _, err := s.master.TaskMaster.AddComment(ctx, tx, task, body, auther.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (s *TaskService) Delete(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	if !auther.IsAdmin {
+		return faulterr.NewUnauthorizedError("Permission not granted")
+	}
+	_, err := s.dbstore.TaskStore.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.TaskStore.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Helpers
+
+// filterTasks keeps the tasks with the given status, if any
+func filterTasks(tasks []models.Task, status null.String) []models.Task {
+	if !status.Valid {
+		return tasks
+	}
+
+	result := []models.Task{}
+	for _, task := range tasks {
+		if task.Status == status.String {
+			result = append(result, task)
+		}
+	}
+	return result
+}
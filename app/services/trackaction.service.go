@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+)
+
+type TrackActionService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ TrackActionServiceInterface = &TrackActionService{}
+
+type TrackActionServiceInterface interface {
+	ListByContainerID(ctx context.Context, containerID int64, auther *models.Auther) ([]models.TrackAction, *faulterr.FaultErr)
+	ListByPalletID(ctx context.Context, palletID int64, auther *models.Auther) ([]models.TrackAction, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.TrackAction, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.TrackAction, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.TrackActionRequest, auther *models.Auther) (*models.TrackAction, *faulterr.FaultErr)
+}
+
+func NewTrackActionService(s *dbstore.DBStore, m *master.Master) *TrackActionService {
+	return &TrackActionService{s, m}
+}
+
+// ListByContainerID gets the timeline of a container
+func (s *TrackActionService) ListByContainerID(ctx context.Context, containerID int64, auther *models.Auther) ([]models.TrackAction, *faulterr.FaultErr) {
+	container, err := s.dbstore.ContainerStore.GetByID(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != container.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no container found")
+	}
+	return s.dbstore.TrackActionStore.ListByContainerID(ctx, containerID)
+}
+
+// ListByPalletID gets the timeline of a pallet
+func (s *TrackActionService) ListByPalletID(ctx context.Context, palletID int64, auther *models.Auther) ([]models.TrackAction, *faulterr.FaultErr) {
+	pallet, err := s.dbstore.PalletStore.GetByID(ctx, palletID)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != pallet.OrganizationID.Int64 {
+		return nil, faulterr.NewNotFoundError("no pallet found")
+	}
+	return s.dbstore.TrackActionStore.ListByPalletID(ctx, palletID)
+}
+
+func (s *TrackActionService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.TrackAction, *faulterr.FaultErr) {
+	obj, err := s.dbstore.TrackActionStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no track action found")
+	}
+	return obj, nil
+}
+
+func (s *TrackActionService) GetByUID(ctx context.Context, uid uuid.UUID, auther *models.Auther) (*models.TrackAction, *faulterr.FaultErr) {
+	obj, err := s.dbstore.TrackActionStore.GetByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no track action found")
+	}
+	return obj, nil
+}
+
+// Create records a track action, track actions are append-only and cannot be changed afterwards
+func (s *TrackActionService) Create(ctx context.Context, r models.TrackActionRequest, auther *models.Auther) (*models.TrackAction, *faulterr.FaultErr) {
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.TrackActionMaster.Create(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
@@ -134,10 +134,8 @@ func (s *UserService) AddAddress(ctx context.Context, a models.Address, auther *
 	if !auther.IsCustomer {
 		return nil, faulterr.NewUnauthorizedError("only consumers can add address")
 	}
-	if err := a.Validate(); err != nil {
-		return nil, err
-	}
-	a.UserID = auther.ID
+	a.UserID = null.Int64From(auther.ID)
+	a.OrganizationID = null.Int64{}
 
 	// Start db transaction
 	tx, err := s.dbstore.DBTX.BeginTx(ctx)
@@ -146,11 +144,11 @@ func (s *UserService) AddAddress(ctx context.Context, a models.Address, auther *
 	}
 	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
 
-	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+	if _, err := s.master.AddressMaster.Create(ctx, tx, a); err != nil {
 		return nil, err
 	}
-	_, err = s.dbstore.AddressStore.Insert(ctx, tx, a)
-	if err != nil {
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+	"time"
+)
+
+type WalletService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ WalletServiceInterface = &WalletService{}
+
+type WalletServiceInterface interface {
+	GetBalance(ctx context.Context, userID int64, auther *models.Auther) (*models.WalletBalance, *faulterr.FaultErr)
+	ListEntries(ctx context.Context, userID int64, auther *models.Auther) ([]models.WalletPointEntry, *faulterr.FaultErr)
+	Adjust(ctx context.Context, request models.WalletAdjustmentRequest, auther *models.Auther) (*models.WalletPointEntry, *faulterr.FaultErr)
+	ExpireAll(ctx context.Context, auther *models.Auther) ([]models.WalletPointEntry, *faulterr.FaultErr)
+}
+
+func NewWalletService(s *dbstore.DBStore, m *master.Master) *WalletService {
+	return &WalletService{s, m}
+}
+
+// GetBalance gets the cached wallet balance of a user together with the balance derived from the ledger
+func (s *WalletService) GetBalance(ctx context.Context, userID int64, auther *models.Auther) (*models.WalletBalance, *faulterr.FaultErr) {
+	if !auther.IsAdmin && auther.ID != userID {
+		return nil, faulterr.NewNotFoundError("no wallet found")
+	}
+
+	profile, err := s.dbstore.ProfileStore.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.dbstore.WalletPointEntryStore.GetBalance(ctx, userID, time.Now().Add(models.WalletPointsExpiryNotice))
+	if err != nil {
+		return nil, err
+	}
+	balance.Points = profile.WalletPoints
+
+	return balance, nil
+}
+
+// ListEntries gets the wallet point history of a user, latest first
+func (s *WalletService) ListEntries(ctx context.Context, userID int64, auther *models.Auther) ([]models.WalletPointEntry, *faulterr.FaultErr) {
+	if !auther.IsAdmin && auther.ID != userID {
+		return nil, faulterr.NewNotFoundError("no wallet found")
+	}
+	return s.dbstore.WalletPointEntryStore.ListByUserID(ctx, userID)
+}
+
+// Adjust corrects the wallet of a user with a reason, only admins can adjust wallets
+func (s *WalletService) Adjust(ctx context.Context, r models.WalletAdjustmentRequest, auther *models.Auther) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.WalletMaster.Adjust(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ExpireAll writes off the expired points of every wallet, each wallet is expired in its own transaction
+func (s *WalletService) ExpireAll(ctx context.Context, auther *models.Auther) ([]models.WalletPointEntry, *faulterr.FaultErr) {
+	if !auther.IsAdmin {
+		return nil, faulterr.NewUnauthorizedError("Permission not granted")
+	}
+
+	userIDs, err := s.dbstore.WalletPointEntryStore.ListUserIDsWithExpired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []models.WalletPointEntry{}
+	for _, userID := range userIDs {
+		entry, err := s.expire(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *WalletService) expire(ctx context.Context, userID int64) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	entry, err := s.master.WalletMaster.Expire(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
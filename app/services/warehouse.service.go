@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"orijinplus/app/master"
+	"orijinplus/app/models"
+	"orijinplus/app/store/dbstore"
+	"orijinplus/utils/faulterr"
+)
+
+type WarehouseService struct {
+	dbstore *dbstore.DBStore
+	master  *master.Master
+}
+
+var _ WarehouseServiceInterface = &WarehouseService{}
+
+type WarehouseServiceInterface interface {
+	List(ctx context.Context, auther *models.Auther) ([]models.Warehouse, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr)
+	Create(ctx context.Context, request models.WarehouseRequest, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr)
+	Update(ctx context.Context, id int64, request models.WarehouseRequest, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr)
+	SetArchived(ctx context.Context, id int64, archived bool, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr)
+	ListLocations(ctx context.Context, warehouseID int64, auther *models.Auther) ([]models.Location, *faulterr.FaultErr)
+	GetLocation(ctx context.Context, id int64, auther *models.Auther) (*models.Location, *faulterr.FaultErr)
+	ListChildLocations(ctx context.Context, id int64, auther *models.Auther) ([]models.Location, *faulterr.FaultErr)
+	CreateLocation(ctx context.Context, request models.LocationRequest, auther *models.Auther) (*models.Location, *faulterr.FaultErr)
+	UpdateLocation(ctx context.Context, id int64, request models.LocationRequest, auther *models.Auther) (*models.Location, *faulterr.FaultErr)
+	DeleteLocation(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr
+	ListContents(ctx context.Context, locationID int64, nested bool, auther *models.Auther) ([]models.Pallet, []models.Container, *faulterr.FaultErr)
+}
+
+func NewWarehouseService(s *dbstore.DBStore, m *master.Master) *WarehouseService {
+	return &WarehouseService{s, m}
+}
+
+// List gets all warehouses
+func (s *WarehouseService) List(ctx context.Context, auther *models.Auther) ([]models.Warehouse, *faulterr.FaultErr) {
+	if auther.IsAdmin {
+		return s.dbstore.WarehouseStore.List(ctx)
+	}
+	return s.dbstore.WarehouseStore.ListByOrgID(ctx, auther.OrganizationID.Int64)
+}
+
+func (s *WarehouseService) GetByID(ctx context.Context, id int64, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr) {
+	obj, err := s.dbstore.WarehouseStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auther.IsAdmin && auther.OrganizationID.Int64 != obj.OrganizationID {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return obj, nil
+}
+
+func (s *WarehouseService) Create(ctx context.Context, r models.WarehouseRequest, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr) {
+	if auther.IsAdmin && !r.OrganizationID.Valid {
+		return nil, faulterr.NewBadRequestError("organization id is required")
+	}
+	// Reassign organization ID to the request
+	if !auther.IsAdmin {
+		r.OrganizationID = auther.OrganizationID
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.WarehouseMaster.Create(ctx, tx, r, auther.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *WarehouseService) Update(ctx context.Context, id int64, r models.WarehouseRequest, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr) {
+	current, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.WarehouseMaster.Update(ctx, tx, current, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// SetArchived archives or unarchives a warehouse, nothing can be put in an archived warehouse
+func (s *WarehouseService) SetArchived(ctx context.Context, id int64, archived bool, auther *models.Auther) (*models.Warehouse, *faulterr.FaultErr) {
+	warehouse, err := s.GetByID(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+	warehouse.IsArchived = archived
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.dbstore.WarehouseStore.Update(ctx, tx, *warehouse); err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+// ListLocations gets all locations of a warehouse
+func (s *WarehouseService) ListLocations(ctx context.Context, warehouseID int64, auther *models.Auther) ([]models.Location, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, warehouseID, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.LocationStore.ListByWarehouseID(ctx, warehouseID)
+}
+
+// GetLocation gets a location of a warehouse of the organization
+func (s *WarehouseService) GetLocation(ctx context.Context, id int64, auther *models.Auther) (*models.Location, *faulterr.FaultErr) {
+	obj, err := s.dbstore.LocationStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetByID(ctx, obj.WarehouseID, auther); err != nil {
+		return nil, faulterr.NewNotFoundError("no object found")
+	}
+	return obj, nil
+}
+
+// ListChildLocations gets the locations directly inside a location
+func (s *WarehouseService) ListChildLocations(ctx context.Context, id int64, auther *models.Auther) ([]models.Location, *faulterr.FaultErr) {
+	if _, err := s.GetLocation(ctx, id, auther); err != nil {
+		return nil, err
+	}
+	return s.dbstore.LocationStore.ListByParentID(ctx, id)
+}
+
+func (s *WarehouseService) CreateLocation(ctx context.Context, r models.LocationRequest, auther *models.Auther) (*models.Location, *faulterr.FaultErr) {
+	if _, err := s.GetByID(ctx, r.WarehouseID, auther); err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.WarehouseMaster.CreateLocation(ctx, tx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *WarehouseService) UpdateLocation(ctx context.Context, id int64, r models.LocationRequest, auther *models.Auther) (*models.Location, *faulterr.FaultErr) {
+	current, err := s.GetLocation(ctx, id, auther)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transactions
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	obj, err := s.master.WarehouseMaster.UpdateLocation(ctx, tx, current, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *WarehouseService) DeleteLocation(ctx context.Context, id int64, auther *models.Auther) *faulterr.FaultErr {
+	current, err := s.GetLocation(ctx, id, auther)
+	if err != nil {
+		return err
+	}
+
+	// Start db transaction
+	tx, err := s.dbstore.DBTX.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.dbstore.DBTX.RollbackTx(ctx, tx)
+
+	if err := s.master.WarehouseMaster.DeleteLocation(ctx, tx, current); err != nil {
+		return err
+	}
+	if err := s.dbstore.DBTX.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListContents gets the loose pallets and the containers at a location,
+// nested also includes everything stored in the locations inside it
+func (s *WarehouseService) ListContents(ctx context.Context, locationID int64, nested bool, auther *models.Auther) ([]models.Pallet, []models.Container, *faulterr.FaultErr) {
+	if _, err := s.GetLocation(ctx, locationID, auther); err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int64{locationID}
+	if nested {
+		subtree, err := s.dbstore.LocationStore.ListSubtreeIDs(ctx, locationID)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = subtree
+	}
+
+	pallets, err := s.dbstore.PalletStore.ListByLocationIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	containers, err := s.dbstore.ContainerStore.ListByLocationIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pallets, containers, nil
+}
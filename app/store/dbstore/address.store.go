@@ -4,6 +4,7 @@ import (
 	"context"
 	"orijinplus/app/models"
 	"orijinplus/utils/faulterr"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v4"
@@ -17,13 +18,17 @@ type AddressStore struct {
 var _ AddressStoreInterface = &AddressStore{}
 
 type AddressStoreInterface interface {
-	GetMany(ctx context.Context, ids []int64) ([]*models.Address, []error)
+	GetMany(ctx context.Context, ids []int64) ([]*models.Address, error)
 	List(ctx context.Context) ([]models.Address, *faulterr.FaultErr)
 	ListByUserID(ctx context.Context, userID int64) ([]models.Address, *faulterr.FaultErr)
-	GetByID(ctx context.Context, userID int64) (*models.Address, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Address, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Address, *faulterr.FaultErr)
+	IsInUse(ctx context.Context, id int64) (bool, *faulterr.FaultErr)
 	Insert(ctx context.Context, tx pgx.Tx, p models.Address) (*models.Address, *faulterr.FaultErr)
 	Update(ctx context.Context, tx pgx.Tx, p models.Address) *faulterr.FaultErr
-	Delete(ctx context.Context, tx pgx.Tx, userID int64) *faulterr.FaultErr
+	ClearDefaultByUserID(ctx context.Context, tx pgx.Tx, userID int64) *faulterr.FaultErr
+	ClearDefaultByOrgID(ctx context.Context, tx pgx.Tx, orgID int64) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
 }
 
 func NewAddressStore(conn *pgxpool.Pool) *AddressStore {
@@ -35,12 +40,13 @@ func NewAddressStore(conn *pgxpool.Pool) *AddressStore {
 ///////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetMany get all addresses by ids
-func (s *AddressStore) GetMany(ctx context.Context, ids []int64) ([]*models.Address, []error) {
+func (s *AddressStore) GetMany(ctx context.Context, ids []int64) ([]*models.Address, error) {
 	placeholders := make([]string, len(ids))
 	args := make([]interface{}, len(ids))
 	for i := 0; i < len(ids); i++ {
-		placeholders[i] = "?"
-		args[i] = i
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
 	}
 
 	qeryStmt := "SELECT * from addresses WHERE id IN (" + strings.Join(placeholders, ",") + ")"
@@ -49,93 +55,83 @@ func (s *AddressStore) GetMany(ctx context.Context, ids []int64) ([]*models.Addr
 
 	rows, err := s.conn.Query(ctx, qeryStmt, args...)
 	if err != nil {
-		var errs []error
-		errs = append(errs, err)
-		return nil, errs
+		return nil, err
 	}
+	defer rows.Close()
 
 	addresses, err := s.scanList(rows)
 	if err != nil {
-		var errs []error
-		errs = append(errs, err)
-		return nil, errs
+		return nil, err
 	}
 
-	addressList := []*models.Address{}
-	for _, a := range addresses {
-		addressList = append(addressList, &a)
+	result := []*models.Address{}
+	for i := 0; i < len(addresses); i++ {
+		result = append(result, &addresses[i])
 	}
 
-	return addressList, nil
+	return result, nil
 }
 
 // List gets all addresses
 func (s *AddressStore) List(ctx context.Context) ([]models.Address, *faulterr.FaultErr) {
 	qeryStmt := `SELECT * FROM addresses`
 
-	addresses := []models.Address{}
-	a := models.Address{}
 	errMsg := "error when trying to get addresses"
-
 	rows, err := s.conn.Query(ctx, qeryStmt)
 	if err != nil {
 		return nil, faulterr.NewPostgresError(err, errMsg)
 	}
+	defer rows.Close()
 
-	for rows.Next() {
-		if err := rows.Scan(
-			&a.ID,
-			&a.UserID,
-			&a.Tag,
-			&a.Line1,
-			&a.Line2,
-			&a.Line3,
-			&a.City,
-			&a.State,
-			&a.Country,
-			&a.Pincode,
-			&a.CreatedAt,
-			&a.UpdatedAt,
-		); err != nil {
-			return nil, faulterr.NewPostgresError(err, errMsg)
-		}
-		addresses = append(addresses, a)
+	addresses, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
 	}
 
 	return addresses, nil
 }
 
-// ListByUserID gets all addresses
+// ListByUserID gets all addresses of a customer, the default address first
 func (s *AddressStore) ListByUserID(ctx context.Context, userID int64) ([]models.Address, *faulterr.FaultErr) {
-	qeryStmt := `SELECT * FROM addresses WHERE user_id=$1`
+	qeryStmt := `
+	SELECT * FROM addresses
+	WHERE user_id=$1
+	ORDER BY is_default DESC, id
+	`
 
-	addresses := []models.Address{}
-	a := models.Address{}
 	errMsg := "error when trying to get addresses"
-
 	rows, err := s.conn.Query(ctx, qeryStmt, userID)
 	if err != nil {
 		return nil, faulterr.NewPostgresError(err, errMsg)
 	}
+	defer rows.Close()
 
-	for rows.Next() {
-		if err := rows.Scan(
-			&a.ID,
-			&a.UserID,
-			&a.Tag,
-			&a.Line1,
-			&a.Line2,
-			&a.Line3,
-			&a.City,
-			&a.State,
-			&a.Country,
-			&a.Pincode,
-			&a.CreatedAt,
-			&a.UpdatedAt,
-		); err != nil {
-			return nil, faulterr.NewPostgresError(err, errMsg)
-		}
-		addresses = append(addresses, a)
+	addresses, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return addresses, nil
+}
+
+// ListByOrgID gets all addresses of an organization, the default address first
+func (s *AddressStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Address, *faulterr.FaultErr) {
+	qeryStmt := `
+	SELECT * FROM addresses
+	WHERE organization_id=$1
+	ORDER BY is_default DESC, id
+	`
+
+	errMsg := "error when trying to get addresses"
+	rows, err := s.conn.Query(ctx, qeryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	addresses, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
 	}
 
 	return addresses, nil
@@ -143,30 +139,33 @@ func (s *AddressStore) ListByUserID(ctx context.Context, userID int64) ([]models
 
 // GetByID Address
 func (s *AddressStore) GetByID(ctx context.Context, id int64) (*models.Address, *faulterr.FaultErr) {
-	queryStmt := `SELECT * FROM address WHERE id=$1`
+	queryStmt := `SELECT * FROM addresses WHERE id=$1`
 
-	a := models.Address{}
-	errMsg := "error when trying to get address by id"
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	a, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get address by id")
+	}
 
+	return a, nil
+}
+
+// IsInUse checks whether consumer orders, distributors or shipments refer to the address
+func (s *AddressStore) IsInUse(ctx context.Context, id int64) (bool, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT
+		EXISTS (SELECT 1 FROM consumer_orders WHERE address_id=$1)
+		OR EXISTS (SELECT 1 FROM distributors WHERE address_id=$1)
+		OR EXISTS (SELECT 1 FROM shipments WHERE origin_address_id=$1 OR destination_address_id=$1)
+	`
+
+	var inUse bool
 	row := s.conn.QueryRow(ctx, queryStmt, id)
-	if err := row.Scan(
-		&a.ID,
-		&a.UserID,
-		&a.Tag,
-		&a.Line1,
-		&a.Line2,
-		&a.Line3,
-		&a.City,
-		&a.State,
-		&a.Country,
-		&a.Pincode,
-		&a.CreatedAt,
-		&a.UpdatedAt,
-	); err != nil {
-		return nil, faulterr.NewPostgresError(err, errMsg)
+	if err := row.Scan(&inUse); err != nil {
+		return false, faulterr.NewPostgresError(err, "error when trying to check address usage")
 	}
 
-	return &a, nil
+	return inUse, nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -177,12 +176,11 @@ func (s *AddressStore) GetByID(ctx context.Context, id int64) (*models.Address,
 func (s *AddressStore) Insert(ctx context.Context, tx pgx.Tx, a models.Address) (*models.Address, *faulterr.FaultErr) {
 	queryStmt := `
 	INSERT INTO
-	addresses(user_id, tag, line_1, line_2, line_3, city, state, country, pincode)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	addresses(user_id, tag, line_1, line_2, line_3, city, state, country, pincode, organization_id, is_default)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	RETURNING *
 	`
 
-	errMsg := "error when trying to insert address"
 	row := tx.QueryRow(ctx, queryStmt,
 		a.UserID,
 		a.Tag,
@@ -193,41 +191,33 @@ func (s *AddressStore) Insert(ctx context.Context, tx pgx.Tx, a models.Address)
 		a.State,
 		a.Country,
 		a.Pincode,
+		a.OrganizationID,
+		a.IsDefault,
 	)
-	if err := row.Scan(
-		&a.ID,
-		&a.UserID,
-		&a.Tag,
-		&a.Line1,
-		&a.Line2,
-		&a.Line3,
-		&a.City,
-		&a.State,
-		&a.Country,
-		&a.Pincode,
-		&a.CreatedAt,
-		&a.UpdatedAt,
-	); err != nil {
-		return nil, faulterr.NewPostgresError(err, errMsg)
+	address, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert address")
 	}
 
-	return &a, nil
+	return address, nil
 }
 
 // Update Address
 func (s *AddressStore) Update(ctx context.Context, tx pgx.Tx, a models.Address) *faulterr.FaultErr {
 	queryStmt := `
 	UPDATE addresses
-	SET 
-		tag=$1
-		line_1=$2
-		line_2=$3
-		line_3=$4
-		city=$5
-		state=$6
-		country=$7
-		pincode=$8
-	WHERE addresses.id=$9
+	SET
+		tag=$1,
+		line_1=$2,
+		line_2=$3,
+		line_3=$4,
+		city=$5,
+		state=$6,
+		country=$7,
+		pincode=$8,
+		is_default=$9,
+		updated_at=NOW()
+	WHERE addresses.id=$10
 	`
 
 	errMsg := "error when trying to update address"
@@ -240,6 +230,8 @@ func (s *AddressStore) Update(ctx context.Context, tx pgx.Tx, a models.Address)
 		a.State,
 		a.Country,
 		a.Pincode,
+		a.IsDefault,
+		a.ID,
 	)
 	if err != nil {
 		return faulterr.NewPostgresError(err, errMsg)
@@ -248,10 +240,32 @@ func (s *AddressStore) Update(ctx context.Context, tx pgx.Tx, a models.Address)
 	return nil
 }
 
+// ClearDefaultByUserID unsets the default address of a customer
+func (s *AddressStore) ClearDefaultByUserID(ctx context.Context, tx pgx.Tx, userID int64) *faulterr.FaultErr {
+	queryStmt := `UPDATE addresses SET is_default=FALSE, updated_at=NOW() WHERE user_id=$1 AND is_default`
+	_, err := tx.Exec(ctx, queryStmt, userID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to clear default address")
+	}
+
+	return nil
+}
+
+// ClearDefaultByOrgID unsets the default address of an organization
+func (s *AddressStore) ClearDefaultByOrgID(ctx context.Context, tx pgx.Tx, orgID int64) *faulterr.FaultErr {
+	queryStmt := `UPDATE addresses SET is_default=FALSE, updated_at=NOW() WHERE organization_id=$1 AND is_default`
+	_, err := tx.Exec(ctx, queryStmt, orgID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to clear default address")
+	}
+
+	return nil
+}
+
 // Delete Address
-func (s *AddressStore) Delete(ctx context.Context, tx pgx.Tx, userID int64) *faulterr.FaultErr {
+func (s *AddressStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
 	queryStmt := `DELETE FROM addresses WHERE id=$1`
-	_, err := tx.Exec(ctx, queryStmt, userID)
+	_, err := tx.Exec(ctx, queryStmt, id)
 	if err != nil {
 		return faulterr.NewPostgresError(err, "error when trying to delete address")
 	}
@@ -281,6 +295,8 @@ func (s *AddressStore) scanList(rows pgx.Rows) ([]models.Address, error) {
 			&a.Pincode,
 			&a.CreatedAt,
 			&a.UpdatedAt,
+			&a.OrganizationID,
+			&a.IsDefault,
 		); err != nil {
 			return nil, err
 		}
@@ -305,6 +321,8 @@ func (s *AddressStore) scanRow(row pgx.Row) (*models.Address, error) {
 		&a.Pincode,
 		&a.CreatedAt,
 		&a.UpdatedAt,
+		&a.OrganizationID,
+		&a.IsDefault,
 	); err != nil {
 		return nil, err
 	}
@@ -3,14 +3,48 @@ package dbstore
 import "github.com/jackc/pgx/v4/pgxpool"
 
 type DBStore struct {
-	DBTX              *DBTX
-	OrganizationStore *OrganizationStore
-	RoleStore         *RoleStore
-	UserStore         *UserStore
-	ProfileStore      *ProfileStore
-	AddressStore      *AddressStore
-	ContainerStore    *ContainerStore
-	PalletStore       *PalletStore
+	DBTX                     *DBTX
+	OrganizationStore        *OrganizationStore
+	RoleStore                *RoleStore
+	UserStore                *UserStore
+	ProfileStore             *ProfileStore
+	AddressStore             *AddressStore
+	ContainerStore           *ContainerStore
+	PalletStore              *PalletStore
+	SkuStore                 *SkuStore
+	OrderStore               *OrderStore
+	OrderItemStore           *OrderItemStore
+	OrderAllocationStore     *OrderAllocationStore
+	ContractStore            *ContractStore
+	ContractDocumentStore    *ContractDocumentStore
+	DistributorStore         *DistributorStore
+	TaskStore                *TaskStore
+	TaskCommentStore         *TaskCommentStore
+	PurchaseRecordStore      *PurchaseRecordStore
+	ConsumerOrderStore       *ConsumerOrderStore
+	ConsumerOrderItemStore   *ConsumerOrderItemStore
+	TrackActionStore         *TrackActionStore
+	ReferralStore            *ReferralStore
+	ReferralRuleStore        *ReferralRuleStore
+	WalletPointEntryStore    *WalletPointEntryStore
+	CodeCounterStore         *CodeCounterStore
+	CodeFormatStore          *CodeFormatStore
+	PalletAssignmentStore    *PalletAssignmentStore
+	ContainerTransitionStore *ContainerTransitionStore
+	ShipmentStore            *ShipmentStore
+	ShipmentContainerStore   *ShipmentContainerStore
+	WarehouseStore           *WarehouseStore
+	LocationStore            *LocationStore
+	LocationMoveStore        *LocationMoveStore
+	ProvenanceSettingsStore  *ProvenanceSettingsStore
+	GS1SettingsStore         *GS1SettingsStore
+	EPCISEventStore          *EPCISEventStore
+	AttributeDefinitionStore *AttributeDefinitionStore
+	StoredFileStore          *StoredFileStore
+	RecallStore              *RecallStore
+	RecallItemStore          *RecallItemStore
+	NotificationStore        *NotificationStore
+	LotStore                 *LotStore
 }
 
 func NewDBStore(conn *pgxpool.Pool) *DBStore {
@@ -23,5 +57,39 @@ func NewDBStore(conn *pgxpool.Pool) *DBStore {
 		NewAddressStore(conn),
 		NewContainerStore(conn),
 		NewPalletStore(conn),
+		NewSkuStore(conn),
+		NewOrderStore(conn),
+		NewOrderItemStore(conn),
+		NewOrderAllocationStore(conn),
+		NewContractStore(conn),
+		NewContractDocumentStore(conn),
+		NewDistributorStore(conn),
+		NewTaskStore(conn),
+		NewTaskCommentStore(conn),
+		NewPurchaseRecordStore(conn),
+		NewConsumerOrderStore(conn),
+		NewConsumerOrderItemStore(conn),
+		NewTrackActionStore(conn),
+		NewReferralStore(conn),
+		NewReferralRuleStore(conn),
+		NewWalletPointEntryStore(conn),
+		NewCodeCounterStore(conn),
+		NewCodeFormatStore(conn),
+		NewPalletAssignmentStore(conn),
+		NewContainerTransitionStore(conn),
+		NewShipmentStore(conn),
+		NewShipmentContainerStore(conn),
+		NewWarehouseStore(conn),
+		NewLocationStore(conn),
+		NewLocationMoveStore(conn),
+		NewProvenanceSettingsStore(conn),
+		NewGS1SettingsStore(conn),
+		NewEPCISEventStore(conn),
+		NewAttributeDefinitionStore(conn),
+		NewStoredFileStore(conn),
+		NewRecallStore(conn),
+		NewRecallItemStore(conn),
+		NewNotificationStore(conn),
+		NewLotStore(conn),
 	}
 }
@@ -0,0 +1,204 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type AttributeDefinitionStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ AttributeDefinitionStoreInterface = &AttributeDefinitionStore{}
+
+type AttributeDefinitionStoreInterface interface {
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.AttributeDefinition, *faulterr.FaultErr)
+	ListByEntity(ctx context.Context, orgID int64, entity string) ([]models.AttributeDefinition, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.AttributeDefinition, *faulterr.FaultErr)
+	Upsert(ctx context.Context, tx pgx.Tx, obj models.AttributeDefinition) (*models.AttributeDefinition, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewAttributeDefinitionStore(conn *pgxpool.Pool) *AttributeDefinitionStore {
+	return &AttributeDefinitionStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOrgID retrives the attribute definitions of an organization from database
+func (s *AttributeDefinitionStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.AttributeDefinition, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_attribute_definitions
+	WHERE organization_attribute_definitions.organization_id = $1
+	ORDER BY entity, key
+	`
+
+	errMsg := "error when trying to get attribute definitions"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	defs, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return defs, nil
+}
+
+// ListByEntity retrives the attribute definitions of an organization for an entity from database
+func (s *AttributeDefinitionStore) ListByEntity(ctx context.Context, orgID int64, entity string) ([]models.AttributeDefinition, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_attribute_definitions
+	WHERE organization_attribute_definitions.organization_id = $1
+	AND organization_attribute_definitions.entity = $2
+	ORDER BY key
+	`
+
+	errMsg := "error when trying to get attribute definitions"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID, entity)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	defs, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return defs, nil
+}
+
+// GetByID gets attribute definition by ID from database
+func (s *AttributeDefinitionStore) GetByID(ctx context.Context, id int64) (*models.AttributeDefinition, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_attribute_definitions
+	WHERE organization_attribute_definitions.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get attribute definition")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Upsert inserts or replaces the definition of an attribute of an organization for an entity in database
+func (s *AttributeDefinitionStore) Upsert(ctx context.Context, tx pgx.Tx, obj models.AttributeDefinition) (*models.AttributeDefinition, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	organization_attribute_definitions(
+		organization_id,
+		entity,
+		key,
+		label,
+		type,
+		required,
+		enum_values
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (organization_id, entity, key)
+	DO UPDATE SET
+		label=EXCLUDED.label,
+		type=EXCLUDED.type,
+		required=EXCLUDED.required,
+		enum_values=EXCLUDED.enum_values,
+		updated_at=NOW()
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrganizationID,
+		&obj.Entity,
+		&obj.Key,
+		&obj.Label,
+		&obj.Type,
+		&obj.Required,
+		&obj.EnumValues,
+	)
+
+	def, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to save attribute definition")
+	}
+
+	return def, nil
+}
+
+// Delete removes an attribute definition from database
+func (s *AttributeDefinitionStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM organization_attribute_definitions WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete attribute definition")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *AttributeDefinitionStore) scanList(rows pgx.Rows) ([]models.AttributeDefinition, error) {
+	defs := []models.AttributeDefinition{}
+
+	for rows.Next() {
+		// A fresh object per row so that enum values are not shared between definitions
+		obj := models.AttributeDefinition{}
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.OrganizationID,
+			&obj.Entity,
+			&obj.Key,
+			&obj.Label,
+			&obj.Type,
+			&obj.Required,
+			&obj.EnumValues,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		defs = append(defs, obj)
+	}
+
+	return defs, nil
+}
+
+func (s *AttributeDefinitionStore) scanRow(row pgx.Row) (*models.AttributeDefinition, error) {
+	obj := models.AttributeDefinition{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.OrganizationID,
+		&obj.Entity,
+		&obj.Key,
+		&obj.Label,
+		&obj.Type,
+		&obj.Required,
+		&obj.EnumValues,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,53 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type CodeCounterStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ CodeCounterStoreInterface = &CodeCounterStore{}
+
+type CodeCounterStoreInterface interface {
+	Increment(ctx context.Context, tx pgx.Tx, entity string, prefix string, period int, n int64) (int64, *faulterr.FaultErr)
+}
+
+func NewCodeCounterStore(conn *pgxpool.Pool) *CodeCounterStore {
+	return &CodeCounterStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Increment adds n to a counter and returns its new value, the counter row stays locked until
+// the transaction ends so a rolled back create does not leave a gap in the codes
+func (s *CodeCounterStore) Increment(ctx context.Context, tx pgx.Tx, entity string, prefix string, period int, n int64) (int64, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	code_counters(
+		entity,
+		prefix,
+		period,
+		value
+	)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (entity, prefix, period)
+	DO UPDATE SET value = code_counters.value + EXCLUDED.value
+	RETURNING value
+	`
+
+	var value int64
+	row := tx.QueryRow(ctx, queryStmt, entity, prefix, period, n)
+	if err := row.Scan(&value); err != nil {
+		return 0, faulterr.NewPostgresError(err, "error when trying to increment code counter")
+	}
+
+	return value, nil
+}
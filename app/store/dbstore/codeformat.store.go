@@ -0,0 +1,192 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type CodeFormatStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ CodeFormatStoreInterface = &CodeFormatStore{}
+
+type CodeFormatStoreInterface interface {
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.CodeFormat, *faulterr.FaultErr)
+	GetByOrgID(ctx context.Context, orgID int64, entity string) (*models.CodeFormat, *faulterr.FaultErr)
+	GetByPrefix(ctx context.Context, entity string, prefix string) (*models.CodeFormat, *faulterr.FaultErr)
+	Upsert(ctx context.Context, tx pgx.Tx, obj models.CodeFormat) (*models.CodeFormat, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, orgID int64, entity string) *faulterr.FaultErr
+}
+
+func NewCodeFormatStore(conn *pgxpool.Pool) *CodeFormatStore {
+	return &CodeFormatStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOrgID retrives the code formats of an organization from database
+func (s *CodeFormatStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.CodeFormat, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_code_formats
+	WHERE organization_code_formats.organization_id = $1
+	ORDER BY entity
+	`
+
+	errMsg := "error when trying to get code formats"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	formats, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return formats, nil
+}
+
+// GetByOrgID gets the code format of an organization for an entity from database
+func (s *CodeFormatStore) GetByOrgID(ctx context.Context, orgID int64, entity string) (*models.CodeFormat, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_code_formats
+	WHERE organization_code_formats.organization_id = $1
+	AND organization_code_formats.entity = $2
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, orgID, entity)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get code format")
+	}
+
+	return obj, nil
+}
+
+// GetByPrefix gets the code format using a prefix for an entity from database
+func (s *CodeFormatStore) GetByPrefix(ctx context.Context, entity string, prefix string) (*models.CodeFormat, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_code_formats
+	WHERE organization_code_formats.entity = $1
+	AND organization_code_formats.prefix = $2
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, entity, prefix)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get code format")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Upsert inserts or replaces the code format of an organization for an entity in database
+func (s *CodeFormatStore) Upsert(ctx context.Context, tx pgx.Tx, obj models.CodeFormat) (*models.CodeFormat, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	organization_code_formats(
+		organization_id,
+		entity,
+		prefix,
+		padding,
+		reset_yearly
+	)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (organization_id, entity)
+	DO UPDATE SET
+		prefix=EXCLUDED.prefix,
+		padding=EXCLUDED.padding,
+		reset_yearly=EXCLUDED.reset_yearly,
+		updated_at=NOW()
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrganizationID,
+		&obj.Entity,
+		&obj.Prefix,
+		&obj.Padding,
+		&obj.ResetYearly,
+	)
+
+	format, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to save code format")
+	}
+
+	return format, nil
+}
+
+// Delete removes the code format of an organization for an entity from database
+func (s *CodeFormatStore) Delete(ctx context.Context, tx pgx.Tx, orgID int64, entity string) *faulterr.FaultErr {
+	queryStmt := `
+	DELETE FROM organization_code_formats
+	WHERE organization_code_formats.organization_id = $1
+	AND organization_code_formats.entity = $2
+	`
+
+	_, err := tx.Exec(ctx, queryStmt, orgID, entity)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete code format")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *CodeFormatStore) scanList(rows pgx.Rows) ([]models.CodeFormat, error) {
+	formats := []models.CodeFormat{}
+	obj := models.CodeFormat{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.OrganizationID,
+			&obj.Entity,
+			&obj.Prefix,
+			&obj.Padding,
+			&obj.ResetYearly,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		formats = append(formats, obj)
+	}
+
+	return formats, nil
+}
+
+func (s *CodeFormatStore) scanRow(row pgx.Row) (*models.CodeFormat, error) {
+	obj := models.CodeFormat{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.OrganizationID,
+		&obj.Entity,
+		&obj.Prefix,
+		&obj.Padding,
+		&obj.ResetYearly,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,353 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ConsumerOrderStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ConsumerOrderStoreInterface = &ConsumerOrderStore{}
+
+type ConsumerOrderStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.ConsumerOrder, error)
+	List(ctx context.Context) ([]models.ConsumerOrder, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.ConsumerOrder, *faulterr.FaultErr)
+	ListByCustomerID(ctx context.Context, customerID int64) ([]models.ConsumerOrder, *faulterr.FaultErr)
+	ListBySkuID(ctx context.Context, skuID int64, excludedStatuses []string) ([]models.ConsumerOrder, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.ConsumerOrder, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.ConsumerOrder, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.ConsumerOrder, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.ConsumerOrder, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.ConsumerOrder) (*models.ConsumerOrder, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.ConsumerOrder) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewConsumerOrderStore(conn *pgxpool.Pool) *ConsumerOrderStore {
+	return &ConsumerOrderStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all consumer orders by ids
+func (s *ConsumerOrderStore) GetMany(ctx context.Context, ids []int64) ([]*models.ConsumerOrder, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from consumer_orders WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.ConsumerOrder{}
+	for i := 0; i < len(orders); i++ {
+		result = append(result, &orders[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all consumer orders from database
+func (s *ConsumerOrderStore) List(ctx context.Context) ([]models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM consumer_orders`
+
+	errMsg := "error when trying to get consumer orders"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// ListByOrgID retrives all consumer orders of an organization from database
+func (s *ConsumerOrderStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_orders
+	WHERE consumer_orders.organization_id = $1
+	`
+
+	errMsg := "error when trying to get consumer orders"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// ListByCustomerID retrives all consumer orders of a customer from database
+func (s *ConsumerOrderStore) ListByCustomerID(ctx context.Context, customerID int64) ([]models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_orders
+	WHERE consumer_orders.customer_id = $1
+	ORDER BY id DESC
+	`
+
+	errMsg := "error when trying to get consumer orders"
+	rows, err := s.conn.Query(ctx, queryStmt, customerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// GetByID gets consumer order by ID from database
+func (s *ConsumerOrderStore) GetByID(ctx context.Context, id int64) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_orders
+	WHERE consumer_orders.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get consumer order")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets consumer order by UID from database
+func (s *ConsumerOrderStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_orders
+	WHERE consumer_orders.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get consumer order")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets consumer order by code from database
+func (s *ConsumerOrderStore) GetByCode(ctx context.Context, code string) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_orders
+	WHERE consumer_orders.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get consumer order")
+	}
+
+	return obj, nil
+}
+
+// ListBySkuID retrives the consumer orders with items of a SKU which are not in one of the excluded statuses from database
+func (s *ConsumerOrderStore) ListBySkuID(ctx context.Context, skuID int64, excludedStatuses []string) ([]models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT consumer_orders.* FROM consumer_orders
+	WHERE consumer_orders.id IN (SELECT consumer_order_id FROM consumer_order_items WHERE consumer_order_items.sku_id = $1)
+	AND consumer_orders.status <> ALL($2)
+	ORDER BY consumer_orders.id
+	`
+
+	errMsg := "error when trying to get consumer orders"
+	rows, err := s.conn.Query(ctx, queryStmt, skuID, excludedStatuses)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// LockByID gets a consumer order and locks it until the transaction ends,
+// status changes and the wallet refunds they trigger are serialized on it
+func (s *ConsumerOrderStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_orders
+	WHERE consumer_orders.id = $1
+	FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock consumer order")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a consumer order in database
+func (s *ConsumerOrderStore) Insert(ctx context.Context, tx pgx.Tx, obj models.ConsumerOrder) (*models.ConsumerOrder, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	consumer_orders(
+		uid,
+		code,
+		status,
+		customer_id,
+		address_id,
+		total,
+		wallet_points,
+		organization_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Status,
+		&obj.CustomerID,
+		&obj.AddressID,
+		&obj.Total,
+		&obj.WalletPoints,
+		&obj.OrganizationID,
+	)
+
+	order, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert consumer order")
+	}
+
+	return order, nil
+}
+
+// Update updates a consumer order in database
+func (s *ConsumerOrderStore) Update(ctx context.Context, tx pgx.Tx, obj models.ConsumerOrder) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE consumer_orders
+	SET
+		status = $1,
+		updated_at = NOW()
+	WHERE id=$2
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Status,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update consumer order")
+	}
+
+	return nil
+}
+
+// Delete deletes a consumer order from database
+func (s *ConsumerOrderStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM consumer_orders WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete consumer order")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ConsumerOrderStore) scanList(rows pgx.Rows) ([]models.ConsumerOrder, error) {
+	orders := []models.ConsumerOrder{}
+	obj := models.ConsumerOrder{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Status,
+			&obj.CustomerID,
+			&obj.AddressID,
+			&obj.Total,
+			&obj.WalletPoints,
+			&obj.OrganizationID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		orders = append(orders, obj)
+	}
+
+	return orders, nil
+}
+
+func (s *ConsumerOrderStore) scanRow(row pgx.Row) (*models.ConsumerOrder, error) {
+	obj := models.ConsumerOrder{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Status,
+		&obj.CustomerID,
+		&obj.AddressID,
+		&obj.Total,
+		&obj.WalletPoints,
+		&obj.OrganizationID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
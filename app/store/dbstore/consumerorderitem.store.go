@@ -0,0 +1,140 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ConsumerOrderItemStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ConsumerOrderItemStoreInterface = &ConsumerOrderItemStore{}
+
+type ConsumerOrderItemStoreInterface interface {
+	ListByConsumerOrderID(ctx context.Context, consumerOrderID int64) ([]models.ConsumerOrderItem, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.ConsumerOrderItem) (*models.ConsumerOrderItem, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewConsumerOrderItemStore(conn *pgxpool.Pool) *ConsumerOrderItemStore {
+	return &ConsumerOrderItemStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByConsumerOrderID retrives all items of a consumer order from database
+func (s *ConsumerOrderItemStore) ListByConsumerOrderID(ctx context.Context, consumerOrderID int64) ([]models.ConsumerOrderItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM consumer_order_items
+	WHERE consumer_order_items.consumer_order_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get consumer order items"
+	rows, err := s.conn.Query(ctx, queryStmt, consumerOrderID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	items, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return items, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a consumer order item in database
+func (s *ConsumerOrderItemStore) Insert(ctx context.Context, tx pgx.Tx, obj models.ConsumerOrderItem) (*models.ConsumerOrderItem, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	consumer_order_items(
+		consumer_order_id,
+		sku_id,
+		quantity,
+		unit_price
+	)
+	VALUES ($1, $2, $3, $4)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.ConsumerOrderID,
+		&obj.SkuID,
+		&obj.Quantity,
+		&obj.UnitPrice,
+	)
+
+	item, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert consumer order item")
+	}
+
+	return item, nil
+}
+
+// Delete deletes a consumer order item from database
+func (s *ConsumerOrderItemStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM consumer_order_items WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete consumer order item")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ConsumerOrderItemStore) scanList(rows pgx.Rows) ([]models.ConsumerOrderItem, error) {
+	items := []models.ConsumerOrderItem{}
+	obj := models.ConsumerOrderItem{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.ConsumerOrderID,
+			&obj.SkuID,
+			&obj.Quantity,
+			&obj.UnitPrice,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, obj)
+	}
+
+	return items, nil
+}
+
+func (s *ConsumerOrderItemStore) scanRow(row pgx.Row) (*models.ConsumerOrderItem, error) {
+	obj := models.ConsumerOrderItem{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.ConsumerOrderID,
+		&obj.SkuID,
+		&obj.Quantity,
+		&obj.UnitPrice,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -2,6 +2,7 @@ package dbstore
 
 import (
 	"context"
+	"fmt"
 	"orijinplus/app/models"
 	"orijinplus/utils/faulterr"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/volatiletech/null"
 )
 
 type ContainerStore struct {
@@ -19,12 +21,21 @@ type ContainerStore struct {
 var _ ContainerStoreInterface = &ContainerStore{}
 
 type ContainerStoreInterface interface {
-	GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr)
 	List(ctx context.Context) ([]models.Container, *faulterr.FaultErr)
+	ListByAttributes(ctx context.Context, orgID null.Int64, attrs map[string]interface{}) ([]models.Container, *faulterr.FaultErr)
+	ListByLocationIDs(ctx context.Context, locationIDs []int64) ([]models.Container, *faulterr.FaultErr)
+	ListByUIDs(ctx context.Context, uids []string) ([]models.Container, *faulterr.FaultErr)
+	ListByIDs(ctx context.Context, ids []int64) ([]models.Container, *faulterr.FaultErr)
 	GetByID(ctx context.Context, id int64) (*models.Container, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Container, *faulterr.FaultErr)
+	HasHistory(ctx context.Context, id int64) (bool, *faulterr.FaultErr)
 	GetByCode(ctx context.Context, code string) (*models.Container, *faulterr.FaultErr)
 	Insert(ctx context.Context, tx pgx.Tx, obj models.Container) (*models.Container, *faulterr.FaultErr)
+	InsertMany(ctx context.Context, tx pgx.Tx, objs []models.Container) ([]models.Container, *faulterr.FaultErr)
 	Update(ctx context.Context, tx pgx.Tx, obj models.Container) *faulterr.FaultErr
+	SetLocation(ctx context.Context, tx pgx.Tx, id int64, locationID null.Int64) *faulterr.FaultErr
+	SetSSCC(ctx context.Context, tx pgx.Tx, id int64, sscc string) *faulterr.FaultErr
+	RemoveAttribute(ctx context.Context, tx pgx.Tx, orgID int64, key string) *faulterr.FaultErr
 	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
 }
 
@@ -36,32 +47,6 @@ func NewContainerStore(conn *pgxpool.Pool) *ContainerStore {
 //////////////////////////////////////////****Read****/////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetLastInsertedRow retrives last row from database
-func (s *ContainerStore) GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr) {
-	queryStmt := `
-	SELECT id FROM containers
-	ORDER BY id DESC
-	LIMIT 1
-	`
-
-	var ID int64
-	errMsg := "error getting last inserted row"
-
-	rows, err := s.conn.Query(context.Background(), queryStmt)
-	if err != nil {
-		return 0, faulterr.NewPostgresError(err, errMsg)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		if err := rows.Scan(&ID); err != nil {
-			return 0, faulterr.NewPostgresError(err, errMsg)
-		}
-	}
-
-	return ID, nil
-}
-
 // GetMany get all containers by ids
 func (s *ContainerStore) GetMany(ctx context.Context, ids []int64) ([]*models.Container, error) {
 	placeholders := make([]string, len(ids))
@@ -76,13 +61,13 @@ func (s *ContainerStore) GetMany(ctx context.Context, ids []int64) ([]*models.Co
 	// errMsg := "error when trying to get containers"
 
 	containers := []models.Container{}
-	obj := models.Container{}
 	rows, err := s.conn.Query(ctx, queryStmt, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
+		obj := models.Container{}
 		if err := rows.Scan(
 			&obj.ID,
 			&obj.UID,
@@ -93,6 +78,10 @@ func (s *ContainerStore) GetMany(ctx context.Context, ids []int64) ([]*models.Co
 			&obj.CreatedByID,
 			&obj.CreatedAt,
 			&obj.UpdatedAt,
+			&obj.Status,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
 		); err != nil {
 			return nil, err
 		}
@@ -112,7 +101,6 @@ func (s *ContainerStore) List(ctx context.Context) ([]models.Container, *faulter
 	queryStmt := `SELECT * FROM containers`
 
 	containers := []models.Container{}
-	obj := models.Container{}
 	errMsg := "error when trying to get containers"
 
 	rows, err := s.conn.Query(context.Background(), queryStmt)
@@ -122,6 +110,7 @@ func (s *ContainerStore) List(ctx context.Context) ([]models.Container, *faulter
 	defer rows.Close()
 
 	for rows.Next() {
+		obj := models.Container{}
 		if err := rows.Scan(
 			&obj.ID,
 			&obj.UID,
@@ -132,6 +121,10 @@ func (s *ContainerStore) List(ctx context.Context) ([]models.Container, *faulter
 			&obj.CreatedByID,
 			&obj.CreatedAt,
 			&obj.UpdatedAt,
+			&obj.Status,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
 		); err != nil {
 			return nil, faulterr.NewPostgresError(err, errMsg)
 		}
@@ -149,7 +142,6 @@ func (s *ContainerStore) ListByOrgID(ctx context.Context, orgID int64) ([]models
 	`
 
 	containers := []models.Container{}
-	obj := models.Container{}
 	errMsg := "error when trying to get containers"
 
 	rows, err := s.conn.Query(ctx, queryStmt, orgID)
@@ -159,6 +151,7 @@ func (s *ContainerStore) ListByOrgID(ctx context.Context, orgID int64) ([]models
 	defer rows.Close()
 
 	for rows.Next() {
+		obj := models.Container{}
 		if err := rows.Scan(
 			&obj.ID,
 			&obj.UID,
@@ -169,6 +162,10 @@ func (s *ContainerStore) ListByOrgID(ctx context.Context, orgID int64) ([]models
 			&obj.CreatedByID,
 			&obj.CreatedAt,
 			&obj.UpdatedAt,
+			&obj.Status,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
 		); err != nil {
 			return nil, faulterr.NewPostgresError(err, errMsg)
 		}
@@ -178,6 +175,53 @@ func (s *ContainerStore) ListByOrgID(ctx context.Context, orgID int64) ([]models
 	return containers, nil
 }
 
+// ListByAttributes retrives the containers having all the attribute values, of an organization unless orgID is null
+func (s *ContainerStore) ListByAttributes(ctx context.Context, orgID null.Int64, attrs map[string]interface{}) ([]models.Container, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM containers
+	WHERE containers.attributes @> $1
+	AND ($2::bigint IS NULL OR containers.organization_id = $2)
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get containers"
+	rows, err := s.conn.Query(ctx, queryStmt, attrs, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	containers, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return containers, nil
+}
+
+// ListByLocationIDs retrives all containers stored at any of the locations
+func (s *ContainerStore) ListByLocationIDs(ctx context.Context, locationIDs []int64) ([]models.Container, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM containers
+	WHERE containers.location_id = ANY($1)
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get containers"
+	rows, err := s.conn.Query(ctx, queryStmt, locationIDs)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	containers, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return containers, nil
+}
+
 // GetByID gets container by ID from database
 func (s *ContainerStore) GetByID(ctx context.Context, id int64) (*models.Container, *faulterr.FaultErr) {
 	queryStmt := `
@@ -198,6 +242,10 @@ func (s *ContainerStore) GetByID(ctx context.Context, id int64) (*models.Contain
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.Status,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to get container")
 	}
@@ -205,6 +253,39 @@ func (s *ContainerStore) GetByID(ctx context.Context, id int64) (*models.Contain
 	return &obj, nil
 }
 
+// LockByID gets a container and locks it until the transaction ends,
+// which serializes status changes with pallet moves
+func (s *ContainerStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Container, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM containers
+	WHERE containers.id = $1
+	FOR UPDATE
+	`
+
+	obj := models.Container{}
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Description,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.Status,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+	); err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock container")
+	}
+
+	return &obj, nil
+}
+
 // GetByID gets container by UID from database
 func (s *ContainerStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Container, *faulterr.FaultErr) {
 	queryStmt := `
@@ -225,6 +306,41 @@ func (s *ContainerStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.C
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.Status,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+	); err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get container")
+	}
+
+	return &obj, nil
+}
+
+// GetBySSCC gets container by SSCC from database
+func (s *ContainerStore) GetBySSCC(ctx context.Context, sscc string) (*models.Container, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM containers
+	WHERE containers.sscc = $1
+	`
+
+	obj := models.Container{}
+
+	row := s.conn.QueryRow(ctx, queryStmt, sscc)
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Description,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.Status,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to get container")
 	}
@@ -252,6 +368,10 @@ func (s *ContainerStore) GetByCode(ctx context.Context, code string) (*models.Co
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.Status,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to get container")
 	}
@@ -259,6 +379,73 @@ func (s *ContainerStore) GetByCode(ctx context.Context, code string) (*models.Co
 	return &obj, nil
 }
 
+// ListByUIDs retrives the containers with the given UIDs from database
+func (s *ContainerStore) ListByUIDs(ctx context.Context, uids []string) ([]models.Container, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM containers
+	WHERE containers.uid = ANY($1::uuid[])
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get containers"
+	rows, err := s.conn.Query(ctx, queryStmt, uids)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	containers, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return containers, nil
+}
+
+// ListByIDs retrives the containers with the given IDs from database
+func (s *ContainerStore) ListByIDs(ctx context.Context, ids []int64) ([]models.Container, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM containers
+	WHERE containers.id = ANY($1)
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get containers"
+	rows, err := s.conn.Query(ctx, queryStmt, ids)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	containers, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return containers, nil
+}
+
+// HasHistory checks whether pallets, tasks, track actions, status transitions or shipments refer to the container
+func (s *ContainerStore) HasHistory(ctx context.Context, id int64) (bool, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT
+		EXISTS (SELECT 1 FROM pallets WHERE container_id=$1)
+		OR EXISTS (SELECT 1 FROM pallet_assignments WHERE container_id=$1)
+		OR EXISTS (SELECT 1 FROM container_transitions WHERE container_id=$1)
+		OR EXISTS (SELECT 1 FROM shipment_containers WHERE container_id=$1)
+		OR EXISTS (SELECT 1 FROM track_actions WHERE container_id=$1)
+		OR EXISTS (SELECT 1 FROM tasks WHERE container_id=$1)
+	`
+
+	var hasHistory bool
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	if err := row.Scan(&hasHistory); err != nil {
+		return false, faulterr.NewPostgresError(err, "error when trying to check container history")
+	}
+
+	return hasHistory, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////
 //////////////////////////////////////////****Mutate****///////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -273,9 +460,11 @@ func (s *ContainerStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Conta
 		description,
 		is_archived,
 		organization_id,
-		created_by_id
+		created_by_id,
+		sscc,
+		attributes
 	)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	RETURNING *
 	`
 
@@ -286,6 +475,8 @@ func (s *ContainerStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Conta
 		&obj.IsArchived,
 		&obj.OrganizationID,
 		&obj.CreatedByID,
+		&obj.SSCC,
+		&obj.Attributes,
 	)
 
 	if err := row.Scan(
@@ -298,6 +489,10 @@ func (s *ContainerStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Conta
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.Status,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to insert container")
 	}
@@ -305,19 +500,76 @@ func (s *ContainerStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Conta
 	return &obj, nil
 }
 
+// InsertMany inserts containers in a single statement
+func (s *ContainerStore) InsertMany(ctx context.Context, tx pgx.Tx, objs []models.Container) ([]models.Container, *faulterr.FaultErr) {
+	values := make([]string, len(objs))
+	args := make([]interface{}, 0, len(objs)*8)
+	for i, obj := range objs {
+		n := i * 8
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8)
+		args = append(args,
+			obj.UID,
+			obj.Code,
+			obj.Description,
+			obj.IsArchived,
+			obj.OrganizationID,
+			obj.CreatedByID,
+			obj.SSCC,
+			obj.Attributes,
+		)
+	}
+
+	queryStmt := `
+	INSERT INTO
+	containers(
+		uid,
+		code,
+		description,
+		is_archived,
+		organization_id,
+		created_by_id,
+		sscc,
+		attributes
+	)
+	VALUES ` + strings.Join(values, ", ") + `
+	RETURNING *
+	`
+
+	errMsg := "error when trying to insert containers"
+	rows, err := tx.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	containers, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return containers, nil
+}
+
 // Update updates a container in database
 func (s *ContainerStore) Update(ctx context.Context, tx pgx.Tx, obj models.Container) *faulterr.FaultErr {
 	queryStmt := `
 	UPDATE containers
 	SET
 		description = $1,
-		is_archived = $2
-	WHERE id=$3
+		is_archived = $2,
+		status = $3,
+		attributes = $4
+	WHERE id=$5
 	`
 
 	_, err := tx.Exec(ctx, queryStmt,
 		&obj.Description,
 		&obj.IsArchived,
+		&obj.Status,
+		&obj.Attributes,
 		&obj.ID,
 	)
 	if err != nil {
@@ -327,6 +579,42 @@ func (s *ContainerStore) Update(ctx context.Context, tx pgx.Tx, obj models.Conta
 	return nil
 }
 
+// SetSSCC gives a container its serial shipping container code
+func (s *ContainerStore) SetSSCC(ctx context.Context, tx pgx.Tx, id int64, sscc string) *faulterr.FaultErr {
+	queryStmt := `UPDATE containers SET sscc=$1 WHERE id=$2`
+
+	_, err := tx.Exec(ctx, queryStmt, sscc, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update container sscc")
+	}
+
+	return nil
+}
+
+// RemoveAttribute removes a custom attribute from the containers of an organization
+func (s *ContainerStore) RemoveAttribute(ctx context.Context, tx pgx.Tx, orgID int64, key string) *faulterr.FaultErr {
+	queryStmt := `UPDATE containers SET attributes = attributes - $1 WHERE organization_id=$2 AND attributes ? $1`
+
+	_, err := tx.Exec(ctx, queryStmt, key, orgID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to remove container attribute")
+	}
+
+	return nil
+}
+
+// SetLocation puts a container at a location, a null location takes it off its location
+func (s *ContainerStore) SetLocation(ctx context.Context, tx pgx.Tx, id int64, locationID null.Int64) *faulterr.FaultErr {
+	queryStmt := `UPDATE containers SET location_id=$1 WHERE id=$2`
+
+	_, err := tx.Exec(ctx, queryStmt, locationID, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update container location")
+	}
+
+	return nil
+}
+
 // Delete deletes a container from database
 func (s *ContainerStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
 	queryStmt := `DELETE FROM containers WHERE id=$1`
@@ -342,3 +630,32 @@ func (s *ContainerStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *fault
 ///////////////////////////////////////////////////////////////////////////////////////////////
 //////////////////////////////////////////****Helpers****//////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ContainerStore) scanList(rows pgx.Rows) ([]models.Container, error) {
+	containers := []models.Container{}
+
+	for rows.Next() {
+		// A fresh object per row so that attribute maps are not shared between containers
+		obj := models.Container{}
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Description,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+			&obj.Status,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
+		); err != nil {
+			return nil, err
+		}
+		containers = append(containers, obj)
+	}
+
+	return containers, nil
+}
@@ -0,0 +1,144 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ContainerTransitionStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ContainerTransitionStoreInterface = &ContainerTransitionStore{}
+
+type ContainerTransitionStoreInterface interface {
+	ListByContainerID(ctx context.Context, containerID int64) ([]models.ContainerTransition, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.ContainerTransition, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.ContainerTransition) (*models.ContainerTransition, *faulterr.FaultErr)
+}
+
+func NewContainerTransitionStore(conn *pgxpool.Pool) *ContainerTransitionStore {
+	return &ContainerTransitionStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByContainerID retrives all container transitions of a container from database
+func (s *ContainerTransitionStore) ListByContainerID(ctx context.Context, containerID int64) ([]models.ContainerTransition, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM container_transitions
+	WHERE container_transitions.container_id = $1
+	ORDER BY occurred_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get container transitions"
+	rows, err := s.conn.Query(ctx, queryStmt, containerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	transitions, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return transitions, nil
+}
+
+// GetByID gets container transition by ID from database
+func (s *ContainerTransitionStore) GetByID(ctx context.Context, id int64) (*models.ContainerTransition, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM container_transitions
+	WHERE container_transitions.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get container transition")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a container transition in database
+func (s *ContainerTransitionStore) Insert(ctx context.Context, tx pgx.Tx, obj models.ContainerTransition) (*models.ContainerTransition, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	container_transitions(
+		container_id,
+		from_status,
+		to_status,
+		actor_id
+	)
+	VALUES ($1, $2, $3, $4)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.ContainerID,
+		&obj.FromStatus,
+		&obj.ToStatus,
+		&obj.ActorID,
+	)
+
+	transition, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert container transition")
+	}
+
+	return transition, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ContainerTransitionStore) scanList(rows pgx.Rows) ([]models.ContainerTransition, error) {
+	transitions := []models.ContainerTransition{}
+	obj := models.ContainerTransition{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.ContainerID,
+			&obj.FromStatus,
+			&obj.ToStatus,
+			&obj.ActorID,
+			&obj.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, obj)
+	}
+
+	return transitions, nil
+}
+
+func (s *ContainerTransitionStore) scanRow(row pgx.Row) (*models.ContainerTransition, error) {
+	obj := models.ContainerTransition{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.ContainerID,
+		&obj.FromStatus,
+		&obj.ToStatus,
+		&obj.ActorID,
+		&obj.OccurredAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,351 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ContractStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ContractStoreInterface = &ContractStore{}
+
+type ContractStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Contract, error)
+	List(ctx context.Context) ([]models.Contract, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Contract, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Contract, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Contract, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Contract, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Contract, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Contract) (*models.Contract, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Contract) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewContractStore(conn *pgxpool.Pool) *ContractStore {
+	return &ContractStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all contracts by ids
+func (s *ContractStore) GetMany(ctx context.Context, ids []int64) ([]*models.Contract, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from contracts WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contracts, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Contract{}
+	for i := 0; i < len(contracts); i++ {
+		result = append(result, &contracts[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all contracts from database
+func (s *ContractStore) List(ctx context.Context) ([]models.Contract, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM contracts`
+
+	errMsg := "error when trying to get contracts"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	contracts, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return contracts, nil
+}
+
+// ListByOrgID retrives all contracts where an organization is the supplier or the buyer
+func (s *ContractStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Contract, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contracts
+	WHERE contracts.organization_id = $1
+	OR contracts.buyer_organization_id = $1
+	`
+
+	errMsg := "error when trying to get contracts"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	contracts, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return contracts, nil
+}
+
+// GetByID gets contract by ID from database
+func (s *ContractStore) GetByID(ctx context.Context, id int64) (*models.Contract, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contracts
+	WHERE contracts.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get contract")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets contract by UID from database
+func (s *ContractStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Contract, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contracts
+	WHERE contracts.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get contract")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets contract by code from database
+func (s *ContractStore) GetByCode(ctx context.Context, code string) (*models.Contract, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contracts
+	WHERE contracts.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get contract")
+	}
+
+	return obj, nil
+}
+
+// LockByID gets a contract and locks it until the transaction ends,
+// edits of the terms and approvals of either side are serialized on it
+func (s *ContractStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Contract, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contracts
+	WHERE contracts.id = $1
+	FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock contract")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a contract in database
+func (s *ContractStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Contract) (*models.Contract, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	contracts(
+		uid,
+		code,
+		title,
+		description,
+		buyer_organization_id,
+		start_date,
+		end_date,
+		pricing_terms,
+		volume_terms,
+		is_archived,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Title,
+		&obj.Description,
+		&obj.BuyerOrganizationID,
+		&obj.StartDate,
+		&obj.EndDate,
+		&obj.PricingTerms,
+		&obj.VolumeTerms,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	contract, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert contract")
+	}
+
+	return contract, nil
+}
+
+// Update updates a contract in database
+func (s *ContractStore) Update(ctx context.Context, tx pgx.Tx, obj models.Contract) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE contracts
+	SET
+		title = $1,
+		description = $2,
+		buyer_organization_id = $3,
+		start_date = $4,
+		end_date = $5,
+		pricing_terms = $6,
+		volume_terms = $7,
+		supplier_approved_by_id = $8,
+		supplier_approved_at = $9,
+		buyer_approved_by_id = $10,
+		buyer_approved_at = $11,
+		is_archived = $12,
+		updated_at = NOW()
+	WHERE id=$13
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Title,
+		&obj.Description,
+		&obj.BuyerOrganizationID,
+		&obj.StartDate,
+		&obj.EndDate,
+		&obj.PricingTerms,
+		&obj.VolumeTerms,
+		&obj.SupplierApprovedByID,
+		&obj.SupplierApprovedAt,
+		&obj.BuyerApprovedByID,
+		&obj.BuyerApprovedAt,
+		&obj.IsArchived,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update contract")
+	}
+
+	return nil
+}
+
+// Delete deletes a contract from database
+func (s *ContractStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM contracts WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete contract")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ContractStore) scanList(rows pgx.Rows) ([]models.Contract, error) {
+	contracts := []models.Contract{}
+	obj := models.Contract{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Title,
+			&obj.Description,
+			&obj.BuyerOrganizationID,
+			&obj.StartDate,
+			&obj.EndDate,
+			&obj.PricingTerms,
+			&obj.VolumeTerms,
+			&obj.SupplierApprovedByID,
+			&obj.SupplierApprovedAt,
+			&obj.BuyerApprovedByID,
+			&obj.BuyerApprovedAt,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, obj)
+	}
+
+	return contracts, nil
+}
+
+func (s *ContractStore) scanRow(row pgx.Row) (*models.Contract, error) {
+	obj := models.Contract{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Title,
+		&obj.Description,
+		&obj.BuyerOrganizationID,
+		&obj.StartDate,
+		&obj.EndDate,
+		&obj.PricingTerms,
+		&obj.VolumeTerms,
+		&obj.SupplierApprovedByID,
+		&obj.SupplierApprovedAt,
+		&obj.BuyerApprovedByID,
+		&obj.BuyerApprovedAt,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,157 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ContractDocumentStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ContractDocumentStoreInterface = &ContractDocumentStore{}
+
+type ContractDocumentStoreInterface interface {
+	ListByContractID(ctx context.Context, contractID int64) ([]models.ContractDocument, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.ContractDocument, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.ContractDocument) (*models.ContractDocument, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewContractDocumentStore(conn *pgxpool.Pool) *ContractDocumentStore {
+	return &ContractDocumentStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByContractID retrives all documents attached to a contract from database
+func (s *ContractDocumentStore) ListByContractID(ctx context.Context, contractID int64) ([]models.ContractDocument, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contract_documents
+	WHERE contract_documents.contract_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get contract documents"
+	rows, err := s.conn.Query(ctx, queryStmt, contractID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	documents, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return documents, nil
+}
+
+// GetByID gets contract document by ID from database
+func (s *ContractDocumentStore) GetByID(ctx context.Context, id int64) (*models.ContractDocument, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM contract_documents
+	WHERE contract_documents.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get contract document")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a contract document in database
+func (s *ContractDocumentStore) Insert(ctx context.Context, tx pgx.Tx, obj models.ContractDocument) (*models.ContractDocument, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	contract_documents(
+		contract_id,
+		name,
+		url,
+		uploaded_by_id
+	)
+	VALUES ($1, $2, $3, $4)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.ContractID,
+		&obj.Name,
+		&obj.URL,
+		&obj.UploadedByID,
+	)
+
+	document, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert contract document")
+	}
+
+	return document, nil
+}
+
+// Delete deletes a contract document from database
+func (s *ContractDocumentStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM contract_documents WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete contract document")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ContractDocumentStore) scanList(rows pgx.Rows) ([]models.ContractDocument, error) {
+	documents := []models.ContractDocument{}
+	obj := models.ContractDocument{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.ContractID,
+			&obj.Name,
+			&obj.URL,
+			&obj.UploadedByID,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		documents = append(documents, obj)
+	}
+
+	return documents, nil
+}
+
+func (s *ContractDocumentStore) scanRow(row pgx.Row) (*models.ContractDocument, error) {
+	obj := models.ContractDocument{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.ContractID,
+		&obj.Name,
+		&obj.URL,
+		&obj.UploadedByID,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,345 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type DistributorStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ DistributorStoreInterface = &DistributorStore{}
+
+type DistributorStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Distributor, error)
+	List(ctx context.Context) ([]models.Distributor, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Distributor, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Distributor, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Distributor, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Distributor, *faulterr.FaultErr)
+	GetByPalletID(ctx context.Context, palletID int64) (*models.Distributor, *faulterr.FaultErr)
+	GetByAddressID(ctx context.Context, addressID int64) (*models.Distributor, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Distributor) (*models.Distributor, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Distributor) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewDistributorStore(conn *pgxpool.Pool) *DistributorStore {
+	return &DistributorStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all distributors by ids
+func (s *DistributorStore) GetMany(ctx context.Context, ids []int64) ([]*models.Distributor, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from distributors WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	distributors, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Distributor{}
+	for i := 0; i < len(distributors); i++ {
+		result = append(result, &distributors[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all distributors from database
+func (s *DistributorStore) List(ctx context.Context) ([]models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM distributors`
+
+	errMsg := "error when trying to get distributors"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	distributors, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return distributors, nil
+}
+
+// ListByOrgID retrives all distributors of an organization from database
+func (s *DistributorStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM distributors
+	WHERE distributors.organization_id = $1
+	`
+
+	errMsg := "error when trying to get distributors"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	distributors, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return distributors, nil
+}
+
+// GetByID gets distributor by ID from database
+func (s *DistributorStore) GetByID(ctx context.Context, id int64) (*models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM distributors
+	WHERE distributors.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get distributor")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets distributor by UID from database
+func (s *DistributorStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM distributors
+	WHERE distributors.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get distributor")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets distributor by code from database
+func (s *DistributorStore) GetByCode(ctx context.Context, code string) (*models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM distributors
+	WHERE distributors.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get distributor")
+	}
+
+	return obj, nil
+}
+
+// GetByPalletID gets the distributor of the order a pallet is allocated to
+func (s *DistributorStore) GetByPalletID(ctx context.Context, palletID int64) (*models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT distributors.* FROM distributors
+	INNER JOIN orders ON orders.distributor_id = distributors.id
+	INNER JOIN order_allocations ON order_allocations.order_id = orders.id
+	WHERE order_allocations.pallet_id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, palletID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get distributor")
+	}
+
+	return obj, nil
+}
+
+// GetByAddressID gets the distributor an address belongs to
+func (s *DistributorStore) GetByAddressID(ctx context.Context, addressID int64) (*models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM distributors
+	WHERE distributors.address_id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, addressID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get distributor")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a distributor in database
+func (s *DistributorStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Distributor) (*models.Distributor, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	distributors(
+		uid,
+		code,
+		name,
+		contact_name,
+		email,
+		phone,
+		regions,
+		address_id,
+		is_archived,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.ContactName,
+		&obj.Email,
+		&obj.Phone,
+		&obj.Regions,
+		&obj.AddressID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	distributor, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert distributor")
+	}
+
+	return distributor, nil
+}
+
+// Update updates a distributor in database
+func (s *DistributorStore) Update(ctx context.Context, tx pgx.Tx, obj models.Distributor) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE distributors
+	SET
+		name = $1,
+		contact_name = $2,
+		email = $3,
+		phone = $4,
+		regions = $5,
+		address_id = $6,
+		is_archived = $7,
+		updated_at = NOW()
+	WHERE id=$8
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Name,
+		&obj.ContactName,
+		&obj.Email,
+		&obj.Phone,
+		&obj.Regions,
+		&obj.AddressID,
+		&obj.IsArchived,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update distributor")
+	}
+
+	return nil
+}
+
+// Delete deletes a distributor from database
+func (s *DistributorStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM distributors WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete distributor")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *DistributorStore) scanList(rows pgx.Rows) ([]models.Distributor, error) {
+	distributors := []models.Distributor{}
+	obj := models.Distributor{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Name,
+			&obj.ContactName,
+			&obj.Email,
+			&obj.Phone,
+			&obj.Regions,
+			&obj.AddressID,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		distributors = append(distributors, obj)
+	}
+
+	return distributors, nil
+}
+
+func (s *DistributorStore) scanRow(row pgx.Row) (*models.Distributor, error) {
+	obj := models.Distributor{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.ContactName,
+		&obj.Email,
+		&obj.Phone,
+		&obj.Regions,
+		&obj.AddressID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
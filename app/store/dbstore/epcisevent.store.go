@@ -0,0 +1,89 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type EPCISEventStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ EPCISEventStoreInterface = &EPCISEventStore{}
+
+type EPCISEventStoreInterface interface {
+	Insert(ctx context.Context, tx pgx.Tx, obj models.EPCISEvent) (*models.EPCISEvent, *faulterr.FaultErr)
+}
+
+func NewEPCISEventStore(conn *pgxpool.Pool) *EPCISEventStore {
+	return &EPCISEventStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a captured EPCIS event in database, no event is returned when
+// the organization already captured one with the same event id
+func (s *EPCISEventStore) Insert(ctx context.Context, tx pgx.Tx, obj models.EPCISEvent) (*models.EPCISEvent, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	epcis_events(
+		event_id,
+		event_type,
+		event_time,
+		document,
+		organization_id,
+		captured_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT ((COALESCE(organization_id, 0)), event_id) DO NOTHING
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.EventID,
+		&obj.EventType,
+		&obj.EventTime,
+		&obj.Document,
+		&obj.OrganizationID,
+		&obj.CapturedByID,
+	)
+
+	event, err := s.scanRow(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert EPCIS event")
+	}
+
+	return event, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *EPCISEventStore) scanRow(row pgx.Row) (*models.EPCISEvent, error) {
+	obj := models.EPCISEvent{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.EventID,
+		&obj.EventType,
+		&obj.EventTime,
+		&obj.Document,
+		&obj.OrganizationID,
+		&obj.CapturedByID,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
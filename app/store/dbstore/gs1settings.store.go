@@ -0,0 +1,118 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type GS1SettingsStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ GS1SettingsStoreInterface = &GS1SettingsStore{}
+
+type GS1SettingsStoreInterface interface {
+	GetByOrgID(ctx context.Context, orgID int64) (*models.GS1Settings, *faulterr.FaultErr)
+	GetByPrefix(ctx context.Context, prefix string) (*models.GS1Settings, *faulterr.FaultErr)
+	Upsert(ctx context.Context, tx pgx.Tx, obj models.GS1Settings) (*models.GS1Settings, *faulterr.FaultErr)
+}
+
+func NewGS1SettingsStore(conn *pgxpool.Pool) *GS1SettingsStore {
+	return &GS1SettingsStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetByOrgID gets the GS1 settings of an organization from database
+func (s *GS1SettingsStore) GetByOrgID(ctx context.Context, orgID int64) (*models.GS1Settings, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_gs1_settings
+	WHERE organization_gs1_settings.organization_id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, orgID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get GS1 settings")
+	}
+
+	return obj, nil
+}
+
+// GetByPrefix gets the GS1 settings using a company prefix from database
+func (s *GS1SettingsStore) GetByPrefix(ctx context.Context, prefix string) (*models.GS1Settings, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_gs1_settings
+	WHERE organization_gs1_settings.company_prefix = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, prefix)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get GS1 settings")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Upsert inserts or replaces the GS1 settings of an organization in database
+func (s *GS1SettingsStore) Upsert(ctx context.Context, tx pgx.Tx, obj models.GS1Settings) (*models.GS1Settings, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	organization_gs1_settings(
+		organization_id,
+		company_prefix,
+		extension_digit
+	)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (organization_id)
+	DO UPDATE SET
+		company_prefix=EXCLUDED.company_prefix,
+		extension_digit=EXCLUDED.extension_digit,
+		updated_at=NOW()
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrganizationID,
+		&obj.CompanyPrefix,
+		&obj.ExtensionDigit,
+	)
+
+	settings, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to save GS1 settings")
+	}
+
+	return settings, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *GS1SettingsStore) scanRow(row pgx.Row) (*models.GS1Settings, error) {
+	obj := models.GS1Settings{}
+
+	if err := row.Scan(
+		&obj.OrganizationID,
+		&obj.CompanyPrefix,
+		&obj.ExtensionDigit,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
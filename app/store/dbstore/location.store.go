@@ -0,0 +1,281 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type LocationStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ LocationStoreInterface = &LocationStore{}
+
+type LocationStoreInterface interface {
+	ListByWarehouseID(ctx context.Context, warehouseID int64) ([]models.Location, *faulterr.FaultErr)
+	ListByParentID(ctx context.Context, parentID int64) ([]models.Location, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Location, *faulterr.FaultErr)
+	GetMany(ctx context.Context, ids []int64) ([]*models.Location, error)
+	ListSubtreeIDs(ctx context.Context, id int64) ([]int64, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Location) (*models.Location, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Location) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewLocationStore(conn *pgxpool.Pool) *LocationStore {
+	return &LocationStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByWarehouseID retrives all locations of a warehouse from database
+func (s *LocationStore) ListByWarehouseID(ctx context.Context, warehouseID int64) ([]models.Location, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM locations
+	WHERE locations.warehouse_id = $1
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get locations"
+	rows, err := s.conn.Query(ctx, queryStmt, warehouseID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	locations, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return locations, nil
+}
+
+// ListByParentID retrives all locations nested in a location from database
+func (s *LocationStore) ListByParentID(ctx context.Context, parentID int64) ([]models.Location, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM locations
+	WHERE locations.parent_id = $1
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get locations"
+	rows, err := s.conn.Query(ctx, queryStmt, parentID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	locations, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return locations, nil
+}
+
+// GetByID gets location by ID from database
+func (s *LocationStore) GetByID(ctx context.Context, id int64) (*models.Location, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM locations
+	WHERE locations.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get location")
+	}
+
+	return obj, nil
+}
+
+// GetMany get all locations by ids
+func (s *LocationStore) GetMany(ctx context.Context, ids []int64) ([]*models.Location, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from locations WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Location{}
+	for i := 0; i < len(locations); i++ {
+		result = append(result, &locations[i])
+	}
+
+	return result, nil
+}
+
+// ListSubtreeIDs gets the id of a location and of every location nested in it
+func (s *LocationStore) ListSubtreeIDs(ctx context.Context, id int64) ([]int64, *faulterr.FaultErr) {
+	queryStmt := `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM locations WHERE id = $1
+		UNION ALL
+		SELECT locations.id FROM locations
+		INNER JOIN subtree ON locations.parent_id = subtree.id
+	)
+	SELECT id FROM subtree
+	`
+
+	errMsg := "error when trying to get locations"
+	rows, err := s.conn.Query(ctx, queryStmt, id)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, faulterr.NewPostgresError(err, errMsg)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a location in database
+func (s *LocationStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Location) (*models.Location, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	locations(
+		uid,
+		code,
+		name,
+		kind,
+		warehouse_id,
+		parent_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.Kind,
+		&obj.WarehouseID,
+		&obj.ParentID,
+	)
+
+	location, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert location")
+	}
+
+	return location, nil
+}
+
+// Update updates a location in database
+func (s *LocationStore) Update(ctx context.Context, tx pgx.Tx, obj models.Location) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE locations
+	SET
+		code = $1,
+		name = $2,
+		updated_at = NOW()
+	WHERE id=$3
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Code,
+		&obj.Name,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update location")
+	}
+
+	return nil
+}
+
+// Delete deletes a location from database
+func (s *LocationStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM locations WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete location")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *LocationStore) scanList(rows pgx.Rows) ([]models.Location, error) {
+	locations := []models.Location{}
+	obj := models.Location{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Name,
+			&obj.Kind,
+			&obj.WarehouseID,
+			&obj.ParentID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		locations = append(locations, obj)
+	}
+
+	return locations, nil
+}
+
+func (s *LocationStore) scanRow(row pgx.Row) (*models.Location, error) {
+	obj := models.Location{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.Kind,
+		&obj.WarehouseID,
+		&obj.ParentID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
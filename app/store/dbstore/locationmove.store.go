@@ -0,0 +1,199 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type LocationMoveStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ LocationMoveStoreInterface = &LocationMoveStore{}
+
+type LocationMoveStoreInterface interface {
+	ListByPalletID(ctx context.Context, palletID int64) ([]models.LocationMove, *faulterr.FaultErr)
+	ListByContainerID(ctx context.Context, containerID int64) ([]models.LocationMove, *faulterr.FaultErr)
+	DeleteByPalletID(ctx context.Context, tx pgx.Tx, palletID int64) *faulterr.FaultErr
+	DeleteByContainerID(ctx context.Context, tx pgx.Tx, containerID int64) *faulterr.FaultErr
+	ExistsByLocationID(ctx context.Context, locationID int64) (bool, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.LocationMove) (*models.LocationMove, *faulterr.FaultErr)
+}
+
+func NewLocationMoveStore(conn *pgxpool.Pool) *LocationMoveStore {
+	return &LocationMoveStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByPalletID retrives all location moves of a pallet from database
+func (s *LocationMoveStore) ListByPalletID(ctx context.Context, palletID int64) ([]models.LocationMove, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM location_moves
+	WHERE location_moves.pallet_id = $1
+	ORDER BY moved_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get location moves"
+	rows, err := s.conn.Query(ctx, queryStmt, palletID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	moves, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return moves, nil
+}
+
+// ListByContainerID retrives all location moves of a container from database
+func (s *LocationMoveStore) ListByContainerID(ctx context.Context, containerID int64) ([]models.LocationMove, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM location_moves
+	WHERE location_moves.container_id = $1
+	ORDER BY moved_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get location moves"
+	rows, err := s.conn.Query(ctx, queryStmt, containerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	moves, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return moves, nil
+}
+
+// ExistsByLocationID reports whether anything was ever moved to or from a location
+func (s *LocationMoveStore) ExistsByLocationID(ctx context.Context, locationID int64) (bool, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT EXISTS (
+		SELECT 1 FROM location_moves
+		WHERE from_location_id = $1 OR to_location_id = $1
+	)
+	`
+
+	var exists bool
+	if err := s.conn.QueryRow(ctx, queryStmt, locationID).Scan(&exists); err != nil {
+		return false, faulterr.NewPostgresError(err, "error when trying to get location moves")
+	}
+
+	return exists, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a location move in database
+func (s *LocationMoveStore) Insert(ctx context.Context, tx pgx.Tx, obj models.LocationMove) (*models.LocationMove, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	location_moves(
+		pallet_id,
+		container_id,
+		from_location_id,
+		to_location_id,
+		actor_id
+	)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.PalletID,
+		&obj.ContainerID,
+		&obj.FromLocationID,
+		&obj.ToLocationID,
+		&obj.ActorID,
+	)
+
+	move, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert location move")
+	}
+
+	return move, nil
+}
+
+// DeleteByPalletID deletes the location moves of a pallet from database
+func (s *LocationMoveStore) DeleteByPalletID(ctx context.Context, tx pgx.Tx, palletID int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM location_moves WHERE pallet_id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, palletID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete location moves")
+	}
+
+	return nil
+}
+
+// DeleteByContainerID deletes the location moves of a container from database
+func (s *LocationMoveStore) DeleteByContainerID(ctx context.Context, tx pgx.Tx, containerID int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM location_moves WHERE container_id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, containerID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete location moves")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *LocationMoveStore) scanList(rows pgx.Rows) ([]models.LocationMove, error) {
+	moves := []models.LocationMove{}
+	obj := models.LocationMove{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.PalletID,
+			&obj.ContainerID,
+			&obj.FromLocationID,
+			&obj.ToLocationID,
+			&obj.ActorID,
+			&obj.MovedAt,
+		); err != nil {
+			return nil, err
+		}
+		moves = append(moves, obj)
+	}
+
+	return moves, nil
+}
+
+func (s *LocationMoveStore) scanRow(row pgx.Row) (*models.LocationMove, error) {
+	obj := models.LocationMove{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.PalletID,
+		&obj.ContainerID,
+		&obj.FromLocationID,
+		&obj.ToLocationID,
+		&obj.ActorID,
+		&obj.MovedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
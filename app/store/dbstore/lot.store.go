@@ -0,0 +1,367 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/volatiletech/null"
+)
+
+type LotStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ LotStoreInterface = &LotStore{}
+
+type LotStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Lot, error)
+	List(ctx context.Context) ([]models.Lot, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Lot, *faulterr.FaultErr)
+	ListBySkuID(ctx context.Context, skuID int64) ([]models.Lot, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Lot, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Lot, *faulterr.FaultErr)
+	ListExpiring(ctx context.Context, orgID null.Int64, now time.Time, until time.Time) ([]models.Lot, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Lot, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Lot) (*models.Lot, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Lot) *faulterr.FaultErr
+	FlagExpired(ctx context.Context, tx pgx.Tx, now time.Time) ([]models.Lot, *faulterr.FaultErr)
+}
+
+func NewLotStore(conn *pgxpool.Pool) *LotStore {
+	return &LotStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all lots by ids
+func (s *LotStore) GetMany(ctx context.Context, ids []int64) ([]*models.Lot, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from lots WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Lot{}
+	for i := 0; i < len(lots); i++ {
+		result = append(result, &lots[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all lots from database
+func (s *LotStore) List(ctx context.Context) ([]models.Lot, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM lots`
+
+	errMsg := "error when trying to get lots"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	lots, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return lots, nil
+}
+
+// ListByOrgID retrives all lots of an organization from database
+func (s *LotStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM lots
+	WHERE lots.organization_id = $1
+	`
+
+	errMsg := "error when trying to get lots"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	lots, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return lots, nil
+}
+
+// ListBySkuID retrives all lots of a SKU from database
+func (s *LotStore) ListBySkuID(ctx context.Context, skuID int64) ([]models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM lots
+	WHERE lots.sku_id = $1
+	ORDER BY production_date, code
+	`
+
+	errMsg := "error when trying to get lots"
+	rows, err := s.conn.Query(ctx, queryStmt, skuID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	lots, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return lots, nil
+}
+
+// GetByID gets lot by ID from database
+func (s *LotStore) GetByID(ctx context.Context, id int64) (*models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM lots
+	WHERE lots.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get lot")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets lot by UID from database
+func (s *LotStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM lots
+	WHERE lots.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get lot")
+	}
+
+	return obj, nil
+}
+
+// ListExpiring retrives the lots which have not expired yet but will by the given time from database,
+// soonest first, for every organization when no organization is given
+func (s *LotStore) ListExpiring(ctx context.Context, orgID null.Int64, now time.Time, until time.Time) ([]models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM lots
+	WHERE NOT lots.is_expired
+	AND lots.expiry_date > $1
+	AND lots.expiry_date <= $2
+	AND ($3::bigint IS NULL OR lots.organization_id = $3)
+	ORDER BY expiry_date, code
+	`
+
+	errMsg := "error when trying to get lots"
+	rows, err := s.conn.Query(ctx, queryStmt, now, until, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	lots, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return lots, nil
+}
+
+// LockByID gets a lot and locks it until the transaction ends
+func (s *LotStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM lots
+	WHERE lots.id = $1
+	FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock lot")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a lot in database
+func (s *LotStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Lot) (*models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	lots(
+		uid,
+		code,
+		sku_id,
+		production_date,
+		expiry_date,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.SkuID,
+		&obj.ProductionDate,
+		&obj.ExpiryDate,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	lot, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert lot")
+	}
+
+	return lot, nil
+}
+
+// Update updates a lot in database
+func (s *LotStore) Update(ctx context.Context, tx pgx.Tx, obj models.Lot) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE lots
+	SET
+		code = $1,
+		production_date = $2,
+		expiry_date = $3,
+		is_expired = $4,
+		expired_at = $5,
+		updated_at = NOW()
+	WHERE id=$6
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Code,
+		&obj.ProductionDate,
+		&obj.ExpiryDate,
+		&obj.IsExpired,
+		&obj.ExpiredAt,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update lot")
+	}
+
+	return nil
+}
+
+// FlagExpired flags the lots which reached their expiry date in database and returns them
+func (s *LotStore) FlagExpired(ctx context.Context, tx pgx.Tx, now time.Time) ([]models.Lot, *faulterr.FaultErr) {
+	queryStmt := `
+	UPDATE lots
+	SET
+		is_expired = TRUE,
+		expired_at = $1,
+		updated_at = NOW()
+	WHERE NOT is_expired
+	AND expiry_date <= $1
+	RETURNING *
+	`
+
+	errMsg := "error when trying to flag expired lots"
+	rows, err := tx.Query(ctx, queryStmt, now)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	lots, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return lots, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *LotStore) scanList(rows pgx.Rows) ([]models.Lot, error) {
+	lots := []models.Lot{}
+	obj := models.Lot{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.SkuID,
+			&obj.ProductionDate,
+			&obj.ExpiryDate,
+			&obj.IsExpired,
+			&obj.ExpiredAt,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		lots = append(lots, obj)
+	}
+
+	return lots, nil
+}
+
+func (s *LotStore) scanRow(row pgx.Row) (*models.Lot, error) {
+	obj := models.Lot{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.SkuID,
+		&obj.ProductionDate,
+		&obj.ExpiryDate,
+		&obj.IsExpired,
+		&obj.ExpiredAt,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
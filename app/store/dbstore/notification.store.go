@@ -0,0 +1,181 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/volatiletech/null"
+)
+
+type NotificationStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ NotificationStoreInterface = &NotificationStore{}
+
+type NotificationStoreInterface interface {
+	ListByRecipient(ctx context.Context, userID int64, orgID null.Int64) ([]models.Notification, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Notification, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Notification) (*models.Notification, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Notification) *faulterr.FaultErr
+}
+
+func NewNotificationStore(conn *pgxpool.Pool) *NotificationStore {
+	return &NotificationStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByRecipient retrives the notifications of a user and of the organization the user is a member of from database
+func (s *NotificationStore) ListByRecipient(ctx context.Context, userID int64, orgID null.Int64) ([]models.Notification, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM notifications
+	WHERE notifications.user_id = $1
+	OR notifications.organization_id = $2
+	ORDER BY created_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get notifications"
+	rows, err := s.conn.Query(ctx, queryStmt, userID, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	notifications, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return notifications, nil
+}
+
+// GetByID gets notification by ID from database
+func (s *NotificationStore) GetByID(ctx context.Context, id int64) (*models.Notification, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM notifications
+	WHERE notifications.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get notification")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a notification in database
+func (s *NotificationStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Notification) (*models.Notification, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	notifications(
+		organization_id,
+		user_id,
+		kind,
+		subject,
+		body,
+		entity_type,
+		entity_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrganizationID,
+		&obj.UserID,
+		&obj.Kind,
+		&obj.Subject,
+		&obj.Body,
+		&obj.EntityType,
+		&obj.EntityID,
+	)
+
+	notification, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert notification")
+	}
+
+	return notification, nil
+}
+
+// Update updates a notification in database
+func (s *NotificationStore) Update(ctx context.Context, tx pgx.Tx, obj models.Notification) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE notifications
+	SET
+		read_at = $1
+	WHERE id=$2
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.ReadAt,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update notification")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *NotificationStore) scanList(rows pgx.Rows) ([]models.Notification, error) {
+	notifications := []models.Notification{}
+	obj := models.Notification{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.OrganizationID,
+			&obj.UserID,
+			&obj.Kind,
+			&obj.Subject,
+			&obj.Body,
+			&obj.EntityType,
+			&obj.EntityID,
+			&obj.ReadAt,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, obj)
+	}
+
+	return notifications, nil
+}
+
+func (s *NotificationStore) scanRow(row pgx.Row) (*models.Notification, error) {
+	obj := models.Notification{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.OrganizationID,
+		&obj.UserID,
+		&obj.Kind,
+		&obj.Subject,
+		&obj.Body,
+		&obj.EntityType,
+		&obj.EntityID,
+		&obj.ReadAt,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,418 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OrderStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ OrderStoreInterface = &OrderStore{}
+
+type OrderStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Order, error)
+	List(ctx context.Context) ([]models.Order, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Order, *faulterr.FaultErr)
+	ListByContractID(ctx context.Context, contractID int64) ([]models.Order, *faulterr.FaultErr)
+	ListByDistributorID(ctx context.Context, distributorID int64) ([]models.Order, *faulterr.FaultErr)
+	ListBySkuID(ctx context.Context, skuID int64, excludedStatuses []string) ([]models.Order, *faulterr.FaultErr)
+	ListByPalletIDs(ctx context.Context, palletIDs []int64) ([]models.Order, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Order, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Order, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Order, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Order, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Order) (*models.Order, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Order) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewOrderStore(conn *pgxpool.Pool) *OrderStore {
+	return &OrderStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all orders by ids
+func (s *OrderStore) GetMany(ctx context.Context, ids []int64) ([]*models.Order, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from orders WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Order{}
+	for i := 0; i < len(orders); i++ {
+		result = append(result, &orders[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all orders from database
+func (s *OrderStore) List(ctx context.Context) ([]models.Order, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM orders`
+
+	errMsg := "error when trying to get orders"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// ListByOrgID retrives all orders sold or bought by an organization from database
+func (s *OrderStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.organization_id = $1
+	OR orders.buyer_organization_id = $1
+	`
+
+	errMsg := "error when trying to get orders"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// ListByContractID retrives all orders placed against a contract from database
+func (s *OrderStore) ListByContractID(ctx context.Context, contractID int64) ([]models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.contract_id = $1
+	`
+
+	errMsg := "error when trying to get orders"
+	rows, err := s.conn.Query(ctx, queryStmt, contractID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// ListByDistributorID retrives all orders routed through a distributor from database
+func (s *OrderStore) ListByDistributorID(ctx context.Context, distributorID int64) ([]models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.distributor_id = $1
+	`
+
+	errMsg := "error when trying to get orders"
+	rows, err := s.conn.Query(ctx, queryStmt, distributorID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// GetByID gets order by ID from database
+func (s *OrderStore) GetByID(ctx context.Context, id int64) (*models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get order")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets order by UID from database
+func (s *OrderStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get order")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets order by code from database
+func (s *OrderStore) GetByCode(ctx context.Context, code string) (*models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get order")
+	}
+
+	return obj, nil
+}
+
+// LockByID gets an order and locks it until the transaction ends,
+// status changes and the allocations they allow are serialized on it
+func (s *OrderStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM orders
+	WHERE orders.id = $1
+	FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock order")
+	}
+
+	return obj, nil
+}
+
+// ListBySkuID retrives the orders with items of a SKU which are not in one of the excluded statuses from database
+func (s *OrderStore) ListBySkuID(ctx context.Context, skuID int64, excludedStatuses []string) ([]models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT orders.* FROM orders
+	WHERE orders.id IN (SELECT order_id FROM order_items WHERE order_items.sku_id = $1)
+	AND orders.status <> ALL($2)
+	ORDER BY orders.id
+	`
+
+	errMsg := "error when trying to get orders"
+	rows, err := s.conn.Query(ctx, queryStmt, skuID, excludedStatuses)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+// ListByPalletIDs retrives the orders the given pallets are allocated to from database
+func (s *OrderStore) ListByPalletIDs(ctx context.Context, palletIDs []int64) ([]models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT orders.* FROM orders
+	WHERE orders.id IN (SELECT order_id FROM order_allocations WHERE order_allocations.pallet_id = ANY($1))
+	ORDER BY orders.id
+	`
+
+	errMsg := "error when trying to get orders"
+	rows, err := s.conn.Query(ctx, queryStmt, palletIDs)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	orders, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return orders, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts an order in database
+func (s *OrderStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Order) (*models.Order, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	orders(
+		uid,
+		code,
+		status,
+		notes,
+		buyer_organization_id,
+		is_archived,
+		organization_id,
+		created_by_id,
+		contract_id,
+		distributor_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Status,
+		&obj.Notes,
+		&obj.BuyerOrganizationID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.ContractID,
+		&obj.DistributorID,
+	)
+
+	order, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert order")
+	}
+
+	return order, nil
+}
+
+// Update updates an order in database
+func (s *OrderStore) Update(ctx context.Context, tx pgx.Tx, obj models.Order) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE orders
+	SET
+		status = $1,
+		notes = $2,
+		buyer_organization_id = $3,
+		is_archived = $4,
+		contract_id = $5,
+		distributor_id = $6,
+		updated_at = NOW()
+	WHERE id=$7
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Status,
+		&obj.Notes,
+		&obj.BuyerOrganizationID,
+		&obj.IsArchived,
+		&obj.ContractID,
+		&obj.DistributorID,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update order")
+	}
+
+	return nil
+}
+
+// Delete deletes an order from database
+func (s *OrderStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM orders WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete order")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *OrderStore) scanList(rows pgx.Rows) ([]models.Order, error) {
+	orders := []models.Order{}
+	obj := models.Order{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Status,
+			&obj.Notes,
+			&obj.BuyerOrganizationID,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+			&obj.ContractID,
+			&obj.DistributorID,
+		); err != nil {
+			return nil, err
+		}
+		orders = append(orders, obj)
+	}
+
+	return orders, nil
+}
+
+func (s *OrderStore) scanRow(row pgx.Row) (*models.Order, error) {
+	obj := models.Order{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Status,
+		&obj.Notes,
+		&obj.BuyerOrganizationID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.ContractID,
+		&obj.DistributorID,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,165 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OrderAllocationStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ OrderAllocationStoreInterface = &OrderAllocationStore{}
+
+type OrderAllocationStoreInterface interface {
+	ListByOrderID(ctx context.Context, orderID int64) ([]models.OrderAllocation, *faulterr.FaultErr)
+	GetByPalletID(ctx context.Context, palletID int64) (*models.OrderAllocation, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.OrderAllocation) (*models.OrderAllocation, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewOrderAllocationStore(conn *pgxpool.Pool) *OrderAllocationStore {
+	return &OrderAllocationStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOrderID retrives all pallet allocations of an order from database
+func (s *OrderAllocationStore) ListByOrderID(ctx context.Context, orderID int64) ([]models.OrderAllocation, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM order_allocations
+	WHERE order_allocations.order_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get order allocations"
+	rows, err := s.conn.Query(ctx, queryStmt, orderID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	allocations, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return allocations, nil
+}
+
+// GetByPalletID gets the allocation of a pallet from database
+func (s *OrderAllocationStore) GetByPalletID(ctx context.Context, palletID int64) (*models.OrderAllocation, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM order_allocations
+	WHERE order_allocations.pallet_id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, palletID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get order allocation")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts an order allocation in database
+func (s *OrderAllocationStore) Insert(ctx context.Context, tx pgx.Tx, obj models.OrderAllocation) (*models.OrderAllocation, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	order_allocations(
+		order_id,
+		pallet_id,
+		allocated_by_id
+	)
+	VALUES ($1, $2, $3)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrderID,
+		&obj.PalletID,
+		&obj.AllocatedByID,
+	)
+
+	allocation, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert order allocation")
+	}
+
+	return allocation, nil
+}
+
+// Delete deletes an order allocation from database
+func (s *OrderAllocationStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM order_allocations WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete order allocation")
+	}
+
+	return nil
+}
+
+// DeleteByOrderID deletes the allocations of an order from database
+func (s *OrderAllocationStore) DeleteByOrderID(ctx context.Context, tx pgx.Tx, orderID int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM order_allocations WHERE order_id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, orderID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete order allocations")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *OrderAllocationStore) scanList(rows pgx.Rows) ([]models.OrderAllocation, error) {
+	allocations := []models.OrderAllocation{}
+	obj := models.OrderAllocation{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.OrderID,
+			&obj.PalletID,
+			&obj.AllocatedByID,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, obj)
+	}
+
+	return allocations, nil
+}
+
+func (s *OrderAllocationStore) scanRow(row pgx.Row) (*models.OrderAllocation, error) {
+	obj := models.OrderAllocation{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.OrderID,
+		&obj.PalletID,
+		&obj.AllocatedByID,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,177 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OrderItemStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ OrderItemStoreInterface = &OrderItemStore{}
+
+type OrderItemStoreInterface interface {
+	ListByOrderID(ctx context.Context, orderID int64) ([]models.OrderItem, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.OrderItem, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.OrderItem) (*models.OrderItem, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.OrderItem) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewOrderItemStore(conn *pgxpool.Pool) *OrderItemStore {
+	return &OrderItemStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOrderID retrives all line items of an order from database
+func (s *OrderItemStore) ListByOrderID(ctx context.Context, orderID int64) ([]models.OrderItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM order_items
+	WHERE order_items.order_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get order items"
+	rows, err := s.conn.Query(ctx, queryStmt, orderID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	items, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return items, nil
+}
+
+// GetByID gets order item by ID from database
+func (s *OrderItemStore) GetByID(ctx context.Context, id int64) (*models.OrderItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM order_items
+	WHERE order_items.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get order item")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts an order item in database
+func (s *OrderItemStore) Insert(ctx context.Context, tx pgx.Tx, obj models.OrderItem) (*models.OrderItem, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	order_items(
+		order_id,
+		sku_id,
+		quantity
+	)
+	VALUES ($1, $2, $3)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrderID,
+		&obj.SkuID,
+		&obj.Quantity,
+	)
+
+	item, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert order item")
+	}
+
+	return item, nil
+}
+
+// Update updates an order item in database
+func (s *OrderItemStore) Update(ctx context.Context, tx pgx.Tx, obj models.OrderItem) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE order_items
+	SET
+		quantity = $1,
+		updated_at = NOW()
+	WHERE id=$2
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Quantity,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update order item")
+	}
+
+	return nil
+}
+
+// Delete deletes an order item from database
+func (s *OrderItemStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM order_items WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete order item")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *OrderItemStore) scanList(rows pgx.Rows) ([]models.OrderItem, error) {
+	items := []models.OrderItem{}
+	obj := models.OrderItem{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.OrderID,
+			&obj.SkuID,
+			&obj.Quantity,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, obj)
+	}
+
+	return items, nil
+}
+
+func (s *OrderItemStore) scanRow(row pgx.Row) (*models.OrderItem, error) {
+	obj := models.OrderItem{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.OrderID,
+		&obj.SkuID,
+		&obj.Quantity,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
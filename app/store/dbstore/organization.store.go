@@ -18,7 +18,6 @@ type OrganizationStore struct {
 var _ OrganizationStoreInterface = &OrganizationStore{}
 
 type OrganizationStoreInterface interface {
-	GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr)
 	GetMany(ctx context.Context, ids []int64) ([]*models.Organization, error)
 	List(ctx context.Context) ([]models.Organization, *faulterr.FaultErr)
 	GetByID(ctx context.Context, id int64) (*models.Organization, *faulterr.FaultErr)
@@ -36,32 +35,6 @@ func NewOrganizationStore(conn *pgxpool.Pool) *OrganizationStore {
 //////////////////////////////////////////****Read****/////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetLastInsertedRow retrives last row from database
-func (s *OrganizationStore) GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr) {
-	queryStmt := `
-	SELECT id FROM organizations
-	ORDER BY id DESC
-	LIMIT 1
-	`
-
-	var ID int64
-	errMsg := "error getting last inserted row"
-
-	rows, err := s.conn.Query(context.Background(), queryStmt)
-	if err != nil {
-		return 0, faulterr.NewPostgresError(err, errMsg)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		if err := rows.Scan(&ID); err != nil {
-			return 0, faulterr.NewPostgresError(err, errMsg)
-		}
-	}
-
-	return ID, nil
-}
-
 // GetMany get all organizations by ids
 func (s *OrganizationStore) GetMany(ctx context.Context, ids []int64) ([]*models.Organization, error) {
 	placeholders := make([]string, len(ids))
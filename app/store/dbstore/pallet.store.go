@@ -2,6 +2,7 @@ package dbstore
 
 import (
 	"context"
+	"fmt"
 	"orijinplus/app/models"
 	"orijinplus/utils/faulterr"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/volatiletech/null"
 )
 
 type PalletStore struct {
@@ -19,12 +21,26 @@ type PalletStore struct {
 var _ PalletStoreInterface = &PalletStore{}
 
 type PalletStoreInterface interface {
-	GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr)
 	List(ctx context.Context) ([]models.Pallet, *faulterr.FaultErr)
+	ListByDistributorID(ctx context.Context, distributorID int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListByAttributes(ctx context.Context, orgID null.Int64, containerID null.Int64, attrs map[string]interface{}) ([]models.Pallet, *faulterr.FaultErr)
+	ListByContainerID(ctx context.Context, containerID int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListByContainerIDs(ctx context.Context, containerIDs []int64) ([]models.Pallet, error)
+	ListByLocationIDs(ctx context.Context, locationIDs []int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListByUIDs(ctx context.Context, uids []string) ([]models.Pallet, *faulterr.FaultErr)
+	ListByOrderIDs(ctx context.Context, orderIDs []int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListByLotID(ctx context.Context, lotID int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListBySkuID(ctx context.Context, skuID int64) ([]models.Pallet, *faulterr.FaultErr)
+	ListFEFO(ctx context.Context, skuID int64, orgID int64, limit int) ([]models.Pallet, *faulterr.FaultErr)
 	GetByID(ctx context.Context, id int64) (*models.Pallet, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Pallet, *faulterr.FaultErr)
 	GetByCode(ctx context.Context, code string) (*models.Pallet, *faulterr.FaultErr)
 	Insert(ctx context.Context, tx pgx.Tx, o models.Pallet) (*models.Pallet, *faulterr.FaultErr)
 	Update(ctx context.Context, tx pgx.Tx, o models.Pallet) *faulterr.FaultErr
+	SetLocation(ctx context.Context, tx pgx.Tx, id int64, locationID null.Int64) *faulterr.FaultErr
+	SetSSCC(ctx context.Context, tx pgx.Tx, id int64, sscc string) *faulterr.FaultErr
+	SetLot(ctx context.Context, tx pgx.Tx, id int64, lotID null.Int64) *faulterr.FaultErr
+	RemoveAttribute(ctx context.Context, tx pgx.Tx, orgID int64, key string) *faulterr.FaultErr
 	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
 }
 
@@ -36,32 +52,6 @@ func NewPalletStore(conn *pgxpool.Pool) *PalletStore {
 //////////////////////////////////////////****Read****/////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetLastInsertedRow retrives last row from database
-func (s *PalletStore) GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr) {
-	queryStmt := `
-	SELECT id FROM pallets
-	ORDER BY id DESC
-	LIMIT 1
-	`
-
-	var ID int64
-	errMsg := "error getting last inserted row"
-
-	rows, err := s.conn.Query(context.Background(), queryStmt)
-	if err != nil {
-		return 0, faulterr.NewPostgresError(err, errMsg)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		if err := rows.Scan(&ID); err != nil {
-			return 0, faulterr.NewPostgresError(err, errMsg)
-		}
-	}
-
-	return ID, nil
-}
-
 // GetMany get all pallets by ids
 func (s *PalletStore) GetMany(ctx context.Context, ids []int64) ([]*models.Pallet, error) {
 	placeholders := make([]string, len(ids))
@@ -76,13 +66,13 @@ func (s *PalletStore) GetMany(ctx context.Context, ids []int64) ([]*models.Palle
 	// errMsg := "error when trying to get pallets"
 
 	pallets := []models.Pallet{}
-	obj := models.Pallet{}
 	rows, err := s.conn.Query(ctx, queryStmt, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
+		obj := models.Pallet{}
 		if err := rows.Scan(
 			&obj.ID,
 			&obj.UID,
@@ -94,6 +84,10 @@ func (s *PalletStore) GetMany(ctx context.Context, ids []int64) ([]*models.Palle
 			&obj.CreatedByID,
 			&obj.CreatedAt,
 			&obj.UpdatedAt,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
+			&obj.LotID,
 		); err != nil {
 			return nil, err
 		}
@@ -113,7 +107,6 @@ func (s *PalletStore) List(ctx context.Context) ([]models.Pallet, *faulterr.Faul
 	queryStmt := `SELECT * FROM pallets`
 
 	pallets := []models.Pallet{}
-	obj := models.Pallet{}
 	errMsg := "error when trying to get pallets"
 
 	rows, err := s.conn.Query(context.Background(), queryStmt)
@@ -123,6 +116,7 @@ func (s *PalletStore) List(ctx context.Context) ([]models.Pallet, *faulterr.Faul
 	defer rows.Close()
 
 	for rows.Next() {
+		obj := models.Pallet{}
 		if err := rows.Scan(
 			&obj.ID,
 			&obj.UID,
@@ -134,6 +128,10 @@ func (s *PalletStore) List(ctx context.Context) ([]models.Pallet, *faulterr.Faul
 			&obj.CreatedByID,
 			&obj.CreatedAt,
 			&obj.UpdatedAt,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
+			&obj.LotID,
 		); err != nil {
 			return nil, faulterr.NewPostgresError(err, errMsg)
 		}
@@ -151,7 +149,6 @@ func (s *PalletStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Pa
 	`
 
 	pallets := []models.Pallet{}
-	obj := models.Pallet{}
 	errMsg := "error when trying to get pallets"
 
 	rows, err := s.conn.Query(ctx, queryStmt, orgID)
@@ -161,6 +158,7 @@ func (s *PalletStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Pa
 	defer rows.Close()
 
 	for rows.Next() {
+		obj := models.Pallet{}
 		if err := rows.Scan(
 			&obj.ID,
 			&obj.UID,
@@ -172,6 +170,10 @@ func (s *PalletStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Pa
 			&obj.CreatedByID,
 			&obj.CreatedAt,
 			&obj.UpdatedAt,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
+			&obj.LotID,
 		); err != nil {
 			return nil, faulterr.NewPostgresError(err, errMsg)
 		}
@@ -181,6 +183,124 @@ func (s *PalletStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Pa
 	return pallets, nil
 }
 
+// ListByAttributes retrives the pallets having all the attribute values,
+// of an organization unless orgID is null and inside a container unless containerID is null
+func (s *PalletStore) ListByAttributes(
+	ctx context.Context,
+	orgID null.Int64,
+	containerID null.Int64,
+	attrs map[string]interface{},
+) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.attributes @> $1
+	AND ($2::bigint IS NULL OR pallets.organization_id = $2)
+	AND ($3::bigint IS NULL OR pallets.container_id = $3)
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, attrs, orgID, containerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListByDistributorID retrives all pallets allocated to orders routed through a distributor
+func (s *PalletStore) ListByDistributorID(ctx context.Context, distributorID int64) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT pallets.* FROM pallets
+	INNER JOIN order_allocations ON order_allocations.pallet_id = pallets.id
+	INNER JOIN orders ON orders.id = order_allocations.order_id
+	WHERE orders.distributor_id = $1
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, distributorID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListByContainerID retrives all pallets inside a container
+func (s *PalletStore) ListByContainerID(ctx context.Context, containerID int64) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.container_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, containerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListByContainerIDs retrives all pallets inside any of the containers
+func (s *PalletStore) ListByContainerIDs(ctx context.Context, containerIDs []int64) ([]models.Pallet, error) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.container_id = ANY($1)
+	ORDER BY id
+	`
+
+	rows, err := s.conn.Query(ctx, queryStmt, containerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanList(rows)
+}
+
+// ListByLocationIDs retrives all pallets stored at any of the locations
+func (s *PalletStore) ListByLocationIDs(ctx context.Context, locationIDs []int64) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.location_id = ANY($1)
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, locationIDs)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
 // GetByID gets pallet by ID from database
 func (s *PalletStore) GetByID(ctx context.Context, id int64) (*models.Pallet, *faulterr.FaultErr) {
 	queryStmt := `
@@ -202,6 +322,10 @@ func (s *PalletStore) GetByID(ctx context.Context, id int64) (*models.Pallet, *f
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+		&obj.LotID,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to get pallet")
 	}
@@ -209,7 +333,41 @@ func (s *PalletStore) GetByID(ctx context.Context, id int64) (*models.Pallet, *f
 	return &obj, nil
 }
 
-// GetByID gets pallet by UID from database
+// LockByID gets a pallet and locks it until the transaction ends,
+// which serializes moves of the pallet
+func (s *PalletStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.id = $1
+	FOR UPDATE
+	`
+
+	obj := models.Pallet{}
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Description,
+		&obj.ContainerID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+		&obj.LotID,
+	); err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock pallet")
+	}
+
+	return &obj, nil
+}
+
+// GetByUID gets pallet by UID from database
 func (s *PalletStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Pallet, *faulterr.FaultErr) {
 	queryStmt := `
 	SELECT * FROM pallets
@@ -230,6 +388,42 @@ func (s *PalletStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Pall
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+		&obj.LotID,
+	); err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get pallet")
+	}
+
+	return &obj, nil
+}
+
+// GetBySSCC gets pallet by SSCC from database
+func (s *PalletStore) GetBySSCC(ctx context.Context, sscc string) (*models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.sscc = $1
+	`
+
+	obj := models.Pallet{}
+
+	row := s.conn.QueryRow(ctx, queryStmt, sscc)
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Description,
+		&obj.ContainerID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+		&obj.LotID,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to get pallet")
 	}
@@ -258,6 +452,10 @@ func (s *PalletStore) GetByCode(ctx context.Context, code string) (*models.Palle
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+		&obj.LotID,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to get pallet")
 	}
@@ -265,6 +463,139 @@ func (s *PalletStore) GetByCode(ctx context.Context, code string) (*models.Palle
 	return &obj, nil
 }
 
+// ListByUIDs retrives the pallets with the given UIDs from database
+func (s *PalletStore) ListByUIDs(ctx context.Context, uids []string) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.uid = ANY($1::uuid[])
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, uids)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListByOrderIDs retrives the pallets allocated to the given orders from database
+func (s *PalletStore) ListByOrderIDs(ctx context.Context, orderIDs []int64) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT pallets.* FROM pallets
+	INNER JOIN order_allocations ON order_allocations.pallet_id = pallets.id
+	WHERE order_allocations.order_id = ANY($1)
+	ORDER BY pallets.code
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, orderIDs)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListByLotID retrives the pallets of a lot from database
+func (s *PalletStore) ListByLotID(ctx context.Context, lotID int64) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallets
+	WHERE pallets.lot_id = $1
+	ORDER BY code
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, lotID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListBySkuID retrives the pallets of every lot of a SKU from database
+func (s *PalletStore) ListBySkuID(ctx context.Context, skuID int64) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT pallets.* FROM pallets
+	JOIN lots ON lots.id = pallets.lot_id
+	WHERE lots.sku_id = $1
+	ORDER BY pallets.code
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, skuID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
+// ListFEFO retrives the pallets of the unexpired lots of a SKU which are not archived, allocated to an order
+// or under an open recall from database, first expired first out: lots expiring soonest first and lots without
+// an expiry date last. Lots are checked against their expiry date, they may not be flagged expired yet
+func (s *PalletStore) ListFEFO(ctx context.Context, skuID int64, orgID int64, limit int) ([]models.Pallet, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT pallets.* FROM pallets
+	INNER JOIN lots ON lots.id = pallets.lot_id
+	WHERE lots.sku_id = $1
+	AND pallets.organization_id = $2
+	AND (lots.expiry_date IS NULL OR lots.expiry_date > NOW())
+	AND NOT pallets.is_archived
+	AND NOT EXISTS (SELECT 1 FROM order_allocations WHERE order_allocations.pallet_id = pallets.id)
+	AND NOT EXISTS (
+		SELECT 1 FROM recall_items
+		INNER JOIN recalls ON recalls.id = recall_items.recall_id
+		WHERE recall_items.item_type = $4
+		AND recall_items.item_id = pallets.id
+		AND recall_items.resolved_at IS NULL
+		AND recalls.status = $5
+	)
+	ORDER BY lots.expiry_date NULLS LAST, lots.production_date, pallets.code
+	LIMIT $3
+	`
+
+	errMsg := "error when trying to get pallets"
+	rows, err := s.conn.Query(ctx, queryStmt, skuID, orgID, limit, models.CodePallet, models.RecallOpen)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////
 //////////////////////////////////////////****Mutate****///////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -280,9 +611,11 @@ func (s *PalletStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Pallet)
 		container_id,
 		is_archived,
 		organization_id,
-		created_by_id
+		created_by_id,
+		sscc,
+		attributes
 	)
-	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	RETURNING *
 	`
 
@@ -294,6 +627,8 @@ func (s *PalletStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Pallet)
 		&obj.IsArchived,
 		&obj.OrganizationID,
 		&obj.CreatedByID,
+		&obj.SSCC,
+		&obj.Attributes,
 	)
 
 	if err := row.Scan(
@@ -307,6 +642,10 @@ func (s *PalletStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Pallet)
 		&obj.CreatedByID,
 		&obj.CreatedAt,
 		&obj.UpdatedAt,
+		&obj.LocationID,
+		&obj.SSCC,
+		&obj.Attributes,
+		&obj.LotID,
 	); err != nil {
 		return nil, faulterr.NewPostgresError(err, "error when trying to insert pallet")
 	}
@@ -314,6 +653,61 @@ func (s *PalletStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Pallet)
 	return &obj, nil
 }
 
+// InsertMany inserts pallets in a single statement
+func (s *PalletStore) InsertMany(ctx context.Context, tx pgx.Tx, objs []models.Pallet) ([]models.Pallet, *faulterr.FaultErr) {
+	values := make([]string, len(objs))
+	args := make([]interface{}, 0, len(objs)*9)
+	for i, obj := range objs {
+		n := i * 9
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9)
+		args = append(args,
+			obj.UID,
+			obj.Code,
+			obj.Description,
+			obj.ContainerID,
+			obj.IsArchived,
+			obj.OrganizationID,
+			obj.CreatedByID,
+			obj.SSCC,
+			obj.Attributes,
+		)
+	}
+
+	queryStmt := `
+	INSERT INTO
+	pallets(
+		uid,
+		code,
+		description,
+		container_id,
+		is_archived,
+		organization_id,
+		created_by_id,
+		sscc,
+		attributes
+	)
+	VALUES ` + strings.Join(values, ", ") + `
+	RETURNING *
+	`
+
+	errMsg := "error when trying to insert pallets"
+	rows, err := tx.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	pallets, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return pallets, nil
+}
+
 // Update updates a pallet in database
 func (s *PalletStore) Update(ctx context.Context, tx pgx.Tx, obj models.Pallet) *faulterr.FaultErr {
 	queryStmt := `
@@ -321,14 +715,16 @@ func (s *PalletStore) Update(ctx context.Context, tx pgx.Tx, obj models.Pallet)
 	SET
 		description = $1,
 		container_id = $2,
-		is_archived = $3
-	WHERE id=$4
+		is_archived = $3,
+		attributes = $4
+	WHERE id=$5
 	`
 
 	_, err := tx.Exec(ctx, queryStmt,
 		&obj.Description,
 		&obj.ContainerID,
 		&obj.IsArchived,
+		&obj.Attributes,
 		&obj.ID,
 	)
 	if err != nil {
@@ -338,6 +734,54 @@ func (s *PalletStore) Update(ctx context.Context, tx pgx.Tx, obj models.Pallet)
 	return nil
 }
 
+// SetSSCC gives a pallet its serial shipping container code
+func (s *PalletStore) SetSSCC(ctx context.Context, tx pgx.Tx, id int64, sscc string) *faulterr.FaultErr {
+	queryStmt := `UPDATE pallets SET sscc=$1 WHERE id=$2`
+
+	_, err := tx.Exec(ctx, queryStmt, sscc, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update pallet sscc")
+	}
+
+	return nil
+}
+
+// SetLot assigns a pallet to a production lot, or takes it out of its lot
+func (s *PalletStore) SetLot(ctx context.Context, tx pgx.Tx, id int64, lotID null.Int64) *faulterr.FaultErr {
+	queryStmt := `UPDATE pallets SET lot_id=$1 WHERE id=$2`
+
+	_, err := tx.Exec(ctx, queryStmt, lotID, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to set pallet lot")
+	}
+
+	return nil
+}
+
+// RemoveAttribute removes a custom attribute from the pallets of an organization
+func (s *PalletStore) RemoveAttribute(ctx context.Context, tx pgx.Tx, orgID int64, key string) *faulterr.FaultErr {
+	queryStmt := `UPDATE pallets SET attributes = attributes - $1 WHERE organization_id=$2 AND attributes ? $1`
+
+	_, err := tx.Exec(ctx, queryStmt, key, orgID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to remove pallet attribute")
+	}
+
+	return nil
+}
+
+// SetLocation puts a pallet at a location, a null location takes it off its location
+func (s *PalletStore) SetLocation(ctx context.Context, tx pgx.Tx, id int64, locationID null.Int64) *faulterr.FaultErr {
+	queryStmt := `UPDATE pallets SET location_id=$1 WHERE id=$2`
+
+	_, err := tx.Exec(ctx, queryStmt, locationID, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update pallet location")
+	}
+
+	return nil
+}
+
 // Delete deletes a pallet from database
 func (s *PalletStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
 	queryStmt := `DELETE FROM pallets WHERE id=$1`
@@ -353,3 +797,33 @@ func (s *PalletStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr
 ///////////////////////////////////////////////////////////////////////////////////////////////
 //////////////////////////////////////////****Helpers****//////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *PalletStore) scanList(rows pgx.Rows) ([]models.Pallet, error) {
+	pallets := []models.Pallet{}
+
+	for rows.Next() {
+		// A fresh object per row so that attribute maps are not shared between pallets
+		obj := models.Pallet{}
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Description,
+			&obj.ContainerID,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+			&obj.LocationID,
+			&obj.SSCC,
+			&obj.Attributes,
+			&obj.LotID,
+		); err != nil {
+			return nil, err
+		}
+		pallets = append(pallets, obj)
+	}
+
+	return pallets, nil
+}
@@ -0,0 +1,260 @@
+package dbstore
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PalletAssignmentStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ PalletAssignmentStoreInterface = &PalletAssignmentStore{}
+
+type PalletAssignmentStoreInterface interface {
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.PalletAssignment, *faulterr.FaultErr)
+	ListByPalletID(ctx context.Context, palletID int64) ([]models.PalletAssignment, *faulterr.FaultErr)
+	ListByContainerID(ctx context.Context, containerID int64) ([]models.PalletAssignment, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.PalletAssignment, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.PalletAssignment) (*models.PalletAssignment, *faulterr.FaultErr)
+	InsertMany(ctx context.Context, tx pgx.Tx, objs []models.PalletAssignment) *faulterr.FaultErr
+	CloseByPalletID(ctx context.Context, tx pgx.Tx, palletID int64, unloadedByID int64) *faulterr.FaultErr
+	DeleteByPalletID(ctx context.Context, tx pgx.Tx, palletID int64) *faulterr.FaultErr
+}
+
+func NewPalletAssignmentStore(conn *pgxpool.Pool) *PalletAssignmentStore {
+	return &PalletAssignmentStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOrgID retrives all pallet assignments of the pallets of an organization from database
+func (s *PalletAssignmentStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.PalletAssignment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT pallet_assignments.* FROM pallet_assignments
+	JOIN pallets ON pallets.id = pallet_assignments.pallet_id
+	WHERE pallets.organization_id = $1
+	ORDER BY pallet_assignments.loaded_at DESC, pallet_assignments.id DESC
+	`
+
+	errMsg := "error when trying to get pallet assignments"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	assignments, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return assignments, nil
+}
+
+// ListByPalletID retrives all pallet assignments of a pallet from database
+func (s *PalletAssignmentStore) ListByPalletID(ctx context.Context, palletID int64) ([]models.PalletAssignment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallet_assignments
+	WHERE pallet_assignments.pallet_id = $1
+	ORDER BY loaded_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get pallet assignments"
+	rows, err := s.conn.Query(ctx, queryStmt, palletID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	assignments, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return assignments, nil
+}
+
+// ListByContainerID retrives all pallet assignments of a container from database
+func (s *PalletAssignmentStore) ListByContainerID(ctx context.Context, containerID int64) ([]models.PalletAssignment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallet_assignments
+	WHERE pallet_assignments.container_id = $1
+	ORDER BY loaded_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get pallet assignments"
+	rows, err := s.conn.Query(ctx, queryStmt, containerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	assignments, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return assignments, nil
+}
+
+// GetByID gets pallet assignment by ID from database
+func (s *PalletAssignmentStore) GetByID(ctx context.Context, id int64) (*models.PalletAssignment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM pallet_assignments
+	WHERE pallet_assignments.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get pallet assignment")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a pallet assignment in database
+func (s *PalletAssignmentStore) Insert(ctx context.Context, tx pgx.Tx, obj models.PalletAssignment) (*models.PalletAssignment, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	pallet_assignments(
+		pallet_id,
+		container_id,
+		loaded_by_id
+	)
+	VALUES ($1, $2, $3)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.PalletID,
+		&obj.ContainerID,
+		&obj.LoadedByID,
+	)
+
+	assignment, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert pallet assignment")
+	}
+
+	return assignment, nil
+}
+
+// InsertMany inserts pallet assignments in database
+func (s *PalletAssignmentStore) InsertMany(ctx context.Context, tx pgx.Tx, objs []models.PalletAssignment) *faulterr.FaultErr {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(objs))
+	args := make([]interface{}, 0, len(objs)*3)
+	for i, obj := range objs {
+		n := i * 3
+		values[i] = fmt.Sprintf("($%d, $%d, $%d)", n+1, n+2, n+3)
+		args = append(args, obj.PalletID, obj.ContainerID, obj.LoadedByID)
+	}
+
+	queryStmt := `
+	INSERT INTO
+	pallet_assignments(
+		pallet_id,
+		container_id,
+		loaded_by_id
+	)
+	VALUES ` + strings.Join(values, ", ")
+
+	_, err := tx.Exec(ctx, queryStmt, args...)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to insert pallet assignments")
+	}
+
+	return nil
+}
+
+// CloseByPalletID ends the open assignment of a pallet, if any
+func (s *PalletAssignmentStore) CloseByPalletID(ctx context.Context, tx pgx.Tx, palletID int64, unloadedByID int64) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE pallet_assignments
+	SET
+		unloaded_by_id=$1,
+		unloaded_at=NOW()
+	WHERE pallet_assignments.pallet_id=$2
+	AND pallet_assignments.unloaded_at IS NULL
+	`
+
+	_, err := tx.Exec(ctx, queryStmt, unloadedByID, palletID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to close pallet assignment")
+	}
+
+	return nil
+}
+
+// DeleteByPalletID deletes the custody history of a pallet from database
+func (s *PalletAssignmentStore) DeleteByPalletID(ctx context.Context, tx pgx.Tx, palletID int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM pallet_assignments WHERE pallet_id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, palletID)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete pallet assignments")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *PalletAssignmentStore) scanList(rows pgx.Rows) ([]models.PalletAssignment, error) {
+	assignments := []models.PalletAssignment{}
+	obj := models.PalletAssignment{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.PalletID,
+			&obj.ContainerID,
+			&obj.LoadedByID,
+			&obj.LoadedAt,
+			&obj.UnloadedByID,
+			&obj.UnloadedAt,
+		); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, obj)
+	}
+
+	return assignments, nil
+}
+
+func (s *PalletAssignmentStore) scanRow(row pgx.Row) (*models.PalletAssignment, error) {
+	obj := models.PalletAssignment{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.PalletID,
+		&obj.ContainerID,
+		&obj.LoadedByID,
+		&obj.LoadedAt,
+		&obj.UnloadedByID,
+		&obj.UnloadedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
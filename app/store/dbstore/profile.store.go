@@ -22,8 +22,11 @@ type ProfileStoreInterface interface {
 	List(ctx context.Context) ([]models.Profile, *faulterr.FaultErr)
 	GetByUserID(ctx context.Context, userID int64) (*models.Profile, *faulterr.FaultErr)
 	GetByReferralCode(ctx context.Context, refCode string) (*models.Profile, *faulterr.FaultErr)
+	LockByUserID(ctx context.Context, tx pgx.Tx, userID int64) (*models.Profile, *faulterr.FaultErr)
 	Insert(ctx context.Context, tx pgx.Tx, p models.Profile) (*models.Profile, *faulterr.FaultErr)
 	Update(ctx context.Context, tx pgx.Tx, p models.Profile) *faulterr.FaultErr
+	AddWalletPoints(ctx context.Context, tx pgx.Tx, userID int64, points int64) (*models.Profile, *faulterr.FaultErr)
+	SpendWalletPoints(ctx context.Context, tx pgx.Tx, userID int64, points int64) (*models.Profile, *faulterr.FaultErr)
 	Delete(ctx context.Context, tx pgx.Tx, userID int64) *faulterr.FaultErr
 }
 
@@ -144,6 +147,20 @@ func (s *ProfileStore) GetByReferralCode(ctx context.Context, refCode string) (*
 	return &p, nil
 }
 
+// LockByUserID gets a profile and locks it until the transaction ends,
+// which serializes changes to the wallet of the user
+func (s *ProfileStore) LockByUserID(ctx context.Context, tx pgx.Tx, userID int64) (*models.Profile, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM profiles WHERE user_id=$1 FOR UPDATE`
+
+	row := tx.QueryRow(ctx, queryStmt, userID)
+	p, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock profile")
+	}
+
+	return p, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////
 //////////////////////////////////////////****Mutate****///////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -171,20 +188,19 @@ func (s *ProfileStore) Insert(ctx context.Context, tx pgx.Tx, p models.Profile)
 	return &p, nil
 }
 
-// Update Profile
+// Update Profile, wallet points are only changed through the wallet point ledger
 func (s *ProfileStore) Update(ctx context.Context, tx pgx.Tx, p models.Profile) *faulterr.FaultErr {
 	queryStmt := `
 	UPDATE profiles
-	SET 
-		date_of_birth=$1
-		wallet_points=$2
-	WHERE profiles.user_id=$3
+	SET
+		date_of_birth=$1,
+		updated_at=NOW()
+	WHERE profiles.user_id=$2
 	`
 
 	errMsg := "error when trying to update profile"
 	_, err := tx.Exec(ctx, queryStmt,
 		&p.DateOfBirth,
-		&p.WalletPoints,
 		&p.UserID,
 	)
 	if err != nil {
@@ -194,6 +210,47 @@ func (s *ProfileStore) Update(ctx context.Context, tx pgx.Tx, p models.Profile)
 	return nil
 }
 
+// AddWalletPoints credits points to the wallet of a profile in a single statement
+func (s *ProfileStore) AddWalletPoints(ctx context.Context, tx pgx.Tx, userID int64, points int64) (*models.Profile, *faulterr.FaultErr) {
+	queryStmt := `
+	UPDATE profiles
+	SET
+		wallet_points=wallet_points + $1,
+		updated_at=NOW()
+	WHERE profiles.user_id=$2
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, points, userID)
+	p, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to add wallet points")
+	}
+
+	return p, nil
+}
+
+// SpendWalletPoints debits points from the wallet of a profile only if the balance covers them
+func (s *ProfileStore) SpendWalletPoints(ctx context.Context, tx pgx.Tx, userID int64, points int64) (*models.Profile, *faulterr.FaultErr) {
+	queryStmt := `
+	UPDATE profiles
+	SET
+		wallet_points=wallet_points - $1,
+		updated_at=NOW()
+	WHERE profiles.user_id=$2
+	AND profiles.wallet_points >= $1
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, points, userID)
+	p, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to spend wallet points")
+	}
+
+	return p, nil
+}
+
 // Delete Profile
 func (s *ProfileStore) Delete(ctx context.Context, tx pgx.Tx, userID int64) *faulterr.FaultErr {
 	queryStmt := `DELETE FROM profiles WHERE user_id=$1`
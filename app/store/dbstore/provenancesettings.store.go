@@ -0,0 +1,116 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ProvenanceSettingsStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ProvenanceSettingsStoreInterface = &ProvenanceSettingsStore{}
+
+type ProvenanceSettingsStoreInterface interface {
+	GetByOrgID(ctx context.Context, orgID int64) (*models.ProvenanceSettings, *faulterr.FaultErr)
+	Upsert(ctx context.Context, tx pgx.Tx, obj models.ProvenanceSettings) (*models.ProvenanceSettings, *faulterr.FaultErr)
+}
+
+func NewProvenanceSettingsStore(conn *pgxpool.Pool) *ProvenanceSettingsStore {
+	return &ProvenanceSettingsStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetByOrgID gets the provenance settings of an organization from database
+func (s *ProvenanceSettingsStore) GetByOrgID(ctx context.Context, orgID int64) (*models.ProvenanceSettings, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM organization_provenance_settings
+	WHERE organization_provenance_settings.organization_id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, orgID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get provenance settings")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Upsert inserts or replaces the provenance settings of an organization in database
+func (s *ProvenanceSettingsStore) Upsert(ctx context.Context, tx pgx.Tx, obj models.ProvenanceSettings) (*models.ProvenanceSettings, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	organization_provenance_settings(
+		organization_id,
+		is_public,
+		show_organization,
+		show_description,
+		show_locations,
+		show_event_details,
+		show_anchoring
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (organization_id)
+	DO UPDATE SET
+		is_public=EXCLUDED.is_public,
+		show_organization=EXCLUDED.show_organization,
+		show_description=EXCLUDED.show_description,
+		show_locations=EXCLUDED.show_locations,
+		show_event_details=EXCLUDED.show_event_details,
+		show_anchoring=EXCLUDED.show_anchoring,
+		updated_at=NOW()
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.OrganizationID,
+		&obj.IsPublic,
+		&obj.ShowOrganization,
+		&obj.ShowDescription,
+		&obj.ShowLocations,
+		&obj.ShowEventDetails,
+		&obj.ShowAnchoring,
+	)
+
+	settings, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to save provenance settings")
+	}
+
+	return settings, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ProvenanceSettingsStore) scanRow(row pgx.Row) (*models.ProvenanceSettings, error) {
+	obj := models.ProvenanceSettings{}
+
+	if err := row.Scan(
+		&obj.OrganizationID,
+		&obj.IsPublic,
+		&obj.ShowOrganization,
+		&obj.ShowDescription,
+		&obj.ShowLocations,
+		&obj.ShowEventDetails,
+		&obj.ShowAnchoring,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,443 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PurchaseRecordStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ PurchaseRecordStoreInterface = &PurchaseRecordStore{}
+
+type PurchaseRecordStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.PurchaseRecord, error)
+	List(ctx context.Context) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	ListByRedeemedByID(ctx context.Context, userID int64) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.PurchaseRecord, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.PurchaseRecord, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.PurchaseRecord, *faulterr.FaultErr)
+	GetByToken(ctx context.Context, token string) (*models.PurchaseRecord, *faulterr.FaultErr)
+	ListUnredeemedByEmail(ctx context.Context, email string) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	ListUnredeemedByPhone(ctx context.Context, phone string) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	ListByProductUIDs(ctx context.Context, productUIDs []string) ([]models.PurchaseRecord, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.PurchaseRecord) (*models.PurchaseRecord, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.PurchaseRecord) *faulterr.FaultErr
+	Redeem(ctx context.Context, tx pgx.Tx, id int64, userID int64) (*models.PurchaseRecord, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewPurchaseRecordStore(conn *pgxpool.Pool) *PurchaseRecordStore {
+	return &PurchaseRecordStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all purchase records by ids
+func (s *PurchaseRecordStore) GetMany(ctx context.Context, ids []int64) ([]*models.PurchaseRecord, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from purchase_records WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.PurchaseRecord{}
+	for i := 0; i < len(records); i++ {
+		result = append(result, &records[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all purchase records from database
+func (s *PurchaseRecordStore) List(ctx context.Context) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM purchase_records`
+
+	errMsg := "error when trying to get purchase records"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return records, nil
+}
+
+// ListByOrgID retrives all purchase records of an organization from database
+func (s *PurchaseRecordStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.organization_id = $1
+	`
+
+	errMsg := "error when trying to get purchase records"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return records, nil
+}
+
+// ListByRedeemedByID retrives all purchase records redeemed by a user from database
+func (s *PurchaseRecordStore) ListByRedeemedByID(ctx context.Context, userID int64) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.redeemed_by_id = $1
+	ORDER BY redeemed_at DESC
+	`
+
+	errMsg := "error when trying to get purchase records"
+	rows, err := s.conn.Query(ctx, queryStmt, userID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return records, nil
+}
+
+// GetByID gets purchase record by ID from database
+func (s *PurchaseRecordStore) GetByID(ctx context.Context, id int64) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get purchase record")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets purchase record by UID from database
+func (s *PurchaseRecordStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get purchase record")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets purchase record by code from database
+func (s *PurchaseRecordStore) GetByCode(ctx context.Context, code string) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get purchase record")
+	}
+
+	return obj, nil
+}
+
+// GetByToken gets purchase record by purchase_token from database
+func (s *PurchaseRecordStore) GetByToken(ctx context.Context, token string) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.purchase_token = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, token)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get purchase record")
+	}
+
+	return obj, nil
+}
+
+// ListUnredeemedByEmail retrives all purchase records of a buyer email which are not redeemed yet
+func (s *PurchaseRecordStore) ListUnredeemedByEmail(ctx context.Context, email string) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE lower(purchase_records.buyer_email) = lower($1)
+	AND purchase_records.redeemed_by_id IS NULL
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get purchase records"
+	rows, err := s.conn.Query(ctx, queryStmt, email)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return records, nil
+}
+
+// ListUnredeemedByPhone retrives all purchase records of a buyer phone which are not redeemed yet
+func (s *PurchaseRecordStore) ListUnredeemedByPhone(ctx context.Context, phone string) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.buyer_phone = $1
+	AND purchase_records.redeemed_by_id IS NULL
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get purchase records"
+	rows, err := s.conn.Query(ctx, queryStmt, phone)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return records, nil
+}
+
+// ListByProductUIDs retrives the purchase records of the products with the given UIDs from database
+func (s *PurchaseRecordStore) ListByProductUIDs(ctx context.Context, productUIDs []string) ([]models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM purchase_records
+	WHERE purchase_records.product_uid = ANY($1::uuid[])
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get purchase records"
+	rows, err := s.conn.Query(ctx, queryStmt, productUIDs)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	records, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return records, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a purchase record in database
+func (s *PurchaseRecordStore) Insert(ctx context.Context, tx pgx.Tx, obj models.PurchaseRecord) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	purchase_records(
+		uid,
+		code,
+		product_uid,
+		buyer_email,
+		buyer_phone,
+		purchase_token,
+		retailer,
+		points,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.ProductUID,
+		&obj.BuyerEmail,
+		&obj.BuyerPhone,
+		&obj.PurchaseToken,
+		&obj.Retailer,
+		&obj.Points,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	record, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert purchase record")
+	}
+
+	return record, nil
+}
+
+// Update updates a purchase record in database
+func (s *PurchaseRecordStore) Update(ctx context.Context, tx pgx.Tx, obj models.PurchaseRecord) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE purchase_records
+	SET
+		product_uid = $1,
+		buyer_email = $2,
+		buyer_phone = $3,
+		retailer = $4,
+		points = $5,
+		updated_at = NOW()
+	WHERE id=$6
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.ProductUID,
+		&obj.BuyerEmail,
+		&obj.BuyerPhone,
+		&obj.Retailer,
+		&obj.Points,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update purchase record")
+	}
+
+	return nil
+}
+
+// Redeem marks a purchase record as redeemed by a user unless it has already been redeemed
+func (s *PurchaseRecordStore) Redeem(ctx context.Context, tx pgx.Tx, id int64, userID int64) (*models.PurchaseRecord, *faulterr.FaultErr) {
+	queryStmt := `
+	UPDATE purchase_records
+	SET
+		redeemed_by_id = $2,
+		redeemed_at = NOW(),
+		updated_at = NOW()
+	WHERE id=$1
+	AND redeemed_by_id IS NULL
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id, userID)
+	record, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to redeem purchase record")
+	}
+
+	return record, nil
+}
+
+// Delete deletes a purchase record from database
+func (s *PurchaseRecordStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM purchase_records WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete purchase record")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *PurchaseRecordStore) scanList(rows pgx.Rows) ([]models.PurchaseRecord, error) {
+	records := []models.PurchaseRecord{}
+	obj := models.PurchaseRecord{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.ProductUID,
+			&obj.BuyerEmail,
+			&obj.BuyerPhone,
+			&obj.PurchaseToken,
+			&obj.Retailer,
+			&obj.Points,
+			&obj.RedeemedByID,
+			&obj.RedeemedAt,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		records = append(records, obj)
+	}
+
+	return records, nil
+}
+
+func (s *PurchaseRecordStore) scanRow(row pgx.Row) (*models.PurchaseRecord, error) {
+	obj := models.PurchaseRecord{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.ProductUID,
+		&obj.BuyerEmail,
+		&obj.BuyerPhone,
+		&obj.PurchaseToken,
+		&obj.Retailer,
+		&obj.Points,
+		&obj.RedeemedByID,
+		&obj.RedeemedAt,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,310 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type RecallStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ RecallStoreInterface = &RecallStore{}
+
+type RecallStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Recall, error)
+	List(ctx context.Context) ([]models.Recall, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Recall, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Recall, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Recall, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Recall, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Recall, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Recall) (*models.Recall, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Recall) *faulterr.FaultErr
+}
+
+func NewRecallStore(conn *pgxpool.Pool) *RecallStore {
+	return &RecallStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all recalls by ids
+func (s *RecallStore) GetMany(ctx context.Context, ids []int64) ([]*models.Recall, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from recalls WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recalls, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Recall{}
+	for i := 0; i < len(recalls); i++ {
+		result = append(result, &recalls[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all recalls from database
+func (s *RecallStore) List(ctx context.Context) ([]models.Recall, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM recalls`
+
+	errMsg := "error when trying to get recalls"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	recalls, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return recalls, nil
+}
+
+// ListByOrgID retrives all recalls of an organization from database
+func (s *RecallStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Recall, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recalls
+	WHERE recalls.organization_id = $1
+	`
+
+	errMsg := "error when trying to get recalls"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	recalls, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return recalls, nil
+}
+
+// GetByID gets recall by ID from database
+func (s *RecallStore) GetByID(ctx context.Context, id int64) (*models.Recall, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recalls
+	WHERE recalls.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get recall")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets recall by UID from database
+func (s *RecallStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Recall, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recalls
+	WHERE recalls.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get recall")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets recall by code from database
+func (s *RecallStore) GetByCode(ctx context.Context, code string) (*models.Recall, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recalls
+	WHERE recalls.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get recall")
+	}
+
+	return obj, nil
+}
+
+// LockByID gets a recall and locks it until the transaction ends,
+// closing a recall and resolving its items are serialized on it
+func (s *RecallStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Recall, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recalls
+	WHERE recalls.id = $1
+	FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock recall")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a recall in database
+func (s *RecallStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Recall) (*models.Recall, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	recalls(
+		uid,
+		code,
+		title,
+		reason,
+		scope,
+		sku_id,
+		uids,
+		organization_id,
+		created_by_id,
+		lot_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Title,
+		&obj.Reason,
+		&obj.Scope,
+		&obj.SkuID,
+		&obj.UIDs,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.LotID,
+	)
+
+	recall, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert recall")
+	}
+
+	return recall, nil
+}
+
+// Update updates a recall in database
+func (s *RecallStore) Update(ctx context.Context, tx pgx.Tx, obj models.Recall) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE recalls
+	SET
+		title = $1,
+		reason = $2,
+		status = $3,
+		closed_at = $4,
+		updated_at = NOW()
+	WHERE id=$5
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Title,
+		&obj.Reason,
+		&obj.Status,
+		&obj.ClosedAt,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update recall")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *RecallStore) scanList(rows pgx.Rows) ([]models.Recall, error) {
+	recalls := []models.Recall{}
+
+	for rows.Next() {
+		// A fresh object per row so that UIDs are not shared between recalls
+		obj := models.Recall{}
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Title,
+			&obj.Reason,
+			&obj.Scope,
+			&obj.SkuID,
+			&obj.UIDs,
+			&obj.Status,
+			&obj.ClosedAt,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+			&obj.LotID,
+		); err != nil {
+			return nil, err
+		}
+		recalls = append(recalls, obj)
+	}
+
+	return recalls, nil
+}
+
+func (s *RecallStore) scanRow(row pgx.Row) (*models.Recall, error) {
+	obj := models.Recall{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Title,
+		&obj.Reason,
+		&obj.Scope,
+		&obj.SkuID,
+		&obj.UIDs,
+		&obj.Status,
+		&obj.ClosedAt,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.LotID,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,248 @@
+package dbstore
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/volatiletech/null"
+)
+
+type RecallItemStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ RecallItemStoreInterface = &RecallItemStore{}
+
+type RecallItemStoreInterface interface {
+	ListByRecallID(ctx context.Context, recallID int64) ([]models.RecallItem, *faulterr.FaultErr)
+	ListByRecipient(ctx context.Context, recallID int64, orgID null.Int64, customerID null.Int64) ([]models.RecallItem, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.RecallItem, *faulterr.FaultErr)
+	ListOpenByItem(ctx context.Context, itemType string, itemID int64) ([]models.RecallItem, *faulterr.FaultErr)
+	InsertMany(ctx context.Context, tx pgx.Tx, objs []models.RecallItem) ([]models.RecallItem, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.RecallItem) *faulterr.FaultErr
+}
+
+func NewRecallItemStore(conn *pgxpool.Pool) *RecallItemStore {
+	return &RecallItemStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByRecallID retrives all items of a recall from database
+func (s *RecallItemStore) ListByRecallID(ctx context.Context, recallID int64) ([]models.RecallItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recall_items
+	WHERE recall_items.recall_id = $1
+	ORDER BY item_type, item_id
+	`
+
+	errMsg := "error when trying to get recall items"
+	rows, err := s.conn.Query(ctx, queryStmt, recallID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	items, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return items, nil
+}
+
+// ListByRecipient retrives the items of a recall which went to an organization or to a customer from database
+func (s *RecallItemStore) ListByRecipient(ctx context.Context, recallID int64, orgID null.Int64, customerID null.Int64) ([]models.RecallItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recall_items
+	WHERE recall_items.recall_id = $1
+	AND (recall_items.organization_id = $2 OR recall_items.customer_id = $3)
+	ORDER BY item_type, item_id
+	`
+
+	errMsg := "error when trying to get recall items"
+	rows, err := s.conn.Query(ctx, queryStmt, recallID, orgID, customerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	items, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return items, nil
+}
+
+// GetByID gets recall item by ID from database
+func (s *RecallItemStore) GetByID(ctx context.Context, id int64) (*models.RecallItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM recall_items
+	WHERE recall_items.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get recall item")
+	}
+
+	return obj, nil
+}
+
+// ListOpenByItem retrives the unresolved items of open recalls for a pallet, container, order,
+// consumer order or purchase record from database
+func (s *RecallItemStore) ListOpenByItem(ctx context.Context, itemType string, itemID int64) ([]models.RecallItem, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT recall_items.* FROM recall_items
+	INNER JOIN recalls ON recalls.id = recall_items.recall_id
+	WHERE recall_items.item_type = $1
+	AND recall_items.item_id = $2
+	AND recall_items.resolved_at IS NULL
+	AND recalls.status = $3
+	ORDER BY recall_items.recall_id
+	`
+
+	errMsg := "error when trying to get recall items"
+	rows, err := s.conn.Query(ctx, queryStmt, itemType, itemID, models.RecallOpen)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	items, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return items, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// InsertMany inserts the items a recall resolved to in database
+func (s *RecallItemStore) InsertMany(ctx context.Context, tx pgx.Tx, objs []models.RecallItem) ([]models.RecallItem, *faulterr.FaultErr) {
+	values := make([]string, len(objs))
+	args := make([]interface{}, 0, len(objs)*5)
+	for i, obj := range objs {
+		n := i * 5
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5)
+		args = append(args,
+			obj.RecallID,
+			obj.ItemType,
+			obj.ItemID,
+			obj.OrganizationID,
+			obj.CustomerID,
+		)
+	}
+
+	queryStmt := `
+	INSERT INTO
+	recall_items(
+		recall_id,
+		item_type,
+		item_id,
+		organization_id,
+		customer_id
+	)
+	VALUES ` + strings.Join(values, ", ") + `
+	RETURNING *
+	`
+
+	errMsg := "error when trying to insert recall items"
+	rows, err := tx.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	items, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return items, nil
+}
+
+// Update updates a recall item in database
+func (s *RecallItemStore) Update(ctx context.Context, tx pgx.Tx, obj models.RecallItem) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE recall_items
+	SET
+		resolved_by_id = $1,
+		resolved_at = $2
+	WHERE id=$3
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.ResolvedByID,
+		&obj.ResolvedAt,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update recall item")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *RecallItemStore) scanList(rows pgx.Rows) ([]models.RecallItem, error) {
+	items := []models.RecallItem{}
+	obj := models.RecallItem{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.RecallID,
+			&obj.ItemType,
+			&obj.ItemID,
+			&obj.OrganizationID,
+			&obj.CustomerID,
+			&obj.ResolvedByID,
+			&obj.ResolvedAt,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, obj)
+	}
+
+	return items, nil
+}
+
+func (s *RecallItemStore) scanRow(row pgx.Row) (*models.RecallItem, error) {
+	obj := models.RecallItem{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.RecallID,
+		&obj.ItemType,
+		&obj.ItemID,
+		&obj.OrganizationID,
+		&obj.CustomerID,
+		&obj.ResolvedByID,
+		&obj.ResolvedAt,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,211 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/volatiletech/null"
+)
+
+type ReferralStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ReferralStoreInterface = &ReferralStore{}
+
+type ReferralStoreInterface interface {
+	List(ctx context.Context) ([]models.Referral, *faulterr.FaultErr)
+	ListByReferrerID(ctx context.Context, referrerID int64) ([]models.Referral, *faulterr.FaultErr)
+	GetByRefereeID(ctx context.Context, refereeID int64) (*models.Referral, *faulterr.FaultErr)
+	GetStats(ctx context.Context, referrerID null.Int64) (*models.ReferralStats, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Referral) (*models.Referral, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewReferralStore(conn *pgxpool.Pool) *ReferralStore {
+	return &ReferralStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByReferrerID retrives all referrals made by a user from database
+func (s *ReferralStore) ListByReferrerID(ctx context.Context, referrerID int64) ([]models.Referral, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM referrals
+	WHERE referrals.referrer_id = $1
+	ORDER BY id DESC
+	`
+
+	errMsg := "error when trying to get referrals"
+	rows, err := s.conn.Query(ctx, queryStmt, referrerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	referrals, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return referrals, nil
+}
+
+// GetByRefereeID gets referral by referee_id from database
+func (s *ReferralStore) GetByRefereeID(ctx context.Context, refereeID int64) (*models.Referral, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM referrals
+	WHERE referrals.referee_id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, refereeID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get referral")
+	}
+
+	return obj, nil
+}
+
+// List retrives all referrals from database
+func (s *ReferralStore) List(ctx context.Context) ([]models.Referral, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM referrals ORDER BY id DESC`
+
+	errMsg := "error when trying to get referrals"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	referrals, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return referrals, nil
+}
+
+// GetStats counts referrals and the points rewarded for them, for a single referrer when given
+func (s *ReferralStore) GetStats(ctx context.Context, referrerID null.Int64) (*models.ReferralStats, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT
+		COUNT(*),
+		COALESCE(SUM(referrals.referrer_points), 0),
+		COALESCE(SUM(referrals.referee_points), 0)
+	FROM referrals
+	WHERE $1::bigint IS NULL OR referrals.referrer_id = $1
+	`
+
+	stats := models.ReferralStats{}
+	row := s.conn.QueryRow(ctx, queryStmt, referrerID)
+	if err := row.Scan(
+		&stats.Referrals,
+		&stats.ReferrerPoints,
+		&stats.RefereePoints,
+	); err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get referral stats")
+	}
+
+	return &stats, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a referral in database
+func (s *ReferralStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Referral) (*models.Referral, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	referrals(
+		referrer_id,
+		referee_id,
+		referral_code,
+		referral_rule_id,
+		referrer_points,
+		referee_points
+	)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.ReferrerID,
+		&obj.RefereeID,
+		&obj.ReferralCode,
+		&obj.ReferralRuleID,
+		&obj.ReferrerPoints,
+		&obj.RefereePoints,
+	)
+
+	referral, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert referral")
+	}
+
+	return referral, nil
+}
+
+// Delete deletes a referral from database
+func (s *ReferralStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM referrals WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete referral")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ReferralStore) scanList(rows pgx.Rows) ([]models.Referral, error) {
+	referrals := []models.Referral{}
+	obj := models.Referral{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.ReferrerID,
+			&obj.RefereeID,
+			&obj.ReferralCode,
+			&obj.ReferralRuleID,
+			&obj.ReferrerPoints,
+			&obj.RefereePoints,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		referrals = append(referrals, obj)
+	}
+
+	return referrals, nil
+}
+
+func (s *ReferralStore) scanRow(row pgx.Row) (*models.Referral, error) {
+	obj := models.Referral{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.ReferrerID,
+		&obj.RefereeID,
+		&obj.ReferralCode,
+		&obj.ReferralRuleID,
+		&obj.ReferrerPoints,
+		&obj.RefereePoints,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
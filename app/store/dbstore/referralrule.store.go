@@ -0,0 +1,220 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ReferralRuleStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ReferralRuleStoreInterface = &ReferralRuleStore{}
+
+type ReferralRuleStoreInterface interface {
+	List(ctx context.Context) ([]models.ReferralRule, *faulterr.FaultErr)
+	GetActive(ctx context.Context) (*models.ReferralRule, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.ReferralRule, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.ReferralRule) (*models.ReferralRule, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.ReferralRule) *faulterr.FaultErr
+	DeactivateOthers(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewReferralRuleStore(conn *pgxpool.Pool) *ReferralRuleStore {
+	return &ReferralRuleStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetByID gets referral rule by ID from database
+func (s *ReferralRuleStore) GetByID(ctx context.Context, id int64) (*models.ReferralRule, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM referral_rules
+	WHERE referral_rules.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get referral rule")
+	}
+
+	return obj, nil
+}
+
+// List retrives all referral rules from database
+func (s *ReferralRuleStore) List(ctx context.Context) ([]models.ReferralRule, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM referral_rules ORDER BY id DESC`
+
+	errMsg := "error when trying to get referral rules"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	rules, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return rules, nil
+}
+
+// GetActive gets the latest active referral rule from database
+func (s *ReferralRuleStore) GetActive(ctx context.Context) (*models.ReferralRule, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM referral_rules
+	WHERE referral_rules.is_active = TRUE
+	ORDER BY id DESC
+	LIMIT 1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt)
+	rule, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get active referral rule")
+	}
+
+	return rule, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a referral rule in database
+func (s *ReferralRuleStore) Insert(ctx context.Context, tx pgx.Tx, obj models.ReferralRule) (*models.ReferralRule, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	referral_rules(
+		referrer_points,
+		referee_points,
+		is_active,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.ReferrerPoints,
+		&obj.RefereePoints,
+		&obj.IsActive,
+		&obj.CreatedByID,
+	)
+
+	rule, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert referral rule")
+	}
+
+	return rule, nil
+}
+
+// Update updates a referral rule in database
+func (s *ReferralRuleStore) Update(ctx context.Context, tx pgx.Tx, obj models.ReferralRule) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE referral_rules
+	SET
+		referrer_points = $1,
+		referee_points = $2,
+		is_active = $3,
+		updated_at = NOW()
+	WHERE id=$4
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.ReferrerPoints,
+		&obj.RefereePoints,
+		&obj.IsActive,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update referral rule")
+	}
+
+	return nil
+}
+
+// Delete deletes a referral rule from database
+func (s *ReferralRuleStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM referral_rules WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete referral rule")
+	}
+
+	return nil
+}
+
+// DeactivateOthers deactivates every referral rule except the given one
+func (s *ReferralRuleStore) DeactivateOthers(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE referral_rules
+	SET
+		is_active = FALSE,
+		updated_at = NOW()
+	WHERE id <> $1
+	AND is_active = TRUE
+	`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to deactivate referral rules")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ReferralRuleStore) scanList(rows pgx.Rows) ([]models.ReferralRule, error) {
+	rules := []models.ReferralRule{}
+	obj := models.ReferralRule{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.ReferrerPoints,
+			&obj.RefereePoints,
+			&obj.IsActive,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rules = append(rules, obj)
+	}
+
+	return rules, nil
+}
+
+func (s *ReferralRuleStore) scanRow(row pgx.Row) (*models.ReferralRule, error) {
+	obj := models.ReferralRule{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.ReferrerPoints,
+		&obj.RefereePoints,
+		&obj.IsActive,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
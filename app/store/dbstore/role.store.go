@@ -18,7 +18,6 @@ type RoleStore struct {
 var _ RoleStoreInterface = &RoleStore{}
 
 type RoleStoreInterface interface {
-	GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr)
 	GetMany(ctx context.Context, ids []int64) ([]*models.Role, error)
 	ListAll(ctx context.Context) ([]models.Role, *faulterr.FaultErr)
 	ListByOrgID(ctx context.Context, orgID int64) ([]models.Role, *faulterr.FaultErr)
@@ -37,32 +36,6 @@ func NewRoleStore(conn *pgxpool.Pool) *RoleStore {
 //////////////////////////////////////////****Read****/////////////////////////////////////////
 ///////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetLastInsertedRow retrives last row from database
-func (s *RoleStore) GetLastInsertedRow(ctx context.Context) (int64, *faulterr.FaultErr) {
-	queryStmt := `
-	SELECT id FROM roles
-	ORDER BY id DESC
-	LIMIT 1
-	`
-
-	var ID int64
-	errMsg := "error getting last inserted row"
-
-	rows, err := s.conn.Query(context.Background(), queryStmt)
-	if err != nil {
-		return 0, faulterr.NewPostgresError(err, errMsg)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		if err := rows.Scan(&ID); err != nil {
-			return 0, faulterr.NewPostgresError(err, errMsg)
-		}
-	}
-
-	return ID, nil
-}
-
 // GetMany get all roles by ids
 func (s *RoleStore) GetMany(ctx context.Context, ids []int64) ([]*models.Role, error) {
 	placeholders := make([]string, len(ids))
@@ -0,0 +1,327 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ShipmentStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ShipmentStoreInterface = &ShipmentStore{}
+
+type ShipmentStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Shipment, error)
+	List(ctx context.Context) ([]models.Shipment, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Shipment, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Shipment, *faulterr.FaultErr)
+	LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Shipment, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Shipment, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Shipment, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Shipment) (*models.Shipment, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Shipment) *faulterr.FaultErr
+}
+
+func NewShipmentStore(conn *pgxpool.Pool) *ShipmentStore {
+	return &ShipmentStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all shipments by ids
+func (s *ShipmentStore) GetMany(ctx context.Context, ids []int64) ([]*models.Shipment, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from shipments WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shipments, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Shipment{}
+	for i := 0; i < len(shipments); i++ {
+		result = append(result, &shipments[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all shipments from database
+func (s *ShipmentStore) List(ctx context.Context) ([]models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM shipments`
+
+	errMsg := "error when trying to get shipments"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	shipments, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return shipments, nil
+}
+
+// ListByOrgID retrives all shipments of an organization from database
+func (s *ShipmentStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipments
+	WHERE shipments.organization_id = $1
+	`
+
+	errMsg := "error when trying to get shipments"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	shipments, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return shipments, nil
+}
+
+// GetByID gets shipment by ID from database
+func (s *ShipmentStore) GetByID(ctx context.Context, id int64) (*models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipments
+	WHERE shipments.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get shipment")
+	}
+
+	return obj, nil
+}
+
+// LockByID gets a shipment and locks it until the transaction ends,
+// which serializes changes to the shipment and its containers
+func (s *ShipmentStore) LockByID(ctx context.Context, tx pgx.Tx, id int64) (*models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipments
+	WHERE shipments.id = $1
+	FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to lock shipment")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets shipment by UID from database
+func (s *ShipmentStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipments
+	WHERE shipments.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get shipment")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets shipment by code from database
+func (s *ShipmentStore) GetByCode(ctx context.Context, code string) (*models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipments
+	WHERE shipments.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get shipment")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a shipment in database
+func (s *ShipmentStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Shipment) (*models.Shipment, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	shipments(
+		uid,
+		code,
+		origin_address_id,
+		destination_address_id,
+		carrier,
+		transport_reference,
+		etd,
+		eta,
+		notes,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.OriginAddressID,
+		&obj.DestinationAddressID,
+		&obj.Carrier,
+		&obj.TransportReference,
+		&obj.ETD,
+		&obj.ETA,
+		&obj.Notes,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	shipment, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert shipment")
+	}
+
+	return shipment, nil
+}
+
+// Update updates a shipment in database
+func (s *ShipmentStore) Update(ctx context.Context, tx pgx.Tx, obj models.Shipment) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE shipments
+	SET
+		status = $1,
+		origin_address_id = $2,
+		destination_address_id = $3,
+		carrier = $4,
+		transport_reference = $5,
+		etd = $6,
+		eta = $7,
+		departed_at = $8,
+		arrived_at = $9,
+		notes = $10,
+		updated_at = NOW()
+	WHERE id=$11
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Status,
+		&obj.OriginAddressID,
+		&obj.DestinationAddressID,
+		&obj.Carrier,
+		&obj.TransportReference,
+		&obj.ETD,
+		&obj.ETA,
+		&obj.DepartedAt,
+		&obj.ArrivedAt,
+		&obj.Notes,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update shipment")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ShipmentStore) scanList(rows pgx.Rows) ([]models.Shipment, error) {
+	shipments := []models.Shipment{}
+	obj := models.Shipment{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Status,
+			&obj.OriginAddressID,
+			&obj.DestinationAddressID,
+			&obj.Carrier,
+			&obj.TransportReference,
+			&obj.ETD,
+			&obj.ETA,
+			&obj.DepartedAt,
+			&obj.ArrivedAt,
+			&obj.Notes,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, obj)
+	}
+
+	return shipments, nil
+}
+
+func (s *ShipmentStore) scanRow(row pgx.Row) (*models.Shipment, error) {
+	obj := models.Shipment{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Status,
+		&obj.OriginAddressID,
+		&obj.DestinationAddressID,
+		&obj.Carrier,
+		&obj.TransportReference,
+		&obj.ETD,
+		&obj.ETA,
+		&obj.DepartedAt,
+		&obj.ArrivedAt,
+		&obj.Notes,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
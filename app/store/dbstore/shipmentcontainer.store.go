@@ -0,0 +1,172 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ShipmentContainerStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ ShipmentContainerStoreInterface = &ShipmentContainerStore{}
+
+type ShipmentContainerStoreInterface interface {
+	ListByShipmentID(ctx context.Context, shipmentID int64) ([]models.ShipmentContainer, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.ShipmentContainer, *faulterr.FaultErr)
+	GetOpenByContainerID(ctx context.Context, containerID int64) (*models.ShipmentContainer, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.ShipmentContainer) (*models.ShipmentContainer, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewShipmentContainerStore(conn *pgxpool.Pool) *ShipmentContainerStore {
+	return &ShipmentContainerStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByShipmentID retrives all containers of a shipment from database
+func (s *ShipmentContainerStore) ListByShipmentID(ctx context.Context, shipmentID int64) ([]models.ShipmentContainer, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipment_containers
+	WHERE shipment_containers.shipment_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get shipment containers"
+	rows, err := s.conn.Query(ctx, queryStmt, shipmentID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	containers, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return containers, nil
+}
+
+// GetByID gets shipment container by ID from database
+func (s *ShipmentContainerStore) GetByID(ctx context.Context, id int64) (*models.ShipmentContainer, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM shipment_containers
+	WHERE shipment_containers.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get shipment container")
+	}
+
+	return obj, nil
+}
+
+// GetOpenByContainerID gets the entry of a container in a shipment which has not arrived or been cancelled
+func (s *ShipmentContainerStore) GetOpenByContainerID(ctx context.Context, containerID int64) (*models.ShipmentContainer, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT shipment_containers.* FROM shipment_containers
+	JOIN shipments ON shipments.id = shipment_containers.shipment_id
+	WHERE shipment_containers.container_id = $1
+	AND shipments.status IN ('planned', 'departed')
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, containerID)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get shipment container")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a shipment container in database
+func (s *ShipmentContainerStore) Insert(ctx context.Context, tx pgx.Tx, obj models.ShipmentContainer) (*models.ShipmentContainer, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	shipment_containers(
+		shipment_id,
+		container_id,
+		added_by_id
+	)
+	VALUES ($1, $2, $3)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.ShipmentID,
+		&obj.ContainerID,
+		&obj.AddedByID,
+	)
+
+	container, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert shipment container")
+	}
+
+	return container, nil
+}
+
+// Delete deletes a shipment container from database
+func (s *ShipmentContainerStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM shipment_containers WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete shipment container")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *ShipmentContainerStore) scanList(rows pgx.Rows) ([]models.ShipmentContainer, error) {
+	containers := []models.ShipmentContainer{}
+	obj := models.ShipmentContainer{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.ShipmentID,
+			&obj.ContainerID,
+			&obj.AddedByID,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		containers = append(containers, obj)
+	}
+
+	return containers, nil
+}
+
+func (s *ShipmentContainerStore) scanRow(row pgx.Row) (*models.ShipmentContainer, error) {
+	obj := models.ShipmentContainer{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.ShipmentID,
+		&obj.ContainerID,
+		&obj.AddedByID,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
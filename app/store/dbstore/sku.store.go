@@ -0,0 +1,336 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type SkuStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ SkuStoreInterface = &SkuStore{}
+
+type SkuStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Sku, error)
+	List(ctx context.Context) ([]models.Sku, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Sku, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Sku, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Sku, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Sku, *faulterr.FaultErr)
+	GetByGTIN(ctx context.Context, gtin string) (*models.Sku, *faulterr.FaultErr)
+	GetByOrgGTIN(ctx context.Context, orgID int64, gtin string) (*models.Sku, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Sku) (*models.Sku, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Sku) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewSkuStore(conn *pgxpool.Pool) *SkuStore {
+	return &SkuStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all skus by ids
+func (s *SkuStore) GetMany(ctx context.Context, ids []int64) ([]*models.Sku, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from skus WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skus, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Sku{}
+	for i := 0; i < len(skus); i++ {
+		result = append(result, &skus[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all skus from database
+func (s *SkuStore) List(ctx context.Context) ([]models.Sku, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM skus`
+
+	errMsg := "error when trying to get skus"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	skus, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return skus, nil
+}
+
+// ListByOrgID retrives all skus of an organization from database
+func (s *SkuStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM skus
+	WHERE skus.organization_id = $1
+	`
+
+	errMsg := "error when trying to get skus"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	skus, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return skus, nil
+}
+
+// GetByID gets sku by ID from database
+func (s *SkuStore) GetByID(ctx context.Context, id int64) (*models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM skus
+	WHERE skus.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get sku")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets sku by UID from database
+func (s *SkuStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM skus
+	WHERE skus.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get sku")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets sku by code from database
+func (s *SkuStore) GetByCode(ctx context.Context, code string) (*models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM skus
+	WHERE skus.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get sku")
+	}
+
+	return obj, nil
+}
+
+// GetByGTIN gets sku by its GTIN-14 from database. Organizations register the GTINs of the products they sell,
+// the sku of the brand owner whose GS1 company prefix the GTIN carries is taken first, then the earliest registered
+func (s *SkuStore) GetByGTIN(ctx context.Context, gtin string) (*models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT skus.* FROM skus
+	LEFT JOIN organization_gs1_settings ON organization_gs1_settings.organization_id = skus.organization_id
+	WHERE skus.gtin = $1
+	ORDER BY COALESCE(SUBSTR(skus.gtin, 2) LIKE organization_gs1_settings.company_prefix || '%', FALSE) DESC, skus.id
+	LIMIT 1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, gtin)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get sku")
+	}
+
+	return obj, nil
+}
+
+// GetByOrgGTIN gets the sku of an organization by its GTIN-14 from database
+func (s *SkuStore) GetByOrgGTIN(ctx context.Context, orgID int64, gtin string) (*models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM skus
+	WHERE skus.organization_id = $1
+	AND skus.gtin = $2
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, orgID, gtin)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get sku")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a sku in database
+func (s *SkuStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Sku) (*models.Sku, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	skus(
+		uid,
+		code,
+		name,
+		description,
+		is_archived,
+		organization_id,
+		created_by_id,
+		price,
+		gtin
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.Description,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.Price,
+		&obj.GTIN,
+	)
+
+	sku, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert sku")
+	}
+
+	return sku, nil
+}
+
+// Update updates a sku in database
+func (s *SkuStore) Update(ctx context.Context, tx pgx.Tx, obj models.Sku) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE skus
+	SET
+		name = $1,
+		description = $2,
+		is_archived = $3,
+		price = $4,
+		gtin = $5,
+		updated_at = NOW()
+	WHERE id=$6
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Name,
+		&obj.Description,
+		&obj.IsArchived,
+		&obj.Price,
+		&obj.GTIN,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update sku")
+	}
+
+	return nil
+}
+
+// Delete deletes a sku from database
+func (s *SkuStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM skus WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete sku")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *SkuStore) scanList(rows pgx.Rows) ([]models.Sku, error) {
+	skus := []models.Sku{}
+	obj := models.Sku{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Name,
+			&obj.Description,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+			&obj.Price,
+			&obj.GTIN,
+		); err != nil {
+			return nil, err
+		}
+		skus = append(skus, obj)
+	}
+
+	return skus, nil
+}
+
+func (s *SkuStore) scanRow(row pgx.Row) (*models.Sku, error) {
+	obj := models.Sku{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.Description,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+		&obj.Price,
+		&obj.GTIN,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,194 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type StoredFileStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ StoredFileStoreInterface = &StoredFileStore{}
+
+type StoredFileStoreInterface interface {
+	ListByOwner(ctx context.Context, ownerType string, ownerID int64) ([]models.StoredFile, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.StoredFile, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.StoredFile) (*models.StoredFile, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.StoredFile) *faulterr.FaultErr
+}
+
+func NewStoredFileStore(conn *pgxpool.Pool) *StoredFileStore {
+	return &StoredFileStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOwner retrives all files attached to an organization, container or pallet from database
+func (s *StoredFileStore) ListByOwner(ctx context.Context, ownerType string, ownerID int64) ([]models.StoredFile, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM files
+	WHERE files.owner_type = $1
+	AND files.owner_id = $2
+	ORDER BY attached_at, id
+	`
+
+	errMsg := "error when trying to get files"
+	rows, err := s.conn.Query(ctx, queryStmt, ownerType, ownerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	files, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return files, nil
+}
+
+// GetByID gets file by ID from database
+func (s *StoredFileStore) GetByID(ctx context.Context, id int64) (*models.StoredFile, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM files
+	WHERE files.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get file")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a file in database
+func (s *StoredFileStore) Insert(ctx context.Context, tx pgx.Tx, obj models.StoredFile) (*models.StoredFile, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	files(
+		name,
+		original_name,
+		url,
+		mime_type,
+		size,
+		checksum,
+		organization_id,
+		uploaded_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.Name,
+		&obj.OriginalName,
+		&obj.URL,
+		&obj.MimeType,
+		&obj.Size,
+		&obj.Checksum,
+		&obj.OrganizationID,
+		&obj.UploadedByID,
+	)
+
+	file, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert file")
+	}
+
+	return file, nil
+}
+
+// Update updates the owner of a file in database
+func (s *StoredFileStore) Update(ctx context.Context, tx pgx.Tx, obj models.StoredFile) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE files
+	SET
+		owner_type = $1,
+		owner_id = $2,
+		organization_id = $3,
+		attached_at = $4
+	WHERE id=$5
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.OwnerType,
+		&obj.OwnerID,
+		&obj.OrganizationID,
+		&obj.AttachedAt,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update file")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *StoredFileStore) scanList(rows pgx.Rows) ([]models.StoredFile, error) {
+	files := []models.StoredFile{}
+	obj := models.StoredFile{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.Name,
+			&obj.OriginalName,
+			&obj.URL,
+			&obj.MimeType,
+			&obj.Size,
+			&obj.Checksum,
+			&obj.OwnerType,
+			&obj.OwnerID,
+			&obj.OrganizationID,
+			&obj.UploadedByID,
+			&obj.AttachedAt,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		files = append(files, obj)
+	}
+
+	return files, nil
+}
+
+func (s *StoredFileStore) scanRow(row pgx.Row) (*models.StoredFile, error) {
+	obj := models.StoredFile{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.Name,
+		&obj.OriginalName,
+		&obj.URL,
+		&obj.MimeType,
+		&obj.Size,
+		&obj.Checksum,
+		&obj.OwnerType,
+		&obj.OwnerID,
+		&obj.OrganizationID,
+		&obj.UploadedByID,
+		&obj.AttachedAt,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,344 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TaskStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ TaskStoreInterface = &TaskStore{}
+
+type TaskStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Task, error)
+	List(ctx context.Context) ([]models.Task, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Task, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Task, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Task, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Task, *faulterr.FaultErr)
+	ListByAssignee(ctx context.Context, userID int64, roleID int64) ([]models.Task, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Task) (*models.Task, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Task) *faulterr.FaultErr
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewTaskStore(conn *pgxpool.Pool) *TaskStore {
+	return &TaskStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all tasks by ids
+func (s *TaskStore) GetMany(ctx context.Context, ids []int64) ([]*models.Task, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from tasks WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Task{}
+	for i := 0; i < len(tasks); i++ {
+		result = append(result, &tasks[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all tasks from database
+func (s *TaskStore) List(ctx context.Context) ([]models.Task, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM tasks`
+
+	errMsg := "error when trying to get tasks"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return tasks, nil
+}
+
+// ListByOrgID retrives all tasks of an organization from database
+func (s *TaskStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Task, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM tasks
+	WHERE tasks.organization_id = $1
+	`
+
+	errMsg := "error when trying to get tasks"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return tasks, nil
+}
+
+// GetByID gets task by ID from database
+func (s *TaskStore) GetByID(ctx context.Context, id int64) (*models.Task, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM tasks
+	WHERE tasks.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get task")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets task by UID from database
+func (s *TaskStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Task, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM tasks
+	WHERE tasks.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get task")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets task by code from database
+func (s *TaskStore) GetByCode(ctx context.Context, code string) (*models.Task, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM tasks
+	WHERE tasks.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get task")
+	}
+
+	return obj, nil
+}
+
+// ListByAssignee retrives all tasks assigned to a user or to the role of the user
+func (s *TaskStore) ListByAssignee(ctx context.Context, userID int64, roleID int64) ([]models.Task, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM tasks
+	WHERE tasks.assignee_user_id = $1
+	OR tasks.assignee_role_id = $2
+	ORDER BY tasks.due_date NULLS LAST, tasks.id
+	`
+
+	errMsg := "error when trying to get tasks"
+	rows, err := s.conn.Query(ctx, queryStmt, userID, roleID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	tasks, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return tasks, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a task in database
+func (s *TaskStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Task) (*models.Task, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	tasks(
+		uid,
+		code,
+		title,
+		description,
+		status,
+		due_date,
+		assignee_user_id,
+		assignee_role_id,
+		container_id,
+		pallet_id,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Title,
+		&obj.Description,
+		&obj.Status,
+		&obj.DueDate,
+		&obj.AssigneeUserID,
+		&obj.AssigneeRoleID,
+		&obj.ContainerID,
+		&obj.PalletID,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	task, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert task")
+	}
+
+	return task, nil
+}
+
+// Update updates a task in database
+func (s *TaskStore) Update(ctx context.Context, tx pgx.Tx, obj models.Task) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE tasks
+	SET
+		title = $1,
+		description = $2,
+		status = $3,
+		due_date = $4,
+		assignee_user_id = $5,
+		assignee_role_id = $6,
+		container_id = $7,
+		pallet_id = $8,
+		completed_at = $9,
+		updated_at = NOW()
+	WHERE id=$10
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Title,
+		&obj.Description,
+		&obj.Status,
+		&obj.DueDate,
+		&obj.AssigneeUserID,
+		&obj.AssigneeRoleID,
+		&obj.ContainerID,
+		&obj.PalletID,
+		&obj.CompletedAt,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update task")
+	}
+
+	return nil
+}
+
+// Delete deletes a task from database
+func (s *TaskStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM tasks WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete task")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *TaskStore) scanList(rows pgx.Rows) ([]models.Task, error) {
+	tasks := []models.Task{}
+	obj := models.Task{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Title,
+			&obj.Description,
+			&obj.Status,
+			&obj.DueDate,
+			&obj.AssigneeUserID,
+			&obj.AssigneeRoleID,
+			&obj.ContainerID,
+			&obj.PalletID,
+			&obj.CompletedAt,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, obj)
+	}
+
+	return tasks, nil
+}
+
+func (s *TaskStore) scanRow(row pgx.Row) (*models.Task, error) {
+	obj := models.Task{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Title,
+		&obj.Description,
+		&obj.Status,
+		&obj.DueDate,
+		&obj.AssigneeUserID,
+		&obj.AssigneeRoleID,
+		&obj.ContainerID,
+		&obj.PalletID,
+		&obj.CompletedAt,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
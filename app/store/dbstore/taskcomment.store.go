@@ -0,0 +1,153 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TaskCommentStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ TaskCommentStoreInterface = &TaskCommentStore{}
+
+type TaskCommentStoreInterface interface {
+	ListByTaskID(ctx context.Context, taskID int64) ([]models.TaskComment, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.TaskComment, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.TaskComment) (*models.TaskComment, *faulterr.FaultErr)
+	Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr
+}
+
+func NewTaskCommentStore(conn *pgxpool.Pool) *TaskCommentStore {
+	return &TaskCommentStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByTaskID retrives all comments of a task from database
+func (s *TaskCommentStore) ListByTaskID(ctx context.Context, taskID int64) ([]models.TaskComment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM task_comments
+	WHERE task_comments.task_id = $1
+	ORDER BY id
+	`
+
+	errMsg := "error when trying to get task comments"
+	rows, err := s.conn.Query(ctx, queryStmt, taskID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	comments, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return comments, nil
+}
+
+// GetByID gets task comment by ID from database
+func (s *TaskCommentStore) GetByID(ctx context.Context, id int64) (*models.TaskComment, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM task_comments
+	WHERE task_comments.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get task comment")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a task comment in database
+func (s *TaskCommentStore) Insert(ctx context.Context, tx pgx.Tx, obj models.TaskComment) (*models.TaskComment, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	task_comments(
+		task_id,
+		user_id,
+		body
+	)
+	VALUES ($1, $2, $3)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.TaskID,
+		&obj.UserID,
+		&obj.Body,
+	)
+
+	comment, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert task comment")
+	}
+
+	return comment, nil
+}
+
+// Delete deletes a task comment from database
+func (s *TaskCommentStore) Delete(ctx context.Context, tx pgx.Tx, id int64) *faulterr.FaultErr {
+	queryStmt := `DELETE FROM task_comments WHERE id=$1`
+
+	_, err := tx.Exec(ctx, queryStmt, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to delete task comment")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *TaskCommentStore) scanList(rows pgx.Rows) ([]models.TaskComment, error) {
+	comments := []models.TaskComment{}
+	obj := models.TaskComment{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.TaskID,
+			&obj.UserID,
+			&obj.Body,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		comments = append(comments, obj)
+	}
+
+	return comments, nil
+}
+
+func (s *TaskCommentStore) scanRow(row pgx.Row) (*models.TaskComment, error) {
+	obj := models.TaskComment{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.TaskID,
+		&obj.UserID,
+		&obj.Body,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
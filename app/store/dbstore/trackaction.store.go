@@ -0,0 +1,235 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TrackActionStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ TrackActionStoreInterface = &TrackActionStore{}
+
+type TrackActionStoreInterface interface {
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.TrackAction, *faulterr.FaultErr)
+	ListByContainerID(ctx context.Context, containerID int64) ([]models.TrackAction, *faulterr.FaultErr)
+	ListByPalletID(ctx context.Context, palletID int64) ([]models.TrackAction, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.TrackAction, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.TrackAction, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.TrackAction) (*models.TrackAction, *faulterr.FaultErr)
+}
+
+func NewTrackActionStore(conn *pgxpool.Pool) *TrackActionStore {
+	return &TrackActionStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByOrgID retrives all track actions of an organization from database
+func (s *TrackActionStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.TrackAction, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM track_actions
+	WHERE track_actions.organization_id = $1
+	ORDER BY occurred_at, id
+	`
+
+	errMsg := "error when trying to get track actions"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	actions, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return actions, nil
+}
+
+// ListByContainerID retrives all track actions of a container from database
+func (s *TrackActionStore) ListByContainerID(ctx context.Context, containerID int64) ([]models.TrackAction, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM track_actions
+	WHERE track_actions.container_id = $1
+	ORDER BY occurred_at, id
+	`
+
+	errMsg := "error when trying to get track actions"
+	rows, err := s.conn.Query(ctx, queryStmt, containerID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	actions, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return actions, nil
+}
+
+// ListByPalletID retrives all track actions of a pallet from database
+func (s *TrackActionStore) ListByPalletID(ctx context.Context, palletID int64) ([]models.TrackAction, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM track_actions
+	WHERE track_actions.pallet_id = $1
+	ORDER BY occurred_at, id
+	`
+
+	errMsg := "error when trying to get track actions"
+	rows, err := s.conn.Query(ctx, queryStmt, palletID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	actions, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return actions, nil
+}
+
+// GetByID gets track action by ID from database
+func (s *TrackActionStore) GetByID(ctx context.Context, id int64) (*models.TrackAction, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM track_actions
+	WHERE track_actions.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get track action")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets track action by UID from database
+func (s *TrackActionStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.TrackAction, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM track_actions
+	WHERE track_actions.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get track action")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a track action in database
+func (s *TrackActionStore) Insert(ctx context.Context, tx pgx.Tx, obj models.TrackAction) (*models.TrackAction, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	track_actions(
+		uid,
+		action,
+		container_id,
+		pallet_id,
+		location,
+		payload,
+		actor_id,
+		organization_id,
+		occurred_at
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Action,
+		&obj.ContainerID,
+		&obj.PalletID,
+		&obj.Location,
+		&obj.Payload,
+		&obj.ActorID,
+		&obj.OrganizationID,
+		&obj.OccurredAt,
+	)
+
+	action, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert track action")
+	}
+
+	return action, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *TrackActionStore) scanList(rows pgx.Rows) ([]models.TrackAction, error) {
+	actions := []models.TrackAction{}
+
+	for rows.Next() {
+		// A fresh object per row so that payload maps are not shared between actions
+		obj := models.TrackAction{}
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Action,
+			&obj.ContainerID,
+			&obj.PalletID,
+			&obj.Location,
+			&obj.Payload,
+			&obj.ActorID,
+			&obj.OrganizationID,
+			&obj.OccurredAt,
+			&obj.CreatedAt,
+			&obj.AnchorTxHash,
+			&obj.AnchoredAt,
+		); err != nil {
+			return nil, err
+		}
+		actions = append(actions, obj)
+	}
+
+	return actions, nil
+}
+
+func (s *TrackActionStore) scanRow(row pgx.Row) (*models.TrackAction, error) {
+	obj := models.TrackAction{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Action,
+		&obj.ContainerID,
+		&obj.PalletID,
+		&obj.Location,
+		&obj.Payload,
+		&obj.ActorID,
+		&obj.OrganizationID,
+		&obj.OccurredAt,
+		&obj.CreatedAt,
+		&obj.AnchorTxHash,
+		&obj.AnchoredAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -0,0 +1,271 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type WalletPointEntryStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ WalletPointEntryStoreInterface = &WalletPointEntryStore{}
+
+type WalletPointEntryStoreInterface interface {
+	ListByUserID(ctx context.Context, userID int64) ([]models.WalletPointEntry, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.WalletPointEntry, *faulterr.FaultErr)
+	ListUnspentByUserID(ctx context.Context, tx pgx.Tx, userID int64) ([]models.WalletPointEntry, *faulterr.FaultErr)
+	ListUserIDsWithExpired(ctx context.Context) ([]int64, *faulterr.FaultErr)
+	GetBalance(ctx context.Context, userID int64, expiringBefore time.Time) (*models.WalletBalance, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.WalletPointEntry) (*models.WalletPointEntry, *faulterr.FaultErr)
+	UpdateRemaining(ctx context.Context, tx pgx.Tx, id int64, remaining int64) *faulterr.FaultErr
+}
+
+func NewWalletPointEntryStore(conn *pgxpool.Pool) *WalletPointEntryStore {
+	return &WalletPointEntryStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ListByUserID retrives all wallet point entries of a user from database
+func (s *WalletPointEntryStore) ListByUserID(ctx context.Context, userID int64) ([]models.WalletPointEntry, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM wallet_point_entries
+	WHERE wallet_point_entries.user_id = $1
+	ORDER BY created_at DESC, id DESC
+	`
+
+	errMsg := "error when trying to get wallet point entries"
+	rows, err := s.conn.Query(ctx, queryStmt, userID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	entries, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return entries, nil
+}
+
+// GetByID gets wallet point entry by ID from database
+func (s *WalletPointEntryStore) GetByID(ctx context.Context, id int64) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM wallet_point_entries
+	WHERE wallet_point_entries.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get wallet point entry")
+	}
+
+	return obj, nil
+}
+
+// ListUnspentByUserID locks and retrives the credits of a user which still have points left,
+// the credits expiring first come first so they are spent before the others
+func (s *WalletPointEntryStore) ListUnspentByUserID(ctx context.Context, tx pgx.Tx, userID int64) ([]models.WalletPointEntry, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM wallet_point_entries
+	WHERE wallet_point_entries.user_id = $1
+	AND wallet_point_entries.remaining > 0
+	ORDER BY expires_at NULLS LAST, id
+	FOR UPDATE
+	`
+
+	errMsg := "error when trying to get wallet point entries"
+	rows, err := tx.Query(ctx, queryStmt, userID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	entries, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return entries, nil
+}
+
+// ListUserIDsWithExpired retrives the users owning credits which expired with points left
+func (s *WalletPointEntryStore) ListUserIDsWithExpired(ctx context.Context) ([]int64, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT DISTINCT user_id FROM wallet_point_entries
+	WHERE wallet_point_entries.remaining > 0
+	AND wallet_point_entries.expires_at <= NOW()
+	`
+
+	errMsg := "error when trying to get users with expired wallet points"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, faulterr.NewPostgresError(err, errMsg)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// GetBalance sums up the ledger of a user along with the points expiring before the given time
+func (s *WalletPointEntryStore) GetBalance(ctx context.Context, userID int64, expiringBefore time.Time) (*models.WalletBalance, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT
+		COALESCE(SUM(points), 0),
+		COALESCE(SUM(remaining) FILTER (WHERE expires_at <= $2), 0),
+		MIN(expires_at) FILTER (WHERE remaining > 0)
+	FROM wallet_point_entries
+	WHERE wallet_point_entries.user_id = $1
+	`
+
+	obj := models.WalletBalance{}
+	row := s.conn.QueryRow(ctx, queryStmt, userID, expiringBefore)
+	if err := row.Scan(
+		&obj.LedgerPoints,
+		&obj.ExpiringPoints,
+		&obj.NextExpiresAt,
+	); err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get wallet balance")
+	}
+
+	return &obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a wallet point entry in database
+func (s *WalletPointEntryStore) Insert(ctx context.Context, tx pgx.Tx, obj models.WalletPointEntry) (*models.WalletPointEntry, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	wallet_point_entries(
+		uid,
+		user_id,
+		entry_type,
+		points,
+		balance,
+		remaining,
+		reason,
+		reference_type,
+		reference_id,
+		expires_at,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.UserID,
+		&obj.EntryType,
+		&obj.Points,
+		&obj.Balance,
+		&obj.Remaining,
+		&obj.Reason,
+		&obj.ReferenceType,
+		&obj.ReferenceID,
+		&obj.ExpiresAt,
+		&obj.CreatedByID,
+	)
+
+	entry, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert wallet point entry")
+	}
+
+	return entry, nil
+}
+
+// UpdateRemaining sets the points left on a credit after spending or expiring part of it
+func (s *WalletPointEntryStore) UpdateRemaining(ctx context.Context, tx pgx.Tx, id int64, remaining int64) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE wallet_point_entries
+	SET
+		remaining=$1
+	WHERE wallet_point_entries.id=$2
+	`
+
+	_, err := tx.Exec(ctx, queryStmt, remaining, id)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update wallet point entry")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *WalletPointEntryStore) scanList(rows pgx.Rows) ([]models.WalletPointEntry, error) {
+	entries := []models.WalletPointEntry{}
+	obj := models.WalletPointEntry{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.UserID,
+			&obj.EntryType,
+			&obj.Points,
+			&obj.Balance,
+			&obj.Remaining,
+			&obj.Reason,
+			&obj.ReferenceType,
+			&obj.ReferenceID,
+			&obj.ExpiresAt,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, obj)
+	}
+
+	return entries, nil
+}
+
+func (s *WalletPointEntryStore) scanRow(row pgx.Row) (*models.WalletPointEntry, error) {
+	obj := models.WalletPointEntry{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.UserID,
+		&obj.EntryType,
+		&obj.Points,
+		&obj.Balance,
+		&obj.Remaining,
+		&obj.Reason,
+		&obj.ReferenceType,
+		&obj.ReferenceID,
+		&obj.ExpiresAt,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
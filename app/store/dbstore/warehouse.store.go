@@ -0,0 +1,270 @@
+package dbstore
+
+import (
+	"context"
+	"orijinplus/app/models"
+	"orijinplus/utils/faulterr"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type WarehouseStore struct {
+	conn *pgxpool.Pool
+}
+
+var _ WarehouseStoreInterface = &WarehouseStore{}
+
+type WarehouseStoreInterface interface {
+	GetMany(ctx context.Context, ids []int64) ([]*models.Warehouse, error)
+	List(ctx context.Context) ([]models.Warehouse, *faulterr.FaultErr)
+	ListByOrgID(ctx context.Context, orgID int64) ([]models.Warehouse, *faulterr.FaultErr)
+	GetByID(ctx context.Context, id int64) (*models.Warehouse, *faulterr.FaultErr)
+	GetByUID(ctx context.Context, uid uuid.UUID) (*models.Warehouse, *faulterr.FaultErr)
+	GetByCode(ctx context.Context, code string) (*models.Warehouse, *faulterr.FaultErr)
+	Insert(ctx context.Context, tx pgx.Tx, obj models.Warehouse) (*models.Warehouse, *faulterr.FaultErr)
+	Update(ctx context.Context, tx pgx.Tx, obj models.Warehouse) *faulterr.FaultErr
+}
+
+func NewWarehouseStore(conn *pgxpool.Pool) *WarehouseStore {
+	return &WarehouseStore{conn}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Read****/////////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMany get all warehouses by ids
+func (s *WarehouseStore) GetMany(ctx context.Context, ids []int64) ([]*models.Warehouse, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i := 0; i < len(ids); i++ {
+		index := strconv.Itoa(i + 1)
+		placeholders[i] = "$" + index
+		args[i] = ids[i]
+	}
+
+	queryStmt := "SELECT * from warehouses WHERE id IN (" + strings.Join(placeholders, ",") + ")"
+
+	rows, err := s.conn.Query(ctx, queryStmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses, err := s.scanList(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Warehouse{}
+	for i := 0; i < len(warehouses); i++ {
+		result = append(result, &warehouses[i])
+	}
+
+	return result, nil
+}
+
+// List retrives all warehouses from database
+func (s *WarehouseStore) List(ctx context.Context) ([]models.Warehouse, *faulterr.FaultErr) {
+	queryStmt := `SELECT * FROM warehouses`
+
+	errMsg := "error when trying to get warehouses"
+	rows, err := s.conn.Query(ctx, queryStmt)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	warehouses, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return warehouses, nil
+}
+
+// ListByOrgID retrives all warehouses of an organization from database
+func (s *WarehouseStore) ListByOrgID(ctx context.Context, orgID int64) ([]models.Warehouse, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM warehouses
+	WHERE warehouses.organization_id = $1
+	`
+
+	errMsg := "error when trying to get warehouses"
+	rows, err := s.conn.Query(ctx, queryStmt, orgID)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+	defer rows.Close()
+
+	warehouses, err := s.scanList(rows)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, errMsg)
+	}
+
+	return warehouses, nil
+}
+
+// GetByID gets warehouse by ID from database
+func (s *WarehouseStore) GetByID(ctx context.Context, id int64) (*models.Warehouse, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM warehouses
+	WHERE warehouses.id = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, id)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get warehouse")
+	}
+
+	return obj, nil
+}
+
+// GetByUID gets warehouse by UID from database
+func (s *WarehouseStore) GetByUID(ctx context.Context, uid uuid.UUID) (*models.Warehouse, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM warehouses
+	WHERE warehouses.uid = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, uid)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get warehouse")
+	}
+
+	return obj, nil
+}
+
+// GetByCode gets warehouse by code from database
+func (s *WarehouseStore) GetByCode(ctx context.Context, code string) (*models.Warehouse, *faulterr.FaultErr) {
+	queryStmt := `
+	SELECT * FROM warehouses
+	WHERE warehouses.code = $1
+	`
+
+	row := s.conn.QueryRow(ctx, queryStmt, code)
+	obj, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to get warehouse")
+	}
+
+	return obj, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Mutate****///////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+// Insert inserts a warehouse in database
+func (s *WarehouseStore) Insert(ctx context.Context, tx pgx.Tx, obj models.Warehouse) (*models.Warehouse, *faulterr.FaultErr) {
+	queryStmt := `
+	INSERT INTO
+	warehouses(
+		uid,
+		code,
+		name,
+		address_id,
+		organization_id,
+		created_by_id
+	)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING *
+	`
+
+	row := tx.QueryRow(ctx, queryStmt,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.AddressID,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+	)
+
+	warehouse, err := s.scanRow(row)
+	if err != nil {
+		return nil, faulterr.NewPostgresError(err, "error when trying to insert warehouse")
+	}
+
+	return warehouse, nil
+}
+
+// Update updates a warehouse in database
+func (s *WarehouseStore) Update(ctx context.Context, tx pgx.Tx, obj models.Warehouse) *faulterr.FaultErr {
+	queryStmt := `
+	UPDATE warehouses
+	SET
+		name = $1,
+		address_id = $2,
+		is_archived = $3,
+		updated_at = NOW()
+	WHERE id=$4
+	`
+
+	_, err := tx.Exec(ctx, queryStmt,
+		&obj.Name,
+		&obj.AddressID,
+		&obj.IsArchived,
+		&obj.ID,
+	)
+	if err != nil {
+		return faulterr.NewPostgresError(err, "error when trying to update warehouse")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////****Helpers****//////////////////////////////////////
+///////////////////////////////////////////////////////////////////////////////////////////////
+
+func (s *WarehouseStore) scanList(rows pgx.Rows) ([]models.Warehouse, error) {
+	warehouses := []models.Warehouse{}
+	obj := models.Warehouse{}
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&obj.ID,
+			&obj.UID,
+			&obj.Code,
+			&obj.Name,
+			&obj.AddressID,
+			&obj.IsArchived,
+			&obj.OrganizationID,
+			&obj.CreatedByID,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, obj)
+	}
+
+	return warehouses, nil
+}
+
+func (s *WarehouseStore) scanRow(row pgx.Row) (*models.Warehouse, error) {
+	obj := models.Warehouse{}
+
+	if err := row.Scan(
+		&obj.ID,
+		&obj.UID,
+		&obj.Code,
+		&obj.Name,
+		&obj.AddressID,
+		&obj.IsArchived,
+		&obj.OrganizationID,
+		&obj.CreatedByID,
+		&obj.CreatedAt,
+		&obj.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
@@ -6,13 +6,14 @@ import (
 	"orijinplus/app/models"
 	"orijinplus/utils/faulterr"
 	"os"
-	"strconv"
+	"path"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/gofrs/uuid"
 )
 
 type FileStore struct {
@@ -60,17 +61,22 @@ func (fs *FileStore) ListBucketItems() *faulterr.FaultErr {
 	return nil
 }
 
+// UploadFile stores a file under a key of its own, the key is prefixed with a random UUID so files
+// uploaded with the same name never replace each other
 func (fs *FileStore) UploadFile(filename string, fileObj []byte) (*models.File, *faulterr.FaultErr) {
-	timestamp := time.Now().UTC().Unix()
-	key := strconv.Itoa(int(timestamp)) + "_" + filename
+	uid, uidErr := uuid.NewV4()
+	if uidErr != nil {
+		return nil, faulterr.NewInternalServerError(uidErr.Error())
+	}
+	key := uid.String() + "_" + path.Base(filename)
 
 	uploader := s3manager.NewUploader(fs.Session)
-	_, err := uploader.Upload(&s3manager.UploadInput{
+	_, uploadErr := uploader.Upload(&s3manager.UploadInput{
 		Bucket: &fs.BucketName,
 		Key:    &key,
 		Body:   bytes.NewReader(fileObj),
 	})
-	if err != nil {
+	if uploadErr != nil {
 		return nil, faulterr.NewInternalServerError("file upload")
 	}
 
@@ -111,6 +117,19 @@ func (fs *FileStore) DownloadFile(key string) (*models.File, *faulterr.FaultErr)
 	return obj, nil
 }
 
+// DownloadURL signs a link the file can be downloaded with until it expires
+func (fs *FileStore) DownloadURL(key string, expiry time.Duration) (string, *faulterr.FaultErr) {
+	req, _ := fs.S3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(fs.BucketName),
+		Key:    aws.String(key),
+	})
+	url, err := req.Presign(expiry)
+	if err != nil {
+		return "", faulterr.NewInternalServerError("file download url")
+	}
+	return url, nil
+}
+
 func (fs *FileStore) generateURL(key string) string {
 	return fs.BucketName + ".s3.amazonaws.com/" + key
 }
@@ -23,7 +23,9 @@ type AWSCredentails struct {
 }
 
 type Server struct {
-	Address string `mapstructure:"SERVER_ADDRESS"`
+	Address        string `mapstructure:"SERVER_ADDRESS"`
+	VerifyBaseURL  string `mapstructure:"VERIFY_BASE_URL"`
+	TrustedProxies string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 // LoadConfig reads configuration from file or environment variables
@@ -32,6 +34,8 @@ func LoadConfig() (*Config, error) {
 
 	serverAddress := os.Getenv("SERVER_ADDRESS")
 	psqlSource := os.Getenv("PSQL_SOURCE")
+	verifyBaseURL := os.Getenv("VERIFY_BASE_URL")
+	trustedProxies := os.Getenv("TRUSTED_PROXIES")
 
 	// awsDefaultRegion := os.Getenv("AWS_DEFAULT_REGION")
 	// awsAccessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
@@ -57,7 +61,9 @@ func LoadConfig() (*Config, error) {
 		PSQLSource: psqlSource,
 	}
 	server := &Server{
-		Address: serverAddress,
+		Address:        serverAddress,
+		VerifyBaseURL:  verifyBaseURL,
+		TrustedProxies: trustedProxies,
 	}
 	config := &Config{
 		Database: db,
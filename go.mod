@@ -5,6 +5,7 @@ go 1.17
 require (
 	github.com/99designs/gqlgen v0.14.0
 	github.com/aws/aws-sdk-go v1.42.22
+	github.com/boombuler/barcode v1.1.0
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/ethereum/go-ethereum v1.10.13
 	github.com/go-chi/chi v1.5.4
@@ -1,7 +1,11 @@
 package server
 
 import (
+	"context"
 	"orijinplus/app/api/dataloaders"
+	"orijinplus/app/api/routes"
+	"orijinplus/app/services"
+	"orijinplus/app/store/dbstore"
 	"orijinplus/config"
 	"time"
 
@@ -13,14 +17,19 @@ import (
 	"github.com/go-chi/cors"
 )
 
+// shutdownTimeout is how long requests in flight may take once the server is asked to stop
+const shutdownTimeout = 10 * time.Second
+
 type RestServer struct {
-	Router *chi.Mux
+	Router   *chi.Mux
+	Services *services.Services
 }
 
 // RestServer is ...
 func NewRestServer(c *config.Clients) *RestServer {
 	r := chi.NewRouter()
-	restServer := &RestServer{r}
+	dbStore, s, rt := Injection(c)
+	restServer := &RestServer{r, s}
 
 	// Add CORS
 	corsOrigin(r)
@@ -28,20 +37,31 @@ func NewRestServer(c *config.Clients) *RestServer {
 	r.Use(middleware.Logger)
 	r.Use(middleware.Timeout(10 * time.Second))
 	r.Route("/", func(r chi.Router) {
-		urls(r, c)
+		urls(r, dbStore, rt)
 	})
 
 	return restServer
 }
 
-func (restServer *RestServer) Start(address string) {
+// Start serves until the context is done, requests in flight are given shutdownTimeout to finish
+func (restServer *RestServer) Start(ctx context.Context, address string) {
+	srv := &http.Server{Addr: address, Handler: restServer.Router}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown: %s", err)
+		}
+	}()
+
 	log.Printf("Listing and serving on port %s", address)
-	http.ListenAndServe(address, restServer.Router)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("Server stopped: %s", err)
+	}
 }
 
-func urls(r chi.Router, c *config.Clients) {
-	dbStore, rt := Injection(c)
-
+func urls(r chi.Router, dbStore *dbstore.DBStore, rt *routes.Routes) {
 	r.Use(dataloaders.DataloaderMiddleware(dbStore))
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +72,9 @@ func urls(r chi.Router, c *config.Clients) {
 		rt.Ping(r)
 		rt.AuthRoutes(r)
 		rt.GraphQL(r)
+		rt.Labels(r)
+		rt.Public(r)
+		rt.EPCIS(r)
 	})
 }
 
@@ -12,7 +12,7 @@ import (
 )
 
 // All dependency injections will go here
-func Injection(c *config.Clients) (*dbstore.DBStore, *routes.Routes) {
+func Injection(c *config.Clients) (*dbstore.DBStore, *services.Services, *routes.Routes) {
 	dbs := dbstore.NewDBStore(c.PostgresConn)
 	blk := blockchain.NewBlockchainStore(c.EthereumClient)
 	fs := filestore.NewFilestore(c.AWSSession)
@@ -21,5 +21,5 @@ func Injection(c *config.Clients) (*dbstore.DBStore, *routes.Routes) {
 	h := handlers.NewHandlers(s, fs)
 	rt := routes.NewRoutes(h)
 
-	return dbs, rt
+	return dbs, s, rt
 }
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"orijinplus/app/models"
+	"orijinplus/app/services"
+	"orijinplus/utils/logger"
+	"time"
+)
+
+// startJobs runs the background jobs of the application until the context is done
+func startJobs(ctx context.Context, s *services.Services) {
+	go lotExpiryJob(ctx, s.LotService, models.LotExpiryInterval)
+}
+
+// lotExpiryJob flags the lots which reached their expiry date when the server starts and then at every interval
+func lotExpiryJob(ctx context.Context, s *services.LotService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		lots, err := s.FlagExpired(ctx)
+		if err != nil {
+			logger.Info(fmt.Sprintf("Lot expiry job failed: %s", err.Message))
+		} else if len(lots) > 0 {
+			logger.Info(fmt.Sprintf("Lot expiry job flagged %d expired lots", len(lots)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package server
 
 import (
+	"context"
 	"log"
+	"orijinplus/app/api/routes"
+	"orijinplus/app/services"
 	"orijinplus/config"
 	"orijinplus/settings/cloud"
 	"orijinplus/settings/database/postgres"
+	"orijinplus/utils/ratelimit"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func StartApplication(conf config.Config) {
@@ -19,6 +26,18 @@ func StartApplication(conf config.Config) {
 
 	awsSession := cloud.NewAWSSession()
 
+	// Labels lead to the default verification site unless another one is configured
+	if conf.Server.VerifyBaseURL != "" {
+		services.VerifyBaseURL = conf.Server.VerifyBaseURL
+	}
+
+	// Forwarding headers are ignored unless the load balancers sending them are configured
+	proxies, err := ratelimit.ParseProxies(conf.Server.TrustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
+	routes.TrustedProxies = proxies
+
 	c := &config.Clients{
 		PostgresConn: postgresConn,
 		AWSSession:   awsSession,
@@ -26,5 +45,10 @@ func StartApplication(conf config.Config) {
 
 	restServer := NewRestServer(c)
 
-	restServer.Start(conf.Server.Address)
+	// Background jobs run until the server shuts down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	startJobs(ctx, restServer.Services)
+
+	restServer.Start(ctx, conf.Server.Address)
 }
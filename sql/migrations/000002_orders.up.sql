@@ -0,0 +1,47 @@
+BEGIN;
+-- SKUs
+CREATE TABLE "skus" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "name" varchar NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "is_archived" boolean NOT NULL DEFAULT FALSE,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Orders
+CREATE TABLE "orders" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'draft',
+  "notes" text NOT NULL DEFAULT '',
+  "buyer_organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "is_archived" boolean NOT NULL DEFAULT FALSE,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE TABLE "order_items" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "order_id" bigint NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  "sku_id" bigint NOT NULL REFERENCES skus (id),
+  "quantity" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("order_id", "sku_id")
+);
+CREATE TABLE "order_allocations" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "order_id" bigint NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  "pallet_id" bigint UNIQUE NOT NULL REFERENCES pallets (id),
+  "allocated_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
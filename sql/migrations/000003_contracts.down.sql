@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE orders DROP COLUMN IF EXISTS contract_id;
+DROP TABLE IF EXISTS contract_documents;
+DROP TABLE IF EXISTS contracts;
+COMMIT;
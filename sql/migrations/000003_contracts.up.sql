@@ -0,0 +1,37 @@
+BEGIN;
+-- Contracts
+CREATE TABLE "contracts" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "title" varchar NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "buyer_organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "start_date" timestamptz NOT NULL,
+  "end_date" timestamptz NOT NULL,
+  "pricing_terms" text NOT NULL DEFAULT '',
+  "volume_terms" text NOT NULL DEFAULT '',
+  "supplier_approved_by_id" bigint REFERENCES users (id),
+  "supplier_approved_at" timestamptz,
+  "buyer_approved_by_id" bigint REFERENCES users (id),
+  "buyer_approved_at" timestamptz,
+  "is_archived" boolean NOT NULL DEFAULT FALSE,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK ("start_date" < "end_date")
+);
+CREATE TABLE "contract_documents" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "contract_id" bigint NOT NULL REFERENCES contracts (id) ON DELETE CASCADE,
+  "name" text NOT NULL,
+  "url" text NOT NULL,
+  "uploaded_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Orders are placed against a contract
+ALTER TABLE "orders" ADD COLUMN "contract_id" bigint REFERENCES contracts (id);
+
+COMMIT;
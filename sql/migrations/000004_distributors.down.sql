@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE orders DROP COLUMN IF EXISTS distributor_id;
+DROP TABLE IF EXISTS distributors;
+DROP TABLE IF EXISTS addresses;
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+-- Addresses
+CREATE TABLE "addresses" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "user_id" bigint REFERENCES users (id),
+  "tag" varchar NOT NULL,
+  "line_1" varchar NOT NULL,
+  "line_2" varchar NOT NULL,
+  "line_3" varchar,
+  "city" varchar NOT NULL,
+  "state" varchar NOT NULL,
+  "country" varchar NOT NULL,
+  "pincode" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Distributors
+CREATE TABLE "distributors" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "name" varchar NOT NULL,
+  "contact_name" varchar NOT NULL DEFAULT '',
+  "email" varchar NOT NULL DEFAULT '',
+  "phone" varchar NOT NULL DEFAULT '',
+  "regions" text[] NOT NULL DEFAULT '{}',
+  "address_id" bigint REFERENCES addresses (id),
+  "is_archived" boolean NOT NULL DEFAULT FALSE,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Orders can be routed through a distributor
+ALTER TABLE "orders" ADD COLUMN "distributor_id" bigint REFERENCES distributors (id);
+
+COMMIT;
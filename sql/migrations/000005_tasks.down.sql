@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS task_comments;
+DROP TABLE IF EXISTS tasks;
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+-- Tasks
+CREATE TABLE "tasks" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "title" varchar NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'open',
+  "due_date" timestamptz,
+  "assignee_user_id" bigint REFERENCES users (id),
+  "assignee_role_id" bigint REFERENCES roles (id),
+  "container_id" bigint REFERENCES containers (id),
+  "pallet_id" bigint REFERENCES pallets (id),
+  "completed_at" timestamptz,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "tasks" ("assignee_user_id");
+CREATE INDEX ON "tasks" ("assignee_role_id");
+CREATE TABLE "task_comments" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "task_id" bigint NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+  "user_id" bigint NOT NULL REFERENCES users (id),
+  "body" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
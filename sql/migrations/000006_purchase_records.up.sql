@@ -0,0 +1,23 @@
+BEGIN;
+-- Purchase records
+CREATE TABLE "purchase_records" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "product_uid" uuid NOT NULL,
+  "buyer_email" varchar,
+  "buyer_phone" varchar,
+  "purchase_token" varchar UNIQUE NOT NULL,
+  "retailer" varchar NOT NULL DEFAULT '',
+  "points" bigint NOT NULL DEFAULT 0,
+  "redeemed_by_id" bigint REFERENCES users (id),
+  "redeemed_at" timestamptz,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "purchase_records" (lower("buyer_email"));
+CREATE INDEX ON "purchase_records" ("buyer_phone");
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+DROP TABLE IF EXISTS consumer_order_items;
+DROP TABLE IF EXISTS consumer_orders;
+ALTER TABLE skus DROP COLUMN IF EXISTS price;
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+-- SKU prices in minor currency units
+ALTER TABLE "skus" ADD COLUMN "price" bigint NOT NULL DEFAULT 0;
+
+-- Consumer orders
+CREATE TABLE "consumer_orders" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "customer_id" bigint NOT NULL REFERENCES users (id),
+  "address_id" bigint NOT NULL REFERENCES addresses (id),
+  "total" bigint NOT NULL DEFAULT 0,
+  "wallet_points" bigint NOT NULL DEFAULT 0,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "consumer_orders" ("customer_id");
+CREATE TABLE "consumer_order_items" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "consumer_order_id" bigint NOT NULL REFERENCES consumer_orders (id) ON DELETE CASCADE,
+  "sku_id" bigint NOT NULL REFERENCES skus (id),
+  "quantity" bigint NOT NULL,
+  "unit_price" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+-- Track actions, append-only event log of traced items
+CREATE TABLE "track_actions" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "action" varchar NOT NULL,
+  "container_id" bigint REFERENCES containers (id),
+  "pallet_id" bigint REFERENCES pallets (id),
+  "location" varchar NOT NULL DEFAULT '',
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "actor_id" bigint NOT NULL REFERENCES users (id),
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "occurred_at" timestamptz NOT NULL DEFAULT NOW(),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK (num_nonnulls("container_id", "pallet_id") = 1)
+);
+CREATE INDEX ON "track_actions" ("container_id", "occurred_at");
+CREATE INDEX ON "track_actions" ("pallet_id", "occurred_at");
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS referrals;
+DROP TABLE IF EXISTS referral_rules;
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+-- Referral reward rules, the latest active rule applies to new referrals
+CREATE TABLE "referral_rules" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "referrer_points" bigint NOT NULL DEFAULT 0,
+  "referee_points" bigint NOT NULL DEFAULT 0,
+  "is_active" boolean NOT NULL DEFAULT TRUE,
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+-- Referrals
+CREATE TABLE "referrals" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "referrer_id" bigint NOT NULL REFERENCES users (id),
+  "referee_id" bigint UNIQUE NOT NULL REFERENCES users (id),
+  "referral_code" varchar NOT NULL,
+  "referral_rule_id" bigint REFERENCES referral_rules (id),
+  "referrer_points" bigint NOT NULL DEFAULT 0,
+  "referee_points" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "referrals" ("referrer_id");
+-- Profiles created before referrals get a code like the ones given on registration, ten random digits
+DO $$
+DECLARE
+  profile RECORD;
+  code varchar;
+BEGIN
+  FOR profile IN SELECT user_id FROM profiles WHERE referral_code IS NULL LOOP
+    LOOP
+      code := lpad(floor(random() * 10000000000)::bigint::text, 10, '0');
+      EXIT WHEN NOT EXISTS (SELECT 1 FROM profiles WHERE referral_code = code);
+    END LOOP;
+    UPDATE profiles SET referral_code = code WHERE user_id = profile.user_id;
+  END LOOP;
+END $$;
+
+COMMIT;
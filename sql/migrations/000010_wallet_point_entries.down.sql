@@ -0,0 +1,7 @@
+BEGIN;
+DROP TABLE IF EXISTS "wallet_point_entries";
+ALTER TABLE "profiles" DROP CONSTRAINT IF EXISTS "profiles_wallet_points_check";
+CREATE SEQUENCE IF NOT EXISTS "profiles_wallet_points_seq" OWNED BY "profiles"."wallet_points";
+SELECT setval('profiles_wallet_points_seq', COALESCE(MAX("wallet_points"), 0) + 1, false) FROM "profiles";
+ALTER TABLE "profiles" ALTER COLUMN "wallet_points" SET DEFAULT nextval('profiles_wallet_points_seq');
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+-- The wallet balance is a cache of the ledger and is no longer a sequence
+ALTER TABLE "profiles" ALTER COLUMN "wallet_points" DROP DEFAULT;
+DROP SEQUENCE IF EXISTS "profiles_wallet_points_seq";
+ALTER TABLE "profiles" ALTER COLUMN "wallet_points" SET DEFAULT 0;
+ALTER TABLE "profiles" ADD CONSTRAINT "profiles_wallet_points_check" CHECK ("wallet_points" >= 0);
+-- Append-only ledger of wallet points, credits keep track of the points not yet spent or expired
+CREATE TABLE "wallet_point_entries" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "user_id" bigint NOT NULL REFERENCES users (id),
+  "entry_type" varchar NOT NULL CHECK ("entry_type" IN ('earn', 'redeem', 'expire', 'adjust')),
+  "points" bigint NOT NULL CHECK ("points" <> 0),
+  "balance" bigint NOT NULL CHECK ("balance" >= 0),
+  "remaining" bigint NOT NULL DEFAULT 0 CHECK ("remaining" >= 0),
+  "reason" varchar NOT NULL,
+  "reference_type" varchar,
+  "reference_id" bigint,
+  "expires_at" timestamptz,
+  "created_by_id" bigint REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "wallet_point_entries" ("user_id", "created_at");
+CREATE INDEX ON "wallet_point_entries" ("expires_at") WHERE "remaining" > 0;
+-- Opening balances so that the ledger matches the existing wallets
+INSERT INTO "wallet_point_entries" ("uid", "user_id", "entry_type", "points", "balance", "remaining", "reason")
+SELECT gen_random_uuid(), "user_id", 'adjust', "wallet_points", "wallet_points", "wallet_points", 'Opening balance'
+FROM "profiles"
+WHERE "wallet_points" > 0;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+DROP INDEX IF EXISTS "addresses_organization_default_idx";
+DROP INDEX IF EXISTS "addresses_user_default_idx";
+ALTER TABLE "addresses" DROP CONSTRAINT IF EXISTS "addresses_owner_check";
+ALTER TABLE "addresses" DROP COLUMN IF EXISTS "is_default";
+ALTER TABLE "addresses" DROP COLUMN IF EXISTS "organization_id";
+COMMIT;
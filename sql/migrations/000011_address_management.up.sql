@@ -0,0 +1,12 @@
+BEGIN;
+-- Addresses belong to a customer, an organization or neither (distributor addresses)
+ALTER TABLE "addresses" ADD COLUMN "organization_id" bigint REFERENCES organizations (id);
+ALTER TABLE "addresses" ADD COLUMN "is_default" boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE "addresses" ADD CONSTRAINT "addresses_owner_check" CHECK ("user_id" IS NULL OR "organization_id" IS NULL);
+CREATE INDEX ON "addresses" ("user_id");
+CREATE INDEX ON "addresses" ("organization_id");
+-- A single default address per owner
+CREATE UNIQUE INDEX "addresses_user_default_idx" ON "addresses" ("user_id") WHERE "is_default";
+CREATE UNIQUE INDEX "addresses_organization_default_idx" ON "addresses" ("organization_id") WHERE "is_default";
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS "organization_code_formats";
+DROP TABLE IF EXISTS "code_counters";
+COMMIT;
@@ -0,0 +1,51 @@
+BEGIN;
+-- Counters handing out the numbers of entity codes, rows are locked by the creating transaction
+-- so concurrent creates wait for each other instead of colliding on the unique code.
+-- Counters are kept per code prefix so a prefix passed on to another organization continues
+-- its numbers, yearly counters use the year as period and the others period 0
+CREATE TABLE "code_counters" (
+  "entity" text NOT NULL,
+  "prefix" text NOT NULL,
+  "period" int NOT NULL DEFAULT 0,
+  "value" bigint NOT NULL DEFAULT 0 CHECK ("value" >= 0),
+  PRIMARY KEY ("entity", "prefix", "period")
+);
+-- Code formats configured by organizations, custom codes are written as PREFIX-[YEAR-]NUMBER
+CREATE TABLE "organization_code_formats" (
+  "id" bigserial PRIMARY KEY NOT NULL,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "entity" text NOT NULL,
+  "prefix" text NOT NULL CHECK ("prefix" ~ '^[A-Z0-9]{1,10}$'),
+  "padding" int NOT NULL DEFAULT 5 CHECK ("padding" BETWEEN 1 AND 12),
+  "reset_yearly" boolean NOT NULL DEFAULT FALSE,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("organization_id", "entity"),
+  -- Prefixes are unique per entity so codes of different organizations never collide
+  UNIQUE ("entity", "prefix")
+);
+-- Continue the global counters from the codes handed out so far
+INSERT INTO "code_counters" ("entity", "prefix", "value")
+SELECT 'organization', 'ORG', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "organizations"
+UNION ALL
+SELECT 'role', 'ROLE', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "roles"
+UNION ALL
+SELECT 'container', 'CNT', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "containers"
+UNION ALL
+SELECT 'pallet', 'PLT', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "pallets"
+UNION ALL
+SELECT 'sku', 'SKU', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "skus"
+UNION ALL
+SELECT 'order', 'ORD', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "orders"
+UNION ALL
+SELECT 'contract', 'CON', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "contracts"
+UNION ALL
+SELECT 'distributor', 'DST', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "distributors"
+UNION ALL
+SELECT 'task', 'TSK', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "tasks"
+UNION ALL
+SELECT 'purchase_record', 'PUR', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "purchase_records"
+UNION ALL
+SELECT 'consumer_order', 'COR', COALESCE(MAX(SUBSTRING("code" FROM '[0-9]+$')::bigint), 0) FROM "consumer_orders";
+
+COMMIT;
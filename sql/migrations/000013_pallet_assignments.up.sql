@@ -0,0 +1,22 @@
+BEGIN;
+-- Pallet assignments, custody history of the containers a pallet has been loaded in
+CREATE TABLE "pallet_assignments" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "pallet_id" bigint NOT NULL REFERENCES pallets (id),
+  "container_id" bigint NOT NULL REFERENCES containers (id),
+  "loaded_by_id" bigint NOT NULL REFERENCES users (id),
+  "loaded_at" timestamptz NOT NULL DEFAULT NOW(),
+  "unloaded_by_id" bigint REFERENCES users (id),
+  "unloaded_at" timestamptz,
+  CHECK (("unloaded_at" IS NULL) = ("unloaded_by_id" IS NULL))
+);
+CREATE INDEX ON "pallet_assignments" ("pallet_id", "loaded_at");
+CREATE INDEX ON "pallet_assignments" ("container_id", "loaded_at");
+-- A pallet is in a single container at a time
+CREATE UNIQUE INDEX "pallet_assignments_open_idx" ON "pallet_assignments" ("pallet_id") WHERE "unloaded_at" IS NULL;
+-- Open the assignments of the pallets already loaded
+INSERT INTO "pallet_assignments" ("pallet_id", "container_id", "loaded_by_id", "loaded_at")
+SELECT "id", "container_id", "created_by_id", "created_at" FROM "pallets"
+WHERE "container_id" IS NOT NULL;
+
+COMMIT;
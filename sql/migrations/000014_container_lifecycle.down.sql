@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS "container_transitions";
+ALTER TABLE "containers" DROP COLUMN IF EXISTS "status";
+COMMIT;
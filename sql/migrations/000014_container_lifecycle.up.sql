@@ -0,0 +1,16 @@
+BEGIN;
+-- Container lifecycle, open -> packing -> sealed -> in_transit -> arrived -> unpacked
+ALTER TABLE "containers" ADD COLUMN "status" varchar NOT NULL DEFAULT 'open'
+  CHECK ("status" IN ('open', 'packing', 'sealed', 'in_transit', 'arrived', 'unpacked'));
+-- Container transitions, append-only history of the status changes of a container
+CREATE TABLE "container_transitions" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "container_id" bigint NOT NULL REFERENCES containers (id),
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "actor_id" bigint NOT NULL REFERENCES users (id),
+  "occurred_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "container_transitions" ("container_id", "occurred_at");
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+DELETE FROM "code_counters" WHERE "entity" = 'shipment';
+DROP TABLE IF EXISTS "shipment_containers";
+DROP TABLE IF EXISTS "shipments";
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+-- Shipments, containers travelling together from an origin to a destination
+CREATE TABLE "shipments" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'planned'
+    CHECK ("status" IN ('planned', 'departed', 'arrived', 'cancelled')),
+  "origin_address_id" bigint NOT NULL REFERENCES addresses (id),
+  "destination_address_id" bigint NOT NULL REFERENCES addresses (id),
+  "carrier" text NOT NULL DEFAULT '',
+  "transport_reference" text NOT NULL DEFAULT '', -- vessel voyage or flight number
+  "etd" timestamptz,
+  "eta" timestamptz,
+  "departed_at" timestamptz,
+  "arrived_at" timestamptz,
+  "notes" text NOT NULL DEFAULT '',
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK ("eta" IS NULL OR "etd" IS NULL OR "eta" >= "etd")
+);
+CREATE INDEX ON "shipments" ("organization_id", "status");
+CREATE TABLE "shipment_containers" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "shipment_id" bigint NOT NULL REFERENCES shipments (id),
+  "container_id" bigint NOT NULL REFERENCES containers (id),
+  "added_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("shipment_id", "container_id")
+);
+CREATE INDEX ON "shipment_containers" ("container_id");
+INSERT INTO "code_counters" ("entity", "prefix", "value") VALUES ('shipment', 'SHP', 0);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+DELETE FROM "code_counters" WHERE "entity" = 'warehouse';
+DROP TABLE IF EXISTS "location_moves";
+ALTER TABLE "containers" DROP COLUMN IF EXISTS "location_id";
+ALTER TABLE "pallets" DROP COLUMN IF EXISTS "location_id";
+DROP TABLE IF EXISTS "locations";
+DROP TABLE IF EXISTS "warehouses";
+COMMIT;
@@ -0,0 +1,49 @@
+BEGIN;
+-- Warehouses, sites of an organization where containers and pallets are stored
+CREATE TABLE "warehouses" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "name" text NOT NULL,
+  "address_id" bigint REFERENCES addresses (id),
+  "is_archived" boolean NOT NULL DEFAULT false,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX ON "warehouses" ("organization_id");
+-- Locations inside a warehouse, zones hold aisles, aisles hold racks and racks hold bins
+CREATE TABLE "locations" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text NOT NULL,
+  "name" text NOT NULL DEFAULT '',
+  "kind" varchar NOT NULL CHECK ("kind" IN ('zone', 'aisle', 'rack', 'bin')),
+  "warehouse_id" bigint NOT NULL REFERENCES warehouses (id),
+  "parent_id" bigint REFERENCES locations (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("warehouse_id", "code")
+);
+CREATE INDEX ON "locations" ("parent_id");
+ALTER TABLE "pallets" ADD COLUMN "location_id" bigint REFERENCES locations (id);
+ALTER TABLE "containers" ADD COLUMN "location_id" bigint REFERENCES locations (id);
+CREATE INDEX ON "pallets" ("location_id");
+CREATE INDEX ON "containers" ("location_id");
+-- Location moves, append-only history of where a pallet or a container was put
+CREATE TABLE "location_moves" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "pallet_id" bigint REFERENCES pallets (id),
+  "container_id" bigint REFERENCES containers (id),
+  "from_location_id" bigint REFERENCES locations (id),
+  "to_location_id" bigint REFERENCES locations (id),
+  "actor_id" bigint NOT NULL REFERENCES users (id),
+  "moved_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK (("pallet_id" IS NULL) <> ("container_id" IS NULL))
+);
+CREATE INDEX ON "location_moves" ("pallet_id", "moved_at");
+CREATE INDEX ON "location_moves" ("container_id", "moved_at");
+INSERT INTO "code_counters" ("entity", "prefix", "value") VALUES ('warehouse', 'WHS', 0);
+
+COMMIT;
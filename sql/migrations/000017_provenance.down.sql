@@ -0,0 +1,5 @@
+BEGIN;
+DROP TABLE IF EXISTS "organization_provenance_settings";
+ALTER TABLE "track_actions" DROP COLUMN IF EXISTS "anchored_at";
+ALTER TABLE "track_actions" DROP COLUMN IF EXISTS "anchor_tx_hash";
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+-- Blockchain anchoring of track actions, empty until the action is written on chain
+ALTER TABLE "track_actions" ADD COLUMN "anchor_tx_hash" varchar;
+ALTER TABLE "track_actions" ADD COLUMN "anchored_at" timestamptz;
+
+-- What an organization shows on the public provenance view of its scanned items,
+-- organizations without settings use the column defaults, nothing is public until an organization opts in
+CREATE TABLE "organization_provenance_settings" (
+  "organization_id" bigint NOT NULL PRIMARY KEY REFERENCES organizations (id),
+  "is_public" boolean NOT NULL DEFAULT false,
+  "show_organization" boolean NOT NULL DEFAULT true,
+  "show_description" boolean NOT NULL DEFAULT true,
+  "show_locations" boolean NOT NULL DEFAULT false,
+  "show_event_details" boolean NOT NULL DEFAULT false,
+  "show_anchoring" boolean NOT NULL DEFAULT true,
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
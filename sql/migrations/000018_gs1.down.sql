@@ -0,0 +1,7 @@
+BEGIN;
+DELETE FROM "code_counters" WHERE "entity" = 'sscc';
+ALTER TABLE "skus" DROP COLUMN IF EXISTS "gtin";
+ALTER TABLE "containers" DROP COLUMN IF EXISTS "sscc";
+ALTER TABLE "pallets" DROP COLUMN IF EXISTS "sscc";
+DROP TABLE IF EXISTS "organization_gs1_settings";
+COMMIT;
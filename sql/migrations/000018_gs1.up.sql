@@ -0,0 +1,21 @@
+BEGIN;
+-- GS1 company prefix of an organization, SSCCs of its pallets and containers are built from it
+CREATE TABLE "organization_gs1_settings" (
+  "organization_id" bigint NOT NULL PRIMARY KEY REFERENCES organizations (id),
+  "company_prefix" varchar(12) UNIQUE NOT NULL,
+  "extension_digit" smallint NOT NULL DEFAULT 0 CHECK ("extension_digit" BETWEEN 0 AND 9),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Serial shipping container codes, kept alongside the codes of pallets and containers
+ALTER TABLE "pallets" ADD COLUMN "sscc" varchar(18) UNIQUE;
+ALTER TABLE "containers" ADD COLUMN "sscc" varchar(18) UNIQUE;
+
+-- GTINs of skus, stored as GTIN-14. A GTIN is unique within an organization, the brand owner
+-- and the distributors selling its products each register it
+ALTER TABLE "skus" ADD COLUMN "gtin" varchar(14);
+ALTER TABLE "skus" ADD UNIQUE ("organization_id", "gtin");
+CREATE INDEX ON "skus" ("gtin");
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+-- EPCIS events captured from supply chain partners, kept as sent once normalised to EPCIS 2.0 JSON-LD.
+-- Events are captured once per organization, a repeated event id is skipped. Events captured without
+-- an organization share one scope
+CREATE TABLE "epcis_events" (
+  "id" bigserial PRIMARY KEY,
+  "event_id" text NOT NULL,
+  "event_type" text NOT NULL,
+  "event_time" timestamptz NOT NULL,
+  "document" jsonb NOT NULL DEFAULT '{}',
+  "organization_id" bigint REFERENCES organizations (id),
+  "captured_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX ON "epcis_events" ((COALESCE("organization_id", 0)), "event_id");
+CREATE INDEX ON "epcis_events" ("organization_id", "event_time");
+
+COMMIT;
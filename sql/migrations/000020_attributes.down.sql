@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE "containers" DROP COLUMN IF EXISTS "attributes";
+ALTER TABLE "pallets" DROP COLUMN IF EXISTS "attributes";
+DROP TABLE IF EXISTS "organization_attribute_definitions";
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+-- Custom attributes an organization defines for its pallets and containers
+CREATE TABLE "organization_attribute_definitions" (
+  "id" bigserial PRIMARY KEY,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "entity" varchar(20) NOT NULL CHECK ("entity" IN ('pallet', 'container')),
+  "key" varchar(40) NOT NULL,
+  "label" varchar(100) NOT NULL DEFAULT '',
+  "type" varchar(10) NOT NULL CHECK ("type" IN ('string', 'number', 'boolean', 'date', 'enum')),
+  "required" boolean NOT NULL DEFAULT false,
+  "enum_values" text[] NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("organization_id", "entity", "key")
+);
+
+-- Values of the custom attributes, keyed by the attribute key
+ALTER TABLE "pallets" ADD COLUMN "attributes" jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE "containers" ADD COLUMN "attributes" jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX ON "pallets" USING GIN ("attributes" jsonb_path_ops);
+CREATE INDEX ON "containers" USING GIN ("attributes" jsonb_path_ops);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+-- Files uploaded to the filestore, attached to the organization, container or pallet they belong to
+CREATE TABLE "files" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar(300) UNIQUE NOT NULL,
+  "original_name" varchar(255) NOT NULL,
+  "url" text NOT NULL,
+  "mime_type" varchar(100) NOT NULL,
+  "size" bigint NOT NULL,
+  "checksum" varchar(64) NOT NULL,
+  "owner_type" varchar(20) CHECK ("owner_type" IN ('organization', 'container', 'pallet')),
+  "owner_id" bigint,
+  "organization_id" bigint REFERENCES organizations (id),
+  "uploaded_by_id" bigint NOT NULL REFERENCES users (id),
+  "attached_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK (("owner_type" IS NULL) = ("owner_id" IS NULL))
+);
+
+CREATE INDEX ON "files" ("owner_type", "owner_id");
+CREATE INDEX ON "files" ("organization_id");
+
+COMMIT;
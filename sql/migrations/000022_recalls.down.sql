@@ -0,0 +1,6 @@
+BEGIN;
+DELETE FROM "code_counters" WHERE "entity" = 'recall';
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "recall_items";
+DROP TABLE IF EXISTS "recalls";
+COMMIT;
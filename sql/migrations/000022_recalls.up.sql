@@ -0,0 +1,53 @@
+BEGIN;
+-- Product recalls, scoped by a SKU or by the UIDs of pallets, containers and products
+CREATE TABLE "recalls" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text UNIQUE NOT NULL,
+  "title" text NOT NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "scope" varchar NOT NULL CHECK ("scope" IN ('sku', 'uids')),
+  "sku_id" bigint REFERENCES skus (id),
+  "uids" text[] NOT NULL DEFAULT '{}',
+  "status" varchar NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'closed')),
+  "closed_at" timestamptz,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK (("scope" = 'sku') = ("sku_id" IS NOT NULL))
+);
+CREATE INDEX ON "recalls" ("organization_id", "status");
+-- Items a recall resolved to, an item stays recalled until it is resolved
+CREATE TABLE "recall_items" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "recall_id" bigint NOT NULL REFERENCES recalls (id) ON DELETE CASCADE,
+  "item_type" varchar NOT NULL CHECK ("item_type" IN ('container', 'pallet', 'order', 'consumer_order', 'purchase_record')),
+  "item_id" bigint NOT NULL,
+  "organization_id" bigint REFERENCES organizations (id),
+  "customer_id" bigint REFERENCES users (id),
+  "resolved_by_id" bigint REFERENCES users (id),
+  "resolved_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("recall_id", "item_type", "item_id")
+);
+CREATE INDEX ON "recall_items" ("item_type", "item_id");
+-- Notifications for the members of an organization or for a single user
+CREATE TABLE "notifications" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "organization_id" bigint REFERENCES organizations (id),
+  "user_id" bigint REFERENCES users (id),
+  "kind" varchar NOT NULL,
+  "subject" text NOT NULL,
+  "body" text NOT NULL DEFAULT '',
+  "entity_type" varchar,
+  "entity_id" bigint,
+  "read_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CHECK (("organization_id" IS NULL) <> ("user_id" IS NULL))
+);
+CREATE INDEX ON "notifications" ("organization_id", "created_at");
+CREATE INDEX ON "notifications" ("user_id", "created_at");
+INSERT INTO "code_counters" ("entity", "prefix", "value") VALUES ('recall', 'RCL', 0);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+DELETE FROM "recalls" WHERE "scope" = 'lot';
+ALTER TABLE "recalls" DROP COLUMN IF EXISTS "lot_id";
+ALTER TABLE "recalls" DROP CONSTRAINT "recalls_scope_check";
+ALTER TABLE "recalls" ADD CONSTRAINT "recalls_scope_check" CHECK ("scope" IN ('sku', 'uids'));
+ALTER TABLE "pallets" DROP COLUMN IF EXISTS "lot_id";
+DROP TABLE IF EXISTS "lots";
+COMMIT;
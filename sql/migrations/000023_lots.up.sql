@@ -0,0 +1,28 @@
+BEGIN;
+-- Production lots of a SKU, expired lots are flagged by the expiry job
+CREATE TABLE "lots" (
+  "id" bigserial NOT NULL PRIMARY KEY,
+  "uid" uuid UNIQUE NOT NULL,
+  "code" text NOT NULL,
+  "sku_id" bigint NOT NULL REFERENCES skus (id),
+  "production_date" timestamptz NOT NULL,
+  "expiry_date" timestamptz,
+  "is_expired" boolean NOT NULL DEFAULT FALSE,
+  "expired_at" timestamptz,
+  "organization_id" bigint NOT NULL REFERENCES organizations (id),
+  "created_by_id" bigint NOT NULL REFERENCES users (id),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  UNIQUE ("sku_id", "code"),
+  CHECK ("expiry_date" IS NULL OR "production_date" < "expiry_date")
+);
+CREATE INDEX ON "lots" ("organization_id", "expiry_date") WHERE NOT "is_expired";
+ALTER TABLE "pallets" ADD COLUMN "lot_id" bigint REFERENCES lots (id);
+CREATE INDEX ON "pallets" ("lot_id");
+-- Recalls can be scoped by a lot
+ALTER TABLE "recalls" ADD COLUMN "lot_id" bigint REFERENCES lots (id);
+ALTER TABLE "recalls" DROP CONSTRAINT "recalls_scope_check";
+ALTER TABLE "recalls" ADD CONSTRAINT "recalls_scope_check" CHECK ("scope" IN ('sku', 'lot', 'uids'));
+ALTER TABLE "recalls" ADD CHECK (("scope" = 'lot') = ("lot_id" IS NOT NULL));
+
+COMMIT;
@@ -0,0 +1,160 @@
+package barcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	bc "github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+// linearHeight is the height of linear barcodes in modules
+const linearHeight = 40
+
+// Symbol is an encoded barcode as a grid of dark and light modules,
+// with the quiet zone scanners need kept around it
+type Symbol struct {
+	Width  int
+	Height int
+	QuietX int
+	QuietY int
+	dark   []bool
+}
+
+// Rect is a run of dark modules, in modules from the top left corner of the symbol
+type Rect struct {
+	X, Y, W, H int
+}
+
+// QR encodes content as a QR code with medium error correction
+func QR(content string) (*Symbol, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+	return fromImage(code, 1, 4, 4), nil
+}
+
+// Code128 encodes content as a Code 128 barcode
+func Code128(content string) (*Symbol, error) {
+	code, err := code128.Encode(content)
+	if err != nil {
+		return nil, err
+	}
+	return fromImage(code, linearHeight, 10, 0), nil
+}
+
+// GS1128 encodes an element string, a GS1 application identifier and its fixed length value,
+// as a GS1-128 barcode, a Code 128 barcode starting with FNC1
+func GS1128(ai string, value string) (*Symbol, error) {
+	code, err := code128.Encode(string(code128.FNC1) + ai + value)
+	if err != nil {
+		return nil, err
+	}
+	return fromImage(code, linearHeight, 10, 0), nil
+}
+
+// fromImage reads the modules of a barcode, repeating each row of linear barcodes to give them height
+func fromImage(code bc.Barcode, repeat int, quietX int, quietY int) *Symbol {
+	bounds := code.Bounds()
+	s := &Symbol{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy() * repeat,
+		QuietX: quietX,
+		QuietY: quietY,
+	}
+	s.dark = make([]bool, s.Width*s.Height)
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			gray := color.GrayModel.Convert(code.At(bounds.Min.X+x, bounds.Min.Y+y/repeat)).(color.Gray)
+			s.dark[y*s.Width+x] = gray.Y < 128
+		}
+	}
+	return s
+}
+
+// Dark reports whether the module at x, y is dark
+func (s *Symbol) Dark(x, y int) bool {
+	return s.dark[y*s.Width+x]
+}
+
+// TotalWidth is the width of the symbol with its quiet zone, in modules
+func (s *Symbol) TotalWidth() int {
+	return s.Width + 2*s.QuietX
+}
+
+// TotalHeight is the height of the symbol with its quiet zone, in modules
+func (s *Symbol) TotalHeight() int {
+	return s.Height + 2*s.QuietY
+}
+
+// Rects gets the dark modules as rectangles, runs on a row are joined and
+// identical runs on consecutive rows are stacked, so bars of linear barcodes are single rectangles
+func (s *Symbol) Rects() []Rect {
+	rects := []Rect{}
+	open := map[[2]int]int{} // x and width of the runs of the previous row to their rectangle
+	for y := 0; y < s.Height; y++ {
+		next := map[[2]int]int{}
+		for x := 0; x < s.Width; {
+			if !s.Dark(x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < s.Width && s.Dark(x, y) {
+				x++
+			}
+			key := [2]int{start, x - start}
+			if i, ok := open[key]; ok {
+				rects[i].H++
+				next[key] = i
+			} else {
+				rects = append(rects, Rect{X: start, Y: y, W: x - start, H: 1})
+				next[key] = len(rects) - 1
+			}
+		}
+		open = next
+	}
+	return rects
+}
+
+// PNG renders the symbol with its quiet zone, each module taking scale pixels
+func (s *Symbol) PNG(scale int) ([]byte, error) {
+	if scale <= 0 {
+		return nil, fmt.Errorf("scale must be greater than zero")
+	}
+	img := image.NewGray(image.Rect(0, 0, s.TotalWidth()*scale, s.TotalHeight()*scale))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for _, r := range s.Rects() {
+		for y := (s.QuietY + r.Y) * scale; y < (s.QuietY+r.Y+r.H)*scale; y++ {
+			for x := (s.QuietX + r.X) * scale; x < (s.QuietX+r.X+r.W)*scale; x++ {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the symbol with its quiet zone, one user unit per module so it scales to any size
+func (s *Symbol) SVG() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, s.TotalWidth(), s.TotalHeight())
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, s.TotalWidth(), s.TotalHeight())
+	buf.WriteString(`<path fill="#000" d="`)
+	for _, r := range s.Rects() {
+		fmt.Fprintf(&buf, "M%d %dh%dv%dh-%dz", s.QuietX+r.X, s.QuietY+r.Y, r.W, r.H, r.W)
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes()
+}
@@ -0,0 +1,159 @@
+package barcode
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// symbol builds a symbol from rows of modules, # being dark
+func symbol(rows ...string) *Symbol {
+	s := &Symbol{Height: len(rows)}
+	if len(rows) > 0 {
+		s.Width = len(rows[0])
+	}
+	for _, row := range rows {
+		for _, module := range row {
+			s.dark = append(s.dark, module == '#')
+		}
+	}
+	return s
+}
+
+type rectsResult struct {
+	rows     []string
+	expected []Rect
+}
+
+var rectsResults = []rectsResult{
+	{[]string{}, []Rect{}},
+	{[]string{"...."}, []Rect{}},
+	{[]string{"##.#"}, []Rect{{0, 0, 2, 1}, {3, 0, 1, 1}}},
+	// Bars of linear barcodes are stacked into one rectangle
+	{[]string{
+		"#.##.",
+		"#.##.",
+		"#.##.",
+	}, []Rect{{0, 0, 1, 3}, {2, 0, 2, 3}}},
+	// Runs which change width start a new rectangle
+	{[]string{
+		"###",
+		"##.",
+		"##.",
+	}, []Rect{{0, 0, 3, 1}, {0, 1, 2, 2}}},
+	// Runs separated by a light row are not joined
+	{[]string{
+		"#.",
+		"..",
+		"#.",
+	}, []Rect{{0, 0, 1, 1}, {0, 2, 1, 1}}},
+}
+
+func TestRects(t *testing.T) {
+	for i, test := range rectsResults {
+		if rects := symbol(test.rows...).Rects(); !reflect.DeepEqual(rects, test.expected) {
+			t.Fatalf("Rects: case %d is %v, expected %v", i, rects, test.expected)
+		}
+	}
+}
+
+// covered checks the rectangles cover every dark module and no light one, without overlapping
+func covered(s *Symbol) bool {
+	count := make([]int, s.Width*s.Height)
+	for _, r := range s.Rects() {
+		for y := r.Y; y < r.Y+r.H; y++ {
+			for x := r.X; x < r.X+r.W; x++ {
+				count[y*s.Width+x]++
+			}
+		}
+	}
+	for i, dark := range s.dark {
+		if dark && count[i] != 1 || !dark && count[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEncode(t *testing.T) {
+	qr, err := QR("https://orijinplus.com.au/p/PAL-000001")
+	if err != nil {
+		t.Fatalf("QR: %s", err)
+	}
+	if qr.Width != qr.Height || qr.TotalWidth() != qr.Width+8 || qr.TotalHeight() != qr.Height+8 {
+		t.Fatalf("QR: %dx%d is not a square with a 4 module quiet zone", qr.TotalWidth(), qr.TotalHeight())
+	}
+	if !covered(qr) {
+		t.Fatalf("QR: rectangles do not cover the dark modules")
+	}
+
+	linear := map[string]func() (*Symbol, error){
+		"Code128": func() (*Symbol, error) { return Code128("PAL-000001") },
+		"GS1128":  func() (*Symbol, error) { return GS1128("00", "093123450000000012") },
+	}
+	for name, encode := range linear {
+		s, err := encode()
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if s.Height != linearHeight || s.TotalWidth() != s.Width+20 || s.TotalHeight() != linearHeight {
+			t.Fatalf("%s: %dx%d is not expected size", name, s.TotalWidth(), s.TotalHeight())
+		}
+		if !covered(s) {
+			t.Fatalf("%s: rectangles do not cover the dark modules", name)
+		}
+		for _, r := range s.Rects() {
+			if r.Y != 0 || r.H != linearHeight {
+				t.Fatalf("%s: bar %v is not a single rectangle", name, r)
+			}
+		}
+	}
+}
+
+func TestPNG(t *testing.T) {
+	s := symbol(
+		"#.",
+		".#",
+	)
+	s.QuietX, s.QuietY = 1, 1
+
+	if _, err := s.PNG(0); err == nil {
+		t.Fatalf("PNG: scale 0 is expected to be invalid")
+	}
+
+	b, err := s.PNG(2)
+	if err != nil {
+		t.Fatalf("PNG: %s", err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("PNG: %s", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 8 || bounds.Dy() != 8 {
+		t.Fatalf("PNG: %dx%d is not expected size", bounds.Dx(), bounds.Dy())
+	}
+	for _, pixel := range []struct {
+		x, y int
+		dark bool
+	}{{0, 0, false}, {2, 2, true}, {3, 3, true}, {4, 2, false}, {4, 4, true}, {7, 7, false}} {
+		r, _, _, _ := img.At(pixel.x, pixel.y).RGBA()
+		if (r == 0) != pixel.dark {
+			t.Fatalf("PNG: pixel %d,%d is not expected color", pixel.x, pixel.y)
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	s := symbol("#.##")
+	s.QuietX = 10
+
+	svg := string(s.SVG())
+	if !strings.Contains(svg, `viewBox="0 0 24 1"`) {
+		t.Fatalf("SVG: %s has the wrong view box", svg)
+	}
+	if !strings.Contains(svg, `d="M10 0h1v1h-1zM12 0h2v1h-2z"`) {
+		t.Fatalf("SVG: %s has the wrong path", svg)
+	}
+}
@@ -0,0 +1,323 @@
+package epcis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// Context is the JSON-LD context of EPCIS 2.0 documents
+const Context = "https://ref.gs1.org/standards/epcis/2.0.0/epcis-context.jsonld"
+
+// Namespace holds the fields added to the standard events, e.g. the free text location of an event
+const Namespace = "https://orijinplus.com.au/epcis/"
+
+const (
+	schemaVersion = "2.0"
+	xmlNamespace  = "urn:epcglobal:epcis:xsd:2"
+	cbvBizStepURN = "urn:epcglobal:cbv:bizstep:"
+	cbvBizStepURL = "https://ref.gs1.org/cbv/BizStep-"
+)
+
+// Event types
+const (
+	ObjectEvent      = "ObjectEvent"
+	AggregationEvent = "AggregationEvent"
+)
+
+// Event actions
+const (
+	ActionAdd     = "ADD"
+	ActionObserve = "OBSERVE"
+	ActionDelete  = "DELETE"
+)
+
+// Document is an EPCIS document, a list of events
+type Document struct {
+	CreationDate time.Time
+	Events       []Event
+}
+
+// Event is an object or aggregation event. Business steps are the bare CBV names, e.g. shipping
+type Event struct {
+	Type           string
+	EventID        string
+	EventTime      time.Time
+	TimeZoneOffset string
+	Action         string
+	BizStep        string
+	EPCList        []string
+	ParentID       string
+	ChildEPCs      []string
+	ReadPoint      string
+	BizLocation    string
+	Location       string
+}
+
+type jsonDocument struct {
+	Context       interface{} `json:"@context"`
+	Type          string      `json:"type"`
+	SchemaVersion string      `json:"schemaVersion"`
+	CreationDate  time.Time   `json:"creationDate"`
+	EPCISBody     jsonBody    `json:"epcisBody"`
+}
+
+type jsonBody struct {
+	EventList []jsonEvent `json:"eventList"`
+}
+
+type jsonEvent struct {
+	Type                string    `json:"type"`
+	EventID             string    `json:"eventID,omitempty"`
+	EventTime           time.Time `json:"eventTime"`
+	EventTimeZoneOffset string    `json:"eventTimeZoneOffset"`
+	EPCList             []string  `json:"epcList,omitempty"`
+	ParentID            string    `json:"parentID,omitempty"`
+	ChildEPCs           []string  `json:"childEPCs,omitempty"`
+	Action              string    `json:"action"`
+	BizStep             string    `json:"bizStep,omitempty"`
+	ReadPoint           *jsonID   `json:"readPoint,omitempty"`
+	BizLocation         *jsonID   `json:"bizLocation,omitempty"`
+	Location            string    `json:"orijin:location,omitempty"`
+}
+
+type jsonID struct {
+	ID string `json:"id"`
+}
+
+type xmlDocument struct {
+	XMLName       xml.Name     `xml:"epcis:EPCISDocument"`
+	Namespace     string       `xml:"xmlns:epcis,attr"`
+	SchemaVersion string       `xml:"schemaVersion,attr"`
+	CreationDate  time.Time    `xml:"creationDate,attr"`
+	EventList     xmlEventList `xml:"EPCISBody>EventList"`
+}
+
+// xmlEventList keeps the events of all types in the order they are listed in
+type xmlEventList struct {
+	Events []Event
+}
+
+type xmlEvent struct {
+	EventTime           time.Time `xml:"eventTime"`
+	EventTimeZoneOffset string    `xml:"eventTimeZoneOffset"`
+	EventID             string    `xml:"eventID,omitempty"`
+	ParentID            string    `xml:"parentID,omitempty"`
+	EPCList             *xmlEPCs  `xml:"epcList"`
+	ChildEPCs           *xmlEPCs  `xml:"childEPCs"`
+	Action              string    `xml:"action"`
+	BizStep             string    `xml:"bizStep,omitempty"`
+	ReadPoint           *xmlID    `xml:"readPoint"`
+	BizLocation         *xmlID    `xml:"bizLocation"`
+	Location            string    `xml:"https://orijinplus.com.au/epcis/ location,omitempty"`
+}
+
+type xmlEPCs struct {
+	EPC []string `xml:"epc"`
+}
+
+type xmlID struct {
+	ID string `xml:"id"`
+}
+
+// JSON writes the document as EPCIS 2.0 JSON-LD
+func (d *Document) JSON() ([]byte, error) {
+	doc := jsonDocument{
+		Context:       []interface{}{Context, map[string]string{"orijin": Namespace}},
+		Type:          "EPCISDocument",
+		SchemaVersion: schemaVersion,
+		CreationDate:  d.CreationDate,
+		EPCISBody:     jsonBody{EventList: make([]jsonEvent, 0, len(d.Events))},
+	}
+	for _, e := range d.Events {
+		doc.EPCISBody.EventList = append(doc.EPCISBody.EventList, toJSON(e))
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// XML writes the document as EPCIS 2.0 XML
+func (d *Document) XML() ([]byte, error) {
+	doc := xmlDocument{
+		Namespace:     xmlNamespace,
+		SchemaVersion: schemaVersion,
+		CreationDate:  d.CreationDate,
+		EventList:     xmlEventList{d.Events},
+	}
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ParseJSON reads an EPCIS 2.0 JSON-LD document
+func ParseJSON(b []byte) (*Document, error) {
+	doc := jsonDocument{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	d := &Document{CreationDate: doc.CreationDate, Events: make([]Event, 0, len(doc.EPCISBody.EventList))}
+	for _, e := range doc.EPCISBody.EventList {
+		event := Event{
+			Type:           e.Type,
+			EventID:        e.EventID,
+			EventTime:      e.EventTime,
+			TimeZoneOffset: e.EventTimeZoneOffset,
+			Action:         e.Action,
+			BizStep:        bizStep(e.BizStep),
+			EPCList:        e.EPCList,
+			ParentID:       e.ParentID,
+			ChildEPCs:      e.ChildEPCs,
+			Location:       e.Location,
+		}
+		if e.ReadPoint != nil {
+			event.ReadPoint = e.ReadPoint.ID
+		}
+		if e.BizLocation != nil {
+			event.BizLocation = e.BizLocation.ID
+		}
+		d.Events = append(d.Events, event)
+	}
+	return d, nil
+}
+
+// ParseXML reads an EPCIS 2.0 XML document
+func ParseXML(b []byte) (*Document, error) {
+	doc := struct {
+		CreationDate time.Time    `xml:"creationDate,attr"`
+		EventList    xmlEventList `xml:"EPCISBody>EventList"`
+	}{}
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return &Document{CreationDate: doc.CreationDate, Events: doc.EventList.Events}, nil
+}
+
+// EventJSON writes a single event as EPCIS 2.0 JSON-LD
+func EventJSON(e Event) ([]byte, error) {
+	return json.Marshal(toJSON(e))
+}
+
+// HashID gets an id for an event sent without one, the hash of the event as JSON-LD
+func HashID(e Event) (string, error) {
+	e.EventID = ""
+	b, err := EventJSON(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return "ni:///sha-256;" + hex.EncodeToString(sum[:]) + "?ver=CBV2.0", nil
+}
+
+func (l xmlEventList) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, e := range l.Events {
+		event := xmlEvent{
+			EventTime:           e.EventTime,
+			EventTimeZoneOffset: e.TimeZoneOffset,
+			EventID:             e.EventID,
+			ParentID:            e.ParentID,
+			Action:              e.Action,
+			Location:            e.Location,
+		}
+		// Object events list their epcs even when there are none
+		if len(e.EPCList) > 0 || e.Type == ObjectEvent {
+			event.EPCList = &xmlEPCs{e.EPCList}
+		}
+		if len(e.ChildEPCs) > 0 {
+			event.ChildEPCs = &xmlEPCs{e.ChildEPCs}
+		}
+		if e.ReadPoint != "" {
+			event.ReadPoint = &xmlID{e.ReadPoint}
+		}
+		if e.BizLocation != "" {
+			event.BizLocation = &xmlID{e.BizLocation}
+		}
+		if e.BizStep != "" {
+			event.BizStep = cbvBizStepURN + e.BizStep
+		}
+		if err := enc.EncodeElement(event, xml.StartElement{Name: xml.Name{Local: e.Type}}); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+func (l *xmlEventList) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			event := xmlEvent{}
+			if err := dec.DecodeElement(&event, &t); err != nil {
+				return err
+			}
+			e := Event{
+				Type:           t.Name.Local,
+				EventID:        event.EventID,
+				EventTime:      event.EventTime,
+				TimeZoneOffset: event.EventTimeZoneOffset,
+				Action:         event.Action,
+				BizStep:        bizStep(event.BizStep),
+				ParentID:       event.ParentID,
+				Location:       event.Location,
+			}
+			if event.EPCList != nil {
+				e.EPCList = event.EPCList.EPC
+			}
+			if event.ChildEPCs != nil {
+				e.ChildEPCs = event.ChildEPCs.EPC
+			}
+			if event.ReadPoint != nil {
+				e.ReadPoint = event.ReadPoint.ID
+			}
+			if event.BizLocation != nil {
+				e.BizLocation = event.BizLocation.ID
+			}
+			l.Events = append(l.Events, e)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func toJSON(e Event) jsonEvent {
+	event := jsonEvent{
+		Type:                e.Type,
+		EventID:             e.EventID,
+		EventTime:           e.EventTime,
+		EventTimeZoneOffset: e.TimeZoneOffset,
+		EPCList:             e.EPCList,
+		ParentID:            e.ParentID,
+		ChildEPCs:           e.ChildEPCs,
+		Action:              e.Action,
+		BizStep:             e.BizStep,
+		Location:            e.Location,
+	}
+	if e.ReadPoint != "" {
+		event.ReadPoint = &jsonID{e.ReadPoint}
+	}
+	if e.BizLocation != "" {
+		event.BizLocation = &jsonID{e.BizLocation}
+	}
+	return event
+}
+
+// bizStep gets the bare CBV name of a business step written as a URN, a URL or a bare name
+func bizStep(step string) string {
+	step = strings.TrimSpace(step)
+	for _, prefix := range []string{cbvBizStepURN, cbvBizStepURL, "cbv:BizStep-", "gs1:BizStep-"} {
+		if strings.HasPrefix(step, prefix) {
+			return strings.TrimPrefix(step, prefix)
+		}
+	}
+	return step
+}
@@ -0,0 +1,190 @@
+package epcis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var brisbane = time.FixedZone("AEST", 10*60*60)
+
+var document = Document{
+	CreationDate: time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC),
+	Events: []Event{
+		{
+			Type:           ObjectEvent,
+			EventID:        "ni:///sha-256;abc?ver=CBV2.0",
+			EventTime:      time.Date(2022, 3, 1, 8, 0, 0, 0, brisbane),
+			TimeZoneOffset: "+10:00",
+			Action:         ActionObserve,
+			BizStep:        "shipping",
+			EPCList:        []string{"urn:epc:id:sgtin:9312345.000001.1", "urn:epc:id:sgtin:9312345.000001.2"},
+			ReadPoint:      "urn:epc:id:sgln:9312345.00001.0",
+			BizLocation:    "urn:epc:id:sgln:9312345.00001.0",
+			Location:       "Dock 3 & 4 <north>",
+		},
+		{
+			Type:           AggregationEvent,
+			EventTime:      time.Date(2022, 3, 1, 7, 15, 30, 0, brisbane),
+			TimeZoneOffset: "+10:00",
+			Action:         ActionAdd,
+			BizStep:        "packing",
+			ParentID:       "urn:epc:id:sscc:9312345.0000000001",
+			ChildEPCs:      []string{"urn:epc:id:sgtin:9312345.000001.1"},
+		},
+		{
+			Type:           ObjectEvent,
+			EventTime:      time.Date(2022, 3, 1, 22, 0, 0, 0, time.UTC),
+			TimeZoneOffset: "+00:00",
+			Action:         ActionDelete,
+		},
+	},
+}
+
+// sameDocument compares documents, times are compared as instants since parsing changes their location
+func sameDocument(t *testing.T, name string, result *Document, expected *Document) {
+	if !result.CreationDate.Equal(expected.CreationDate) {
+		t.Fatalf("%s: creation date is %v, expected %v", name, result.CreationDate, expected.CreationDate)
+	}
+	if len(result.Events) != len(expected.Events) {
+		t.Fatalf("%s: %d events, expected %d", name, len(result.Events), len(expected.Events))
+	}
+	for i := range expected.Events {
+		r, e := result.Events[i], expected.Events[i]
+		if !r.EventTime.Equal(e.EventTime) {
+			t.Fatalf("%s: event %d time is %v, expected %v", name, i, r.EventTime, e.EventTime)
+		}
+		r.EventTime, e.EventTime = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(r, e) {
+			t.Fatalf("%s: event %d is %+v, expected %+v", name, i, r, e)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b, err := document.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %s", err)
+	}
+	for _, expected := range []string{Context, `"orijin": "` + Namespace + `"`, `"orijin:location": `, `"bizStep": "shipping"`} {
+		if !strings.Contains(string(b), expected) {
+			t.Fatalf("JSON: %s is missing %s", b, expected)
+		}
+	}
+
+	d, err := ParseJSON(b)
+	if err != nil {
+		t.Fatalf("ParseJSON: %s", err)
+	}
+	sameDocument(t, "ParseJSON", d, &document)
+}
+
+func TestXML(t *testing.T) {
+	b, err := document.XML()
+	if err != nil {
+		t.Fatalf("XML: %s", err)
+	}
+	for _, expected := range []string{`<epcis:EPCISDocument xmlns:epcis="` + xmlNamespace + `"`, "<bizStep>" + cbvBizStepURN + "shipping</bizStep>", "<epcList></epcList>"} {
+		if !strings.Contains(string(b), expected) {
+			t.Fatalf("XML: %s is missing %s", b, expected)
+		}
+	}
+
+	d, err := ParseXML(b)
+	if err != nil {
+		t.Fatalf("ParseXML: %s", err)
+	}
+	// An empty epc list reads back as an empty list
+	expected := document
+	expected.Events = append([]Event{}, document.Events...)
+	expected.Events[2].EPCList = d.Events[2].EPCList
+	if len(expected.Events[2].EPCList) != 0 {
+		t.Fatalf("ParseXML: event 2 epcs are %v, expected none", expected.Events[2].EPCList)
+	}
+	sameDocument(t, "ParseXML", d, &expected)
+}
+
+// TestParseJSON reads a document written by another system, with a single context and business steps as URLs
+func TestParseJSON(t *testing.T) {
+	b := []byte(`{
+		"@context": "https://ref.gs1.org/standards/epcis/2.0.0/epcis-context.jsonld",
+		"type": "EPCISDocument",
+		"schemaVersion": "2.0",
+		"creationDate": "2022-03-01T09:30:00Z",
+		"epcisBody": {"eventList": [{
+			"type": "ObjectEvent",
+			"eventTime": "2022-03-01T08:00:00+10:00",
+			"eventTimeZoneOffset": "+10:00",
+			"epcList": ["urn:epc:id:sgtin:9312345.000001.1"],
+			"action": "OBSERVE",
+			"bizStep": "https://ref.gs1.org/cbv/BizStep-receiving",
+			"readPoint": {"id": "urn:epc:id:sgln:9312345.00001.0"}
+		}]}
+	}`)
+	d, err := ParseJSON(b)
+	if err != nil {
+		t.Fatalf("ParseJSON: %s", err)
+	}
+	sameDocument(t, "ParseJSON", d, &Document{
+		CreationDate: time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC),
+		Events: []Event{{
+			Type:           ObjectEvent,
+			EventTime:      time.Date(2022, 2, 28, 22, 0, 0, 0, time.UTC),
+			TimeZoneOffset: "+10:00",
+			Action:         ActionObserve,
+			BizStep:        "receiving",
+			EPCList:        []string{"urn:epc:id:sgtin:9312345.000001.1"},
+			ReadPoint:      "urn:epc:id:sgln:9312345.00001.0",
+		}},
+	})
+
+	if _, err := ParseJSON([]byte(`{"epcisBody": [`)); err == nil {
+		t.Fatalf("ParseJSON: truncated document is expected to be invalid")
+	}
+}
+
+type bizStepResult struct {
+	step     string
+	expected string
+}
+
+var bizStepResults = []bizStepResult{
+	{"shipping", "shipping"},
+	{" shipping ", "shipping"},
+	{"urn:epcglobal:cbv:bizstep:shipping", "shipping"},
+	{"https://ref.gs1.org/cbv/BizStep-receiving", "receiving"},
+	{"cbv:BizStep-packing", "packing"},
+	{"gs1:BizStep-commissioning", "commissioning"},
+	{"", ""},
+}
+
+func TestBizStep(t *testing.T) {
+	for _, test := range bizStepResults {
+		if result := bizStep(test.step); result != test.expected {
+			t.Fatalf("bizStep: %q is %q, expected %q", test.step, result, test.expected)
+		}
+	}
+}
+
+func TestHashID(t *testing.T) {
+	e := document.Events[0]
+	id, err := HashID(e)
+	if err != nil {
+		t.Fatalf("HashID: %s", err)
+	}
+	if !strings.HasPrefix(id, "ni:///sha-256;") || !strings.HasSuffix(id, "?ver=CBV2.0") || len(id) != len("ni:///sha-256;?ver=CBV2.0")+64 {
+		t.Fatalf("HashID: %s is not a hash id", id)
+	}
+
+	// The id sent with the event is not part of the hash
+	e.EventID = ""
+	if same, _ := HashID(e); same != id {
+		t.Fatalf("HashID: %s changed to %s without the event id", id, same)
+	}
+
+	e.Action = ActionAdd
+	if other, _ := HashID(e); other == id {
+		t.Fatalf("HashID: different events have the same id %s", id)
+	}
+}
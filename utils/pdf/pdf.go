@@ -0,0 +1,175 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	margin     = 40
+	fontSize   = 9
+	leading    = 12
+)
+
+// MM is the number of points in a millimetre
+const MM = 72 / 25.4
+
+// LinesPerPage is the number of text lines fitting on an A4 page
+const LinesPerPage = (pageHeight - 2*margin) / leading
+
+// CharsPerLine is the number of characters fitting on a line, longer lines are cut
+const CharsPerLine = (pageWidth - 2*margin) * 10 / (fontSize * 6)
+
+// Document is a PDF document written in a monospaced font, so columns can be lined up with padded strings.
+// Text can flow line by line, or text and filled rectangles can be drawn at given positions
+type Document struct {
+	width  float64
+	height float64
+	pages  []*page
+}
+
+type page struct {
+	lines []string
+	ops   []string
+}
+
+// New creates an empty A4 document
+func New() *Document {
+	return &Document{width: pageWidth, height: pageHeight}
+}
+
+// NewSized creates an empty document with pages of the given size in points
+func NewSized(width float64, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// Line appends a line of text, starting a new page when the current one is full
+func (d *Document) Line(text string) {
+	if len(d.pages) == 0 || len(d.current().lines) == d.linesPerPage() {
+		d.PageBreak()
+	}
+	p := d.current()
+	p.lines = append(p.lines, text)
+}
+
+// PageBreak starts a new page
+func (d *Document) PageBreak() {
+	d.pages = append(d.pages, &page{})
+}
+
+// Rect draws a filled black rectangle on the current page,
+// positions are in points from the top left corner of the page
+func (d *Document) Rect(x, y, w, h float64) {
+	p := d.current()
+	p.ops = append(p.ops, fmt.Sprintf("%s %s %s %s re f", num(x), num(d.height-y-h), num(w), num(h)))
+}
+
+// Text draws a line of text on the current page with its baseline at y points from the top of the page
+func (d *Document) Text(x, y, size float64, text string) {
+	p := d.current()
+	p.ops = append(p.ops, fmt.Sprintf("BT /F1 %s Tf %s %s Td (%s) Tj ET", num(size), num(x), num(d.height-y), escape(text, len(text))))
+}
+
+// TextWidth is the width in points of a text drawn at the given size
+func TextWidth(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.6
+}
+
+// Bytes writes the document as a PDF file
+func (d *Document) Bytes() []byte {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*page{{}}
+	}
+
+	// Objects 1 to 3 are the catalog, the page tree and the font,
+	// each page then takes a page object followed by its content stream
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>",
+	}
+	kids := make([]string, len(pages))
+	for i, p := range pages {
+		pageID := 4 + i*2
+		kids[i] = fmt.Sprintf("%d 0 R", pageID)
+
+		var content bytes.Buffer
+		if len(p.lines) > 0 {
+			fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %s Td\n", fontSize, leading, margin, num(d.height-margin-fontSize))
+			for _, line := range p.lines {
+				fmt.Fprintf(&content, "(%s) '\n", escape(line, CharsPerLine))
+			}
+			content.WriteString("ET\n")
+		}
+		for _, op := range p.ops {
+			content.WriteString(op + "\n")
+		}
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", num(d.width), num(d.height), pageID+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func (d *Document) current() *page {
+	if len(d.pages) == 0 {
+		d.PageBreak()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) linesPerPage() int {
+	return int((d.height - 2*margin) / leading)
+}
+
+// num writes a number with at most two decimals
+func num(f float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", f), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escape cuts a text to max characters and escapes it for a PDF string,
+// characters outside of ASCII are not supported by the standard fonts and are replaced
+func escape(text string, max int) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range text {
+		if n == max {
+			break
+		}
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+		n++
+	}
+	return b.String()
+}
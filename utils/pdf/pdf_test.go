@@ -0,0 +1,155 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type numResult struct {
+	f        float64
+	expected string
+}
+
+var numResults = []numResult{
+	{0, "0"},
+	{12, "12"},
+	{12.5, "12.5"},
+	{12.345, "12.35"},
+	{-3.1, "-3.1"},
+	{841.89, "841.89"},
+}
+
+func TestNum(t *testing.T) {
+	for _, test := range numResults {
+		if result := num(test.f); result != test.expected {
+			t.Fatalf("num: %v is %s, expected %s", test.f, result, test.expected)
+		}
+	}
+}
+
+type escapeResult struct {
+	text     string
+	max      int
+	expected string
+}
+
+var escapeResults = []escapeResult{
+	{"plain text", 20, "plain text"},
+	{"cut here", 3, "cut"},
+	{`a (b) \c`, 20, `a \(b\) \\c`},
+	{"café\tbar", 20, "caf??bar"},
+	{"日本語", 2, "??"},
+}
+
+func TestEscape(t *testing.T) {
+	for _, test := range escapeResults {
+		if result := escape(test.text, test.max); result != test.expected {
+			t.Fatalf("escape: %q is %q, expected %q", test.text, result, test.expected)
+		}
+	}
+}
+
+type documentResult struct {
+	name  string
+	build func(d *Document)
+	pages int
+}
+
+var documentResults = []documentResult{
+	{"empty", func(d *Document) {}, 1},
+	{"lines", func(d *Document) {
+		d.Line("Manifest (draft)")
+		d.Line(`C:\path`)
+	}, 1},
+	{"overflowing lines", func(d *Document) {
+		for i := 0; i < LinesPerPage+1; i++ {
+			d.Line(fmt.Sprintf("line %d", i))
+		}
+	}, 2},
+	{"drawing", func(d *Document) {
+		d.Rect(10, 10, 20.5, 5)
+		d.Text(10, 40, 8, "PAL-000001")
+		d.PageBreak()
+		d.Rect(0, 0, 1, 1)
+	}, 2},
+}
+
+var (
+	startXRef = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	pageCount = regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`)
+	stream    = regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`)
+)
+
+// TestBytes checks the xref table points at every object and the stream lengths match their content
+func TestBytes(t *testing.T) {
+	for _, test := range documentResults {
+		d := New()
+		test.build(d)
+		b := d.Bytes()
+
+		if !bytes.HasPrefix(b, []byte("%PDF-1.4\n")) {
+			t.Fatalf("Bytes: %s has no PDF header", test.name)
+		}
+
+		match := startXRef.FindSubmatch(b)
+		if match == nil {
+			t.Fatalf("Bytes: %s has no startxref", test.name)
+		}
+		xref, _ := strconv.Atoi(string(match[1]))
+		lines := strings.Split(string(b[xref:]), "\n")
+		if lines[0] != "xref" {
+			t.Fatalf("Bytes: %s startxref points at %q", test.name, lines[0])
+		}
+
+		var first, size int
+		fmt.Sscanf(lines[1], "%d %d", &first, &size)
+		objects := 3 + 2*test.pages
+		if first != 0 || size != objects+1 {
+			t.Fatalf("Bytes: %s xref lists %d entries, expected %d", test.name, size, objects+1)
+		}
+		if lines[2] != "0000000000 65535 f " {
+			t.Fatalf("Bytes: %s xref entry 0 is %q", test.name, lines[2])
+		}
+		for i := 1; i <= objects; i++ {
+			entry := lines[2+i]
+			if len(entry) != 19 || !strings.HasSuffix(entry, " 00000 n ") {
+				t.Fatalf("Bytes: %s xref entry %d is %q", test.name, i, entry)
+			}
+			offset, _ := strconv.Atoi(entry[:10])
+			if !bytes.HasPrefix(b[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i))) {
+				t.Fatalf("Bytes: %s xref entry %d does not point at its object", test.name, i)
+			}
+		}
+		if !strings.Contains(string(b[xref:]), fmt.Sprintf("/Size %d /Root 1 0 R", objects+1)) {
+			t.Fatalf("Bytes: %s trailer has the wrong size", test.name)
+		}
+
+		count := pageCount.FindSubmatch(b)
+		if count == nil || string(count[1]) != strconv.Itoa(test.pages) {
+			t.Fatalf("Bytes: %s page count is not %d", test.name, test.pages)
+		}
+
+		streams := stream.FindAllSubmatch(b, -1)
+		if len(streams) != test.pages {
+			t.Fatalf("Bytes: %s has %d content streams, expected %d", test.name, len(streams), test.pages)
+		}
+		for _, s := range streams {
+			length, _ := strconv.Atoi(string(s[1]))
+			if length != len(s[2]) {
+				t.Fatalf("Bytes: %s stream length is %d, content is %d bytes", test.name, length, len(s[2]))
+			}
+		}
+	}
+}
+
+func TestRect(t *testing.T) {
+	d := NewSized(100, 50)
+	d.Rect(10, 5, 20, 15)
+	if op := d.current().ops[0]; op != "10 30 20 15 re f" {
+		t.Fatalf("Rect: %q is not expected result", op)
+	}
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type allowResult struct {
+	key     string
+	after   time.Duration
+	allowed bool
+	wait    time.Duration
+}
+
+// A limiter of 1 token per second with a burst of 2, each request happens after the previous one
+var allowResults = []allowResult{
+	{"a", 0, true, 0},
+	{"a", 0, true, 0},
+	{"a", 0, false, time.Second},
+	{"b", 0, true, 0},
+	{"a", 500 * time.Millisecond, false, 500 * time.Millisecond},
+	{"a", 500 * time.Millisecond, true, 0},
+	{"a", 0, false, time.Second},
+	{"a", 10 * time.Second, true, 0},
+	{"a", 0, true, 0},
+	{"a", 0, false, time.Second},
+}
+
+func TestAllowAt(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(1, 2)
+	l.swept = start
+
+	now := start
+	for i, test := range allowResults {
+		now = now.Add(test.after)
+		allowed, wait := l.allowAt(test.key, now)
+		if allowed != test.allowed || wait != test.wait {
+			t.Fatalf("allowAt: case %d is %t after %v, expected %t after %v", i, allowed, wait, test.allowed, test.wait)
+		}
+	}
+
+	// Full buckets are forgotten once a refill period passed
+	l.allowAt("c", now.Add(time.Minute))
+	if len(l.buckets) != 1 {
+		t.Fatalf("allowAt: %d buckets are kept, expected 1", len(l.buckets))
+	}
+}
+
+type clientIPResult struct {
+	remoteAddr string
+	forwarded  string
+	expected   string
+}
+
+var clientIPResults = []clientIPResult{
+	// Untrusted clients cannot pick their address
+	{"203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+	// The load balancer appends the address it received the request from
+	{"10.0.0.2:5000", "203.0.113.7", "203.0.113.7"},
+	{"10.0.0.2:5000", "198.51.100.1, 203.0.113.7", "203.0.113.7"},
+	// Chained proxies are skipped
+	{"10.0.0.2:5000", "198.51.100.1, 203.0.113.7, 10.0.0.3", "203.0.113.7"},
+	{"10.0.0.2:5000", "", "10.0.0.2"},
+	{"10.0.0.2:5000", "unknown", "10.0.0.2"},
+	{"192.0.2.10:5000", "203.0.113.7", "203.0.113.7"},
+}
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseProxies("10.0.0.0/24, 192.0.2.10")
+	if err != nil {
+		t.Fatalf("ParseProxies: %s", err)
+	}
+
+	for i, test := range clientIPResults {
+		r := &http.Request{RemoteAddr: test.remoteAddr, Header: http.Header{}}
+		if test.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+		r.Header.Set("X-Real-IP", "198.51.100.99")
+		if client := ClientIP(r, trusted); client != test.expected {
+			t.Fatalf("ClientIP: case %d is %s, expected %s", i, client, test.expected)
+		}
+	}
+
+	if _, err := ParseProxies("10.0.0.0/33"); err == nil {
+		t.Fatalf("ParseProxies: 10.0.0.0/33 is expected to be invalid")
+	}
+}